@@ -2,6 +2,7 @@ package checkpoint
 
 import (
 	"context"
+	"io"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/client"
@@ -12,6 +13,8 @@ type fakeClient struct {
 	checkpointCreateFunc func(container string, options types.CheckpointCreateOptions) error
 	checkpointDeleteFunc func(container string, options types.CheckpointDeleteOptions) error
 	checkpointListFunc   func(container string, options types.CheckpointListOptions) ([]types.Checkpoint, error)
+	checkpointExportFunc func(container string, options types.CheckpointExportOptions) (io.ReadCloser, error)
+	checkpointImportFunc func(container string, options types.CheckpointImportOptions, input io.Reader) error
 }
 
 func (cli *fakeClient) CheckpointCreate(ctx context.Context, container string, options types.CheckpointCreateOptions) error {
@@ -34,3 +37,17 @@ func (cli *fakeClient) CheckpointList(ctx context.Context, container string, opt
 	}
 	return []types.Checkpoint{}, nil
 }
+
+func (cli *fakeClient) CheckpointExport(ctx context.Context, container string, options types.CheckpointExportOptions) (io.ReadCloser, error) {
+	if cli.checkpointExportFunc != nil {
+		return cli.checkpointExportFunc(container, options)
+	}
+	return nil, nil
+}
+
+func (cli *fakeClient) CheckpointImport(ctx context.Context, container string, options types.CheckpointImportOptions, input io.Reader) error {
+	if cli.checkpointImportFunc != nil {
+		return cli.checkpointImportFunc(container, options, input)
+	}
+	return nil
+}
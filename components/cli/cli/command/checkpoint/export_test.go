@@ -0,0 +1,49 @@
+package checkpoint
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/fs"
+)
+
+func TestCheckpointExportErrors(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	cmd := newExportCommand(cli)
+	cmd.SetArgs([]string{"container-foo"})
+	cmd.SetOutput(ioutil.Discard)
+	assert.ErrorContains(t, cmd.Execute(), "requires exactly 2 arguments")
+}
+
+func TestCheckpointExportOutputToFile(t *testing.T) {
+	dir := fs.NewDir(t, "checkpoint-export-test")
+	defer dir.Remove()
+
+	var containerID string
+	var options types.CheckpointExportOptions
+	cli := test.NewFakeCli(&fakeClient{
+		checkpointExportFunc: func(container string, opts types.CheckpointExportOptions) (io.ReadCloser, error) {
+			containerID = container
+			options = opts
+			return ioutil.NopCloser(strings.NewReader("checkpoint archive")), nil
+		},
+	})
+	cmd := newExportCommand(cli)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"-o", dir.Join("checkpoint.tar"), "--checkpoint-dir", "/dir/foo", "container-foo", "checkpoint-bar"})
+	assert.NilError(t, cmd.Execute())
+
+	assert.Check(t, is.Equal("container-foo", containerID))
+	assert.Check(t, is.Equal("checkpoint-bar", options.CheckpointID))
+	assert.Check(t, is.Equal("/dir/foo", options.CheckpointDir))
+	expected := fs.Expected(t,
+		fs.WithFile("checkpoint.tar", "checkpoint archive", fs.MatchAnyFileMode),
+	)
+	assert.Assert(t, fs.Equal(dir.Path(), expected))
+}
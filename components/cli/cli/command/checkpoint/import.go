@@ -0,0 +1,70 @@
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/system"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type importOptions struct {
+	container     string
+	checkpoint    string
+	checkpointDir string
+	input         string
+}
+
+func newImportCommand(dockerCli command.Cli) *cobra.Command {
+	var opts importOptions
+
+	cmd := &cobra.Command{
+		Use:   "import [OPTIONS] CONTAINER CHECKPOINT",
+		Short: "Import a checkpoint in a container from a tar archive or STDIN",
+		Args:  cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.container = args[0]
+			opts.checkpoint = args[1]
+			return runImport(dockerCli, opts)
+		},
+		Annotations: map[string]string{"version": "1.40"},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.input, "input", "i", "", "Read from tar archive file, instead of STDIN")
+	flags.StringVarP(&opts.checkpointDir, "checkpoint-dir", "", "", "Use a custom checkpoint storage directory")
+
+	return cmd
+}
+
+func runImport(dockerCli command.Cli, opts importOptions) error {
+	var input io.Reader = dockerCli.In()
+	if opts.input != "" {
+		file, err := system.OpenSequential(opts.input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	if opts.input == "" && dockerCli.In().IsTerminal() {
+		return errors.Errorf("requested import from stdin, but stdin is empty")
+	}
+
+	err := dockerCli.Client().CheckpointImport(context.Background(), opts.container, types.CheckpointImportOptions{
+		CheckpointID:  opts.checkpoint,
+		CheckpointDir: opts.checkpointDir,
+	}, input)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(dockerCli.Out(), "%s\n", opts.checkpoint)
+	return nil
+}
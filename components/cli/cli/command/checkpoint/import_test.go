@@ -0,0 +1,74 @@
+package checkpoint
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/fs"
+)
+
+func TestCheckpointImportErrors(t *testing.T) {
+	testCases := []struct {
+		args                 []string
+		checkpointImportFunc func(container string, options types.CheckpointImportOptions, input io.Reader) error
+		expectedError        string
+	}{
+		{
+			args:          []string{"too-few-arguments"},
+			expectedError: "requires exactly 2 arguments",
+		},
+		{
+			args:          []string{"-i", "/does/not/exist", "foo", "bar"},
+			expectedError: "no such file or directory",
+		},
+		{
+			args: []string{"-i", "import_test.go", "foo", "bar"},
+			checkpointImportFunc: func(container string, options types.CheckpointImportOptions, input io.Reader) error {
+				return errors.Errorf("error importing checkpoint")
+			},
+			expectedError: "error importing checkpoint",
+		},
+	}
+
+	for _, tc := range testCases {
+		cli := test.NewFakeCli(&fakeClient{
+			checkpointImportFunc: tc.checkpointImportFunc,
+		})
+		cmd := newImportCommand(cli)
+		cmd.SetArgs(tc.args)
+		cmd.SetOutput(ioutil.Discard)
+		assert.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestCheckpointImportFromFile(t *testing.T) {
+	file := fs.NewFile(t, "checkpoint-import-test", fs.WithContent("checkpoint archive"))
+	defer file.Remove()
+
+	var containerID, content string
+	var options types.CheckpointImportOptions
+	cli := test.NewFakeCli(&fakeClient{
+		checkpointImportFunc: func(container string, opts types.CheckpointImportOptions, input io.Reader) error {
+			containerID = container
+			options = opts
+			b, err := ioutil.ReadAll(input)
+			content = string(b)
+			return err
+		},
+	})
+	cmd := newImportCommand(cli)
+	cmd.SetArgs([]string{"-i", file.Path(), "container-foo", "checkpoint-bar"})
+	assert.NilError(t, cmd.Execute())
+
+	assert.Check(t, is.Equal("container-foo", containerID))
+	assert.Check(t, is.Equal("checkpoint-bar", options.CheckpointID))
+	assert.Check(t, is.Equal("checkpoint archive", content))
+	assert.Check(t, is.Equal("checkpoint-bar", strings.TrimSpace(cli.OutBuffer().String())))
+}
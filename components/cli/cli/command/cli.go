@@ -16,6 +16,7 @@ import (
 	cliconfig "github.com/docker/cli/cli/config"
 	"github.com/docker/cli/cli/config/configfile"
 	"github.com/docker/cli/cli/connhelper"
+	contextstore "github.com/docker/cli/cli/context/store"
 	cliflags "github.com/docker/cli/cli/flags"
 	manifeststore "github.com/docker/cli/cli/manifest/store"
 	registryclient "github.com/docker/cli/cli/registry/client"
@@ -57,6 +58,9 @@ type Cli interface {
 	RegistryClient(bool) registryclient.RegistryClient
 	ContentTrustEnabled() bool
 	NewContainerizedEngineClient(sockPath string) (clitypes.ContainerizedClient, error)
+	ContextStore() contextstore.Store
+	CurrentContext() string
+	HostClients() []HostClient
 }
 
 // DockerCli is an instance the docker command line client.
@@ -71,6 +75,8 @@ type DockerCli struct {
 	clientInfo            ClientInfo
 	contentTrust          bool
 	newContainerizeClient func(string) (clitypes.ContainerizedClient, error)
+	currentContext        string
+	hostClients           []HostClient
 }
 
 // DefaultVersion returns api.defaultVersion or DOCKER_API_VERSION if specified.
@@ -154,6 +160,22 @@ func (cli *DockerCli) ManifestStore() manifeststore.Store {
 	return manifeststore.NewStore(filepath.Join(config.Dir(), "manifests"))
 }
 
+// ContextStore returns the store of the contexts the cli can connect with
+func (cli *DockerCli) ContextStore() contextstore.Store {
+	return contextstore.NewStore(filepath.Join(config.Dir(), "contexts"))
+}
+
+// CurrentContext returns the name of the context the cli is connected with
+func (cli *DockerCli) CurrentContext() string {
+	return cli.currentContext
+}
+
+// HostClients returns the clients of the daemons selected with the --hosts
+// flag, or nil if the flag is not set.
+func (cli *DockerCli) HostClients() []HostClient {
+	return cli.hostClients
+}
+
 // RegistryClient returns a client for communicating with a Docker distribution
 // registry
 func (cli *DockerCli) RegistryClient(allowInsecure bool) registryclient.RegistryClient {
@@ -169,18 +191,22 @@ func (cli *DockerCli) Initialize(opts *cliflags.ClientOptions) error {
 	cli.configFile = cliconfig.LoadDefaultConfigFile(cli.err)
 
 	var err error
-	cli.client, err = NewAPIClientFromFlags(opts.Common, cli.configFile)
-	if tlsconfig.IsErrEncryptedKey(err) {
-		passRetriever := passphrase.PromptRetrieverWithInOut(cli.In(), cli.Out(), nil)
-		newClient := func(password string) (client.APIClient, error) {
-			opts.Common.TLSOptions.Passphrase = password
-			return NewAPIClientFromFlags(opts.Common, cli.configFile)
-		}
-		cli.client, err = getClientWithPassword(passRetriever, newClient)
+	cli.currentContext, err = resolveContextName(opts.Common, cli.configFile)
+	if err != nil {
+		return err
 	}
+	cli.hostClients, err = newHostClients(opts.Common, cli.configFile, cli.ContextStore())
 	if err != nil {
 		return err
 	}
+	if len(cli.hostClients) > 0 {
+		// Commands run against the daemons of the --hosts flag through
+		// HostClients, the client of the first one is used to check which
+		// features are supported.
+		cli.client = cli.hostClients[0].Client
+	} else if err := cli.initializeClient(opts.Common); err != nil {
+		return err
+	}
 	var experimentalValue string
 	// Environment variable always overrides configuration
 	if experimentalValue = os.Getenv("DOCKER_CLI_EXPERIMENTAL"); experimentalValue == "" {
@@ -198,6 +224,23 @@ func (cli *DockerCli) Initialize(opts *cliflags.ClientOptions) error {
 	return nil
 }
 
+func (cli *DockerCli) initializeClient(opts *cliflags.CommonOptions) error {
+	commonOpts, err := contextCommonOptions(opts, cli.ContextStore(), cli.currentContext)
+	if err != nil {
+		return err
+	}
+	cli.client, err = NewAPIClientFromFlags(commonOpts, cli.configFile)
+	if tlsconfig.IsErrEncryptedKey(err) {
+		passRetriever := passphrase.PromptRetrieverWithInOut(cli.In(), cli.Out(), nil)
+		newClient := func(password string) (client.APIClient, error) {
+			commonOpts.TLSOptions.Passphrase = password
+			return NewAPIClientFromFlags(commonOpts, cli.configFile)
+		}
+		cli.client, err = getClientWithPassword(passRetriever, newClient)
+	}
+	return err
+}
+
 func isEnabled(value string) (bool, error) {
 	switch value {
 	case "enabled":
@@ -301,9 +344,11 @@ func NewAPIClientFromFlags(opts *cliflags.CommonOptions, configFile *configfile.
 		clientOpts = append(clientOpts, client.WithDialContext(helper.Dialer))
 	}
 
-	customHeaders := configFile.HTTPHeaders
-	if customHeaders == nil {
-		customHeaders = map[string]string{}
+	// Copy the headers so that the User-Agent is not saved in the
+	// configuration file
+	customHeaders := map[string]string{}
+	for k, v := range configFile.HTTPHeaders {
+		customHeaders[k] = v
 	}
 	customHeaders["User-Agent"] = UserAgent()
 	clientOpts = append(clientOpts, client.WithHTTPHeaders(customHeaders))
@@ -321,13 +366,16 @@ func getServerHost(hosts []string, tlsOptions *tlsconfig.Options) (string, error
 	var host string
 	switch len(hosts) {
 	case 0:
-		host = os.Getenv("DOCKER_HOST")
+		host = os.Getenv(envVarDockerHost)
 	case 1:
 		host = hosts[0]
 	default:
 		return "", errors.New("Please specify only one -H")
 	}
 
+	if connhelper.IsConnectionHelperURL(host) {
+		return host, nil
+	}
 	return dopts.ParseHost(tlsOptions != nil, host)
 }
 
@@ -63,6 +63,13 @@ func TestNewAPIClientFromFlagsForDefaultSchema(t *testing.T) {
 	assert.Check(t, is.Equal(api.DefaultVersion, apiclient.ClientVersion()))
 }
 
+func TestNewAPIClientFromFlagsWithConnectionHelper(t *testing.T) {
+	opts := &flags.CommonOptions{Hosts: []string{"cmd://kubectl exec -i dind -- docker system dial-stdio"}}
+	apiclient, err := NewAPIClientFromFlags(opts, &configfile.ConfigFile{})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("http://docker", apiclient.DaemonHost()))
+}
+
 func TestNewAPIClientFromFlagsWithAPIVersionFromEnv(t *testing.T) {
 	customVersion := "v3.3.3"
 	defer env.Patch(t, "DOCKER_API_VERSION", customVersion)()
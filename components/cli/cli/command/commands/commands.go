@@ -9,6 +9,7 @@ import (
 	"github.com/docker/cli/cli/command/checkpoint"
 	"github.com/docker/cli/cli/command/config"
 	"github.com/docker/cli/cli/command/container"
+	"github.com/docker/cli/cli/command/context"
 	"github.com/docker/cli/cli/command/engine"
 	"github.com/docker/cli/cli/command/image"
 	"github.com/docker/cli/cli/command/manifest"
@@ -39,6 +40,9 @@ func AddCommands(cmd *cobra.Command, dockerCli command.Cli) {
 		container.NewContainerCommand(dockerCli),
 		container.NewRunCommand(dockerCli),
 
+		// context
+		context.NewContextCommand(dockerCli),
+
 		// image
 		image.NewImageCommand(dockerCli),
 		image.NewBuildCommand(dockerCli),
@@ -29,6 +29,7 @@ type fakeClient struct {
 	containerListFunc       func(types.ContainerListOptions) ([]types.Container, error)
 	containerExportFunc     func(string) (io.ReadCloser, error)
 	containerExecResizeFunc func(id string, options types.ResizeOptions) error
+	containerStatsFunc      func(container string, options types.ContainerStatsOptions) (types.ContainerStats, error)
 	Version                 string
 }
 
@@ -143,3 +144,10 @@ func (f *fakeClient) ContainerExecResize(_ context.Context, id string, options t
 	}
 	return nil
 }
+
+func (f *fakeClient) ContainerStatsWithOptions(_ context.Context, container string, options types.ContainerStatsOptions) (types.ContainerStats, error) {
+	if f.containerStatsFunc != nil {
+		return f.containerStatsFunc(container, options)
+	}
+	return types.ContainerStats{}, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/docker/cli/opts"
 	"github.com/docker/cli/templates"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
 	"github.com/spf13/cobra"
 )
 
@@ -35,6 +36,7 @@ func NewPsCommand(dockerCli command.Cli) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runPs(dockerCli, &options)
 		},
+		Annotations: map[string]string{"hosts": ""},
 	}
 
 	flags := cmd.Flags()
@@ -117,11 +119,6 @@ func runPs(dockerCli command.Cli, options *psOptions) error {
 		return err
 	}
 
-	containers, err := dockerCli.Client().ContainerList(ctx, *listOptions)
-	if err != nil {
-		return err
-	}
-
 	format := options.format
 	if len(format) == 0 {
 		if len(dockerCli.ConfigFile().PsFormat) > 0 && !options.quiet {
@@ -136,5 +133,27 @@ func runPs(dockerCli command.Cli, options *psOptions) error {
 		Format: formatter.NewContainerFormat(format, options.quiet, listOptions.Size),
 		Trunc:  !options.noTrunc,
 	}
+
+	if hostClients := dockerCli.HostClients(); len(hostClients) > 0 {
+		hostContainers := make([]formatter.HostContainers, len(hostClients))
+		err := command.RunOnHosts(ctx, hostClients, func(ctx context.Context, i int, apiClient client.APIClient) error {
+			containers, err := apiClient.ContainerList(ctx, *listOptions)
+			if err != nil {
+				return err
+			}
+			hostContainers[i] = formatter.HostContainers{Host: hostClients[i].Host, Containers: containers}
+			return nil
+		})
+		containerCtx.Format = formatter.HostFormat(format, containerCtx.Format)
+		if writeErr := formatter.HostContainersWrite(containerCtx, hostContainers); writeErr != nil {
+			return writeErr
+		}
+		return err
+	}
+
+	containers, err := dockerCli.Client().ContainerList(ctx, *listOptions)
+	if err != nil {
+		return err
+	}
 	return formatter.ContainerWrite(containerCtx, containers)
 }
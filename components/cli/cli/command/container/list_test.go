@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"testing"
 
+	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/config/configfile"
 	"github.com/docker/cli/internal/test"
 	"github.com/docker/docker/api/types"
@@ -162,3 +163,36 @@ func TestContainerListWithFormat(t *testing.T) {
 	assert.NilError(t, cmd.Execute())
 	golden.Assert(t, cli.OutBuffer().String(), "container-list-with-format.golden")
 }
+
+func TestContainerListWithHosts(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	cli.SetHostClients([]command.HostClient{
+		{
+			Host: "tcp://host1:2375",
+			Client: &fakeClient{
+				containerListFunc: func(_ types.ContainerListOptions) ([]types.Container, error) {
+					return []types.Container{*Container("c1"), *Container("c2", WithName("foo"))}, nil
+				},
+			},
+		},
+		{
+			Host: "tcp://host2:2375",
+			Client: &fakeClient{
+				containerListFunc: func(_ types.ContainerListOptions) ([]types.Container, error) {
+					return nil, fmt.Errorf("error listing containers")
+				},
+			},
+		},
+		{
+			Host: "remote",
+			Client: &fakeClient{
+				containerListFunc: func(_ types.ContainerListOptions) ([]types.Container, error) {
+					return []types.Container{*Container("c3", WithPort(80, 80, TCP))}, nil
+				},
+			},
+		},
+	})
+	cmd := newListCommand(cli)
+	assert.Error(t, cmd.Execute(), "tcp://host2:2375: error listing containers")
+	golden.Assert(t, cli.OutBuffer().String(), "container-list-with-hosts.golden")
+}
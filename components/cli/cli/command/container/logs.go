@@ -3,11 +3,13 @@ package container
 import (
 	"context"
 	"io"
+	"text/template"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +20,9 @@ type logsOptions struct {
 	timestamps bool
 	details    bool
 	tail       string
+	format     string
+	grep       string
+	exclude    string
 
 	container string
 }
@@ -44,12 +49,28 @@ func NewLogsCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVarP(&opts.timestamps, "timestamps", "t", false, "Show timestamps")
 	flags.BoolVar(&opts.details, "details", false, "Show extra details provided to logs")
 	flags.StringVar(&opts.tail, "tail", "all", "Number of lines to show from the end of the logs")
+	flags.StringVar(&opts.format, "format", "", "Format each line using a Go template")
+	flags.StringVar(&opts.grep, "grep", "", "Only show lines matching a regular expression")
+	flags.SetAnnotation("grep", "version", []string{"1.40"})
+	flags.StringVar(&opts.exclude, "exclude", "", "Do not show lines matching a regular expression")
+	flags.SetAnnotation("exclude", "version", []string{"1.40"})
 	return cmd
 }
 
 func runLogs(dockerCli command.Cli, opts *logsOptions) error {
 	ctx := context.Background()
 
+	var tmpl *template.Template
+	if opts.format != "" {
+		if opts.details {
+			return errors.New("--format cannot be used with --details")
+		}
+		var err error
+		if tmpl, err = makeLogTemplate(opts.format); err != nil {
+			return errors.Wrap(err, "invalid --format")
+		}
+	}
+
 	options := types.ContainerLogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
@@ -59,6 +80,8 @@ func runLogs(dockerCli command.Cli, opts *logsOptions) error {
 		Follow:     opts.follow,
 		Tail:       opts.tail,
 		Details:    opts.details,
+		Grep:       opts.grep,
+		Exclude:    opts.exclude,
 	}
 	responseBody, err := dockerCli.Client().ContainerLogs(ctx, opts.container, options)
 	if err != nil {
@@ -71,6 +94,10 @@ func runLogs(dockerCli command.Cli, opts *logsOptions) error {
 		return err
 	}
 
+	if tmpl != nil {
+		return copyFormattedLogs(dockerCli, responseBody, c.Config.Tty, tmpl, opts.timestamps)
+	}
+
 	if c.Config.Tty {
 		_, err = io.Copy(dockerCli.Out(), responseBody)
 	} else {
@@ -78,3 +105,24 @@ func runLogs(dockerCli command.Cli, opts *logsOptions) error {
 	}
 	return err
 }
+
+// copyFormattedLogs writes the logs of a container formatted with tmpl.
+func copyFormattedLogs(dockerCli command.Cli, logs io.Reader, tty bool, tmpl *template.Template, timestamps bool) error {
+	if tty {
+		out := newLogFormatWriter(dockerCli.Out(), tmpl, "", timestamps)
+		if _, err := io.Copy(out, logs); err != nil {
+			return err
+		}
+		return out.Flush()
+	}
+
+	stdout := newLogFormatWriter(dockerCli.Out(), tmpl, "stdout", timestamps)
+	stderr := newLogFormatWriter(dockerCli.Err(), tmpl, "stderr", timestamps)
+	if _, err := stdcopy.StdCopy(stdout, stderr, logs); err != nil {
+		return err
+	}
+	if err := stdout.Flush(); err != nil {
+		return err
+	}
+	return stderr.Flush()
+}
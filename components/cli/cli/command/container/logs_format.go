@@ -0,0 +1,96 @@
+package container
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"text/template"
+
+	"github.com/docker/cli/templates"
+	"github.com/docker/docker/pkg/logfields"
+)
+
+// logLine is the data available to the --format template of docker logs.
+type logLine struct {
+	Stream    string
+	Timestamp string
+	Line      string
+	// Fields holds the fields parsed from the line if it is a JSON object
+	// or logfmt pairs.
+	Fields map[string]string
+}
+
+func makeLogTemplate(format string) (*template.Template, error) {
+	tmpl, err := templates.Parse(format)
+	if err != nil {
+		return nil, err
+	}
+	// Missing fields are rendered as empty strings, so that templates can
+	// test for them.
+	tmpl = tmpl.Option("missingkey=zero")
+	// Execute the template for an empty line, so as to validate a bad
+	// template like "{{.badFieldString}}"
+	return tmpl, tmpl.Execute(ioutil.Discard, &logLine{})
+}
+
+// logFormatWriter renders each line of a log stream with a template. Lines
+// for which the template renders nothing are skipped, which allows
+// templates to filter lines.
+type logFormatWriter struct {
+	tmpl       *template.Template
+	out        io.Writer
+	stream     string
+	timestamps bool
+	buf        bytes.Buffer
+}
+
+func newLogFormatWriter(out io.Writer, tmpl *template.Template, stream string, timestamps bool) *logFormatWriter {
+	return &logFormatWriter{tmpl: tmpl, out: out, stream: stream, timestamps: timestamps}
+}
+
+func (w *logFormatWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := string(w.buf.Next(i + 1))
+		if err := w.render(strings.TrimSuffix(line, "\n")); err != nil {
+			return len(p), err
+		}
+	}
+}
+
+// Flush renders the last line of the stream if it is not terminated by a
+// newline.
+func (w *logFormatWriter) Flush() error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	line := w.buf.String()
+	w.buf.Reset()
+	return w.render(line)
+}
+
+func (w *logFormatWriter) render(line string) error {
+	l := logLine{Stream: w.stream, Line: line}
+	if w.timestamps {
+		if i := strings.IndexByte(line, ' '); i >= 0 {
+			l.Timestamp, l.Line = line[:i], line[i+1:]
+		}
+	}
+	l.Fields = logfields.Parse([]byte(l.Line))
+
+	var out bytes.Buffer
+	if err := w.tmpl.Execute(&out, &l); err != nil {
+		return err
+	}
+	if out.Len() == 0 {
+		return nil
+	}
+	out.WriteByte('\n')
+	_, err := w.out.Write(out.Bytes())
+	return err
+}
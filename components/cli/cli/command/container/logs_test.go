@@ -41,6 +41,27 @@ func TestRunLogs(t *testing.T) {
 			options:     &logsOptions{},
 			client:      fakeClient{logFunc: logFn("foo"), inspectFunc: inspectFn},
 		},
+		{
+			doc: "formatted logs",
+			expectedOut: "ERROR: failed\n" +
+				"2019-01-01T00:00:00Z hello\n",
+			options: &logsOptions{
+				timestamps: true,
+				format:     `{{if .Fields.level}}{{upper .Fields.level}}: {{.Fields.msg}}{{else if .Fields}}{{else}}{{.Timestamp}} {{.Line}}{{end}}`,
+			},
+			client: fakeClient{
+				logFunc: logFn("2019-01-01T00:00:00Z level=error msg=failed\n" +
+					"2019-01-01T00:00:00Z {\"msg\":\"skipped\"}\n" +
+					"2019-01-01T00:00:00Z hello"),
+				inspectFunc: inspectFn,
+			},
+		},
+		{
+			doc:           "invalid format",
+			expectedError: "invalid --format",
+			options:       &logsOptions{format: "{{.Unknown}}"},
+			client:        fakeClient{logFunc: logFn("foo"), inspectFunc: inspectFn},
+		},
 	}
 
 	for _, testcase := range testcases {
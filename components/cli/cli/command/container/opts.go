@@ -37,6 +37,7 @@ type containerOptions struct {
 	deviceReadBps      opts.ThrottledeviceOpt
 	deviceWriteBps     opts.ThrottledeviceOpt
 	links              opts.ListOpts
+	dependsOn          opts.ListOpts
 	aliases            opts.ListOpts
 	linkLocalIPs       opts.ListOpts
 	deviceReadIOps     opts.ThrottledeviceOpt
@@ -101,6 +102,7 @@ type containerOptions struct {
 	ipcMode            string
 	pidsLimit          int64
 	restartPolicy      string
+	restartBackoff     restartBackoffOptions
 	readonlyRootfs     bool
 	loggingDriver      string
 	cgroupParent       string
@@ -111,6 +113,8 @@ type containerOptions struct {
 	shmSize            opts.MemBytes
 	noHealthcheck      bool
 	healthCmd          string
+	healthHTTP         string
+	healthTCP          string
 	healthInterval     time.Duration
 	healthTimeout      time.Duration
 	healthStartPeriod  time.Duration
@@ -149,6 +153,7 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 		labelsFile:        opts.NewListOpts(nil),
 		linkLocalIPs:      opts.NewListOpts(nil),
 		links:             opts.NewListOpts(opts.ValidateLink),
+		dependsOn:         opts.NewListOpts(nil),
 		loggingOpts:       opts.NewListOpts(nil),
 		publish:           opts.NewListOpts(nil),
 		securityOpt:       opts.NewListOpts(nil),
@@ -174,6 +179,9 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 	flags.Var(&copts.labelsFile, "label-file", "Read in a line delimited file of labels")
 	flags.BoolVar(&copts.readonlyRootfs, "read-only", false, "Mount the container's root filesystem as read only")
 	flags.StringVar(&copts.restartPolicy, "restart", "no", "Restart policy to apply when a container exits")
+	addRestartBackoffFlags(flags, &copts.restartBackoff)
+	flags.Var(&copts.dependsOn, "depends-on", "Start after another container (format: <name|id>[:started|healthy])")
+	flags.SetAnnotation("depends-on", "version", []string{"1.40"})
 	flags.StringVar(&copts.stopSignal, "stop-signal", signal.DefaultStopSignal, "Signal to stop a container")
 	flags.IntVar(&copts.stopTimeout, "stop-timeout", 0, "Timeout (in seconds) to stop a container")
 	flags.SetAnnotation("stop-timeout", "version", []string{"1.25"})
@@ -229,6 +237,10 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 
 	// Health-checking
 	flags.StringVar(&copts.healthCmd, "health-cmd", "", "Command to run to check health")
+	flags.StringVar(&copts.healthHTTP, "health-http", "", "URL to request from within the container's network to check health")
+	flags.SetAnnotation("health-http", "version", []string{"1.40"})
+	flags.StringVar(&copts.healthTCP, "health-tcp", "", "Address (host:port) to connect to from within the container's network to check health")
+	flags.SetAnnotation("health-tcp", "version", []string{"1.40"})
 	flags.DurationVar(&copts.healthInterval, "health-interval", 0, "Time between running the check (ms|s|m|h) (default 0s)")
 	flags.IntVar(&copts.healthRetries, "health-retries", 0, "Consecutive failures needed to report unhealthy")
 	flags.DurationVar(&copts.healthTimeout, "health-timeout", 0, "Maximum time to allow one check to run (ms|s|m|h) (default 0s)")
@@ -455,6 +467,14 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 	if err != nil {
 		return nil, err
 	}
+	if err := copts.restartBackoff.apply(&restartPolicy); err != nil {
+		return nil, err
+	}
+
+	dependsOn, err := parseDependsOn(copts.dependsOn.GetAll())
+	if err != nil {
+		return nil, err
+	}
 
 	loggingOpts, err := parseLoggingOpts(copts.loggingDriver, copts.loggingOpts.GetAll())
 	if err != nil {
@@ -474,6 +494,8 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 	// Healthcheck
 	var healthConfig *container.HealthConfig
 	haveHealthSettings := copts.healthCmd != "" ||
+		copts.healthHTTP != "" ||
+		copts.healthTCP != "" ||
 		copts.healthInterval != 0 ||
 		copts.healthTimeout != 0 ||
 		copts.healthStartPeriod != 0 ||
@@ -486,9 +508,22 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 		healthConfig = &container.HealthConfig{Test: test}
 	} else if haveHealthSettings {
 		var probe strslice.StrSlice
+		probes := 0
 		if copts.healthCmd != "" {
 			args := []string{"CMD-SHELL", copts.healthCmd}
 			probe = strslice.StrSlice(args)
+			probes++
+		}
+		if copts.healthHTTP != "" {
+			probe = strslice.StrSlice{"HTTP", copts.healthHTTP}
+			probes++
+		}
+		if copts.healthTCP != "" {
+			probe = strslice.StrSlice{"TCP", copts.healthTCP}
+			probes++
+		}
+		if probes > 1 {
+			return nil, errors.Errorf("--health-cmd, --health-http and --health-tcp are mutually exclusive")
 		}
 		if copts.healthInterval < 0 {
 			return nil, errors.Errorf("--health-interval cannot be negative")
@@ -582,6 +617,7 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 		Privileged:      copts.privileged,
 		PortBindings:    portBindings,
 		Links:           copts.links.GetAll(),
+		DependsOn:       dependsOn,
 		PublishAllPorts: copts.publishAll,
 		// Make sure the dns fields are never nil.
 		// New containers don't ever have those fields nil,
@@ -676,6 +712,77 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 	}, nil
 }
 
+// restartBackoffOptions holds the --restart-* flags that tune the delay
+// between restarts and how failures are counted.
+type restartBackoffOptions struct {
+	delay         time.Duration
+	maxDelay      time.Duration
+	multiplier    float64
+	failureWindow time.Duration
+}
+
+func addRestartBackoffFlags(flags *pflag.FlagSet, o *restartBackoffOptions) {
+	flags.DurationVar(&o.delay, "restart-delay", 0, "Delay before the first restart (ms|s|m|h) (default 100ms)")
+	flags.SetAnnotation("restart-delay", "version", []string{"1.40"})
+	flags.DurationVar(&o.maxDelay, "restart-max-delay", 0, "Maximum delay between restarts (ms|s|m|h) (default 1m)")
+	flags.SetAnnotation("restart-max-delay", "version", []string{"1.40"})
+	flags.Float64Var(&o.multiplier, "restart-delay-multiplier", 0, "Factor by which the delay increases after each restart (default 2)")
+	flags.SetAnnotation("restart-delay-multiplier", "version", []string{"1.40"})
+	flags.DurationVar(&o.failureWindow, "restart-window", 0, "Only count failures within this period towards the on-failure retry count (ms|s|m|h)")
+	flags.SetAnnotation("restart-window", "version", []string{"1.40"})
+}
+
+func (o *restartBackoffOptions) isSet() bool {
+	return o.delay != 0 || o.maxDelay != 0 || o.multiplier != 0 || o.failureWindow != 0
+}
+
+// apply sets the backoff options on the restart policy.
+func (o *restartBackoffOptions) apply(p *container.RestartPolicy) error {
+	if o.delay < 0 {
+		return errors.Errorf("--restart-delay cannot be negative")
+	}
+	if o.maxDelay < 0 {
+		return errors.Errorf("--restart-max-delay cannot be negative")
+	}
+	if o.multiplier != 0 && o.multiplier < 1 {
+		return errors.Errorf("--restart-delay-multiplier cannot be less than 1")
+	}
+	if o.failureWindow < 0 {
+		return errors.Errorf("--restart-window cannot be negative")
+	}
+	if o.failureWindow != 0 && !p.IsOnFailure() {
+		return errors.Errorf("--restart-window can only be used with --restart=on-failure")
+	}
+	p.InitialDelay = o.delay
+	p.MaxDelay = o.maxDelay
+	p.Multiplier = o.multiplier
+	p.FailureWindow = o.failureWindow
+	return nil
+}
+
+// parseDependsOn parses the --depends-on values, in the
+// <name|id>[:started|healthy] form.
+func parseDependsOn(values []string) ([]container.Dependency, error) {
+	var deps []container.Dependency
+	for _, v := range values {
+		name, condition := v, ""
+		if i := strings.LastIndex(v, ":"); i >= 0 {
+			name, condition = v[:i], v[i+1:]
+		}
+		d := container.Dependency{Container: name, Condition: container.DependencyCondition(condition)}
+		switch d.Condition {
+		case container.DependencyConditionUnset, container.DependencyConditionStarted, container.DependencyConditionHealthy:
+		default:
+			return nil, errors.Errorf("invalid condition %q for --depends-on: must be 'started' or 'healthy'", condition)
+		}
+		if d.Container == "" {
+			return nil, errors.Errorf("invalid --depends-on %q: missing container name", v)
+		}
+		deps = append(deps, d)
+	}
+	return deps, nil
+}
+
 func parsePortOpts(publishOpts []string) ([]string, error) {
 	optsList := []string{}
 	for _, publish := range publishOpts {
@@ -442,6 +442,53 @@ func TestParseRestartPolicy(t *testing.T) {
 	}
 }
 
+func TestParseRestartPolicyBackoff(t *testing.T) {
+	_, hostconfig, _, err := parseRun([]string{
+		"--restart=on-failure:5",
+		"--restart-delay=1s",
+		"--restart-max-delay=30s",
+		"--restart-delay-multiplier=1.5",
+		"--restart-window=10m",
+		"img", "cmd",
+	})
+	assert.NilError(t, err)
+	expected := container.RestartPolicy{
+		Name:              "on-failure",
+		MaximumRetryCount: 5,
+		InitialDelay:      time.Second,
+		MaxDelay:          30 * time.Second,
+		Multiplier:        1.5,
+		FailureWindow:     10 * time.Minute,
+	}
+	assert.Check(t, is.DeepEqual(expected, hostconfig.RestartPolicy))
+
+	invalids := map[string][]string{
+		"--restart-delay cannot be negative":                          {"--restart=always", "--restart-delay=-1s"},
+		"--restart-delay-multiplier cannot be less than 1":            {"--restart=always", "--restart-delay-multiplier=0.5"},
+		"--restart-window can only be used with --restart=on-failure": {"--restart=always", "--restart-window=1m"},
+	}
+	for expectedError, args := range invalids {
+		_, _, _, err := parseRun(append(args, "img", "cmd"))
+		assert.Check(t, is.Error(err, expectedError))
+	}
+}
+
+func TestParseDependsOn(t *testing.T) {
+	_, hostconfig, _, err := parseRun([]string{"--depends-on=db:healthy", "--depends-on=cache", "img", "cmd"})
+	assert.NilError(t, err)
+	expected := []container.Dependency{
+		{Container: "db", Condition: container.DependencyConditionHealthy},
+		{Container: "cache"},
+	}
+	assert.Check(t, is.DeepEqual(expected, hostconfig.DependsOn))
+
+	_, _, _, err = parseRun([]string{"--depends-on=db:ready", "img", "cmd"})
+	assert.Check(t, is.Error(err, `invalid condition "ready" for --depends-on: must be 'started' or 'healthy'`))
+
+	_, _, _, err = parseRun([]string{"--depends-on=:healthy", "img", "cmd"})
+	assert.Check(t, is.Error(err, `invalid --depends-on ":healthy": missing container name`))
+}
+
 func TestParseRestartPolicyAutoRemove(t *testing.T) {
 	expected := "Conflicting options: --restart and --rm"
 	_, _, _, err := parseRun([]string{"--rm", "--restart=always", "img", "cmd"})
@@ -483,6 +530,19 @@ func TestParseHealth(t *testing.T) {
 	checkError("--no-healthcheck conflicts with --health-* options",
 		"--no-healthcheck", "--health-cmd=/check.sh -q", "img", "cmd")
 
+	health = checkOk("--health-http=http://localhost:8080/ping", "img", "cmd")
+	if len(health.Test) != 2 || health.Test[0] != "HTTP" || health.Test[1] != "http://localhost:8080/ping" {
+		t.Fatalf("--health-http: got %#v", health.Test)
+	}
+
+	health = checkOk("--health-tcp=localhost:5432", "img", "cmd")
+	if len(health.Test) != 2 || health.Test[0] != "TCP" || health.Test[1] != "localhost:5432" {
+		t.Fatalf("--health-tcp: got %#v", health.Test)
+	}
+
+	checkError("--health-cmd, --health-http and --health-tcp are mutually exclusive",
+		"--health-cmd=/check.sh -q", "--health-tcp=localhost:5432", "img", "cmd")
+
 	health = checkOk("--health-timeout=2s", "--health-retries=3", "--health-interval=4.5s", "--health-start-period=5s", "img", "cmd")
 	if health.Timeout != 2*time.Second || health.Retries != 3 || health.Interval != 4500*time.Millisecond || health.StartPeriod != 5*time.Second {
 		t.Fatalf("--health-*: got %#v", health)
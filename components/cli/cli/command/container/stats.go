@@ -19,11 +19,14 @@ import (
 )
 
 type statsOptions struct {
-	all        bool
-	noStream   bool
-	noTrunc    bool
-	format     string
-	containers []string
+	all         bool
+	noStream    bool
+	noTrunc     bool
+	interactive bool
+	format      string
+	since       string
+	until       string
+	containers  []string
 }
 
 // NewStatsCommand creates a new cobra.Command for `docker stats`
@@ -45,6 +48,11 @@ func NewStatsCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVar(&opts.noStream, "no-stream", false, "Disable streaming stats and only pull the first result")
 	flags.BoolVar(&opts.noTrunc, "no-trunc", false, "Do not truncate output")
 	flags.StringVar(&opts.format, "format", "", "Pretty-print images using a Go template")
+	flags.BoolVarP(&opts.interactive, "interactive", "i", false, "Display an interactive view to sort, filter and inspect the containers")
+	flags.StringVar(&opts.since, "since", "", "Show the stats history kept by the daemon since timestamp (e.g. 2013-01-02T13:23:37) or relative (e.g. 42m for 42 minutes)")
+	flags.SetAnnotation("since", "version", []string{"1.40"})
+	flags.StringVar(&opts.until, "until", "", "Show the stats history kept by the daemon until timestamp (e.g. 2013-01-02T13:23:37) or relative (e.g. 42m for 42 minutes)")
+	flags.SetAnnotation("until", "version", []string{"1.40"})
 	return cmd
 }
 
@@ -52,6 +60,21 @@ func NewStatsCommand(dockerCli command.Cli) *cobra.Command {
 // This shows real-time information on CPU usage, memory usage, and network I/O.
 // nolint: gocyclo
 func runStats(dockerCli command.Cli, opts *statsOptions) error {
+	if opts.interactive {
+		if opts.since != "" || opts.until != "" {
+			return errors.New("--interactive cannot be used with --since or --until")
+		}
+		if opts.noStream || opts.format != "" {
+			return errors.New("--interactive cannot be used with --no-stream or --format")
+		}
+		if !dockerCli.In().IsTerminal() || !dockerCli.Out().IsTerminal() {
+			return errors.New("--interactive requires a terminal")
+		}
+	}
+	if opts.since != "" || opts.until != "" {
+		return runStatsHistory(dockerCli, opts)
+	}
+
 	showAll := len(opts.containers) == 0
 	closeChan := make(chan error)
 
@@ -188,6 +211,9 @@ func runStats(dockerCli command.Cli, opts *statsOptions) error {
 
 	// before print to screen, make sure each container get at least one valid stat data
 	waitFirst.Wait()
+	if opts.interactive {
+		return runInteractiveStats(ctx, dockerCli, &cStats, closeChan, showAll, !opts.noTrunc)
+	}
 	format := opts.format
 	if len(format) == 0 {
 		if len(dockerCli.ConfigFile().StatsFormat) > 0 {
@@ -55,10 +55,8 @@ func (s *stats) isKnownContainer(cid string) (int, bool) {
 func collect(ctx context.Context, s *formatter.ContainerStats, cli client.APIClient, streamStats bool, waitFirst *sync.WaitGroup) {
 	logrus.Debugf("collecting stats for %s", s.Container)
 	var (
-		getFirst       bool
-		previousCPU    uint64
-		previousSystem uint64
-		u              = make(chan error, 1)
+		getFirst bool
+		u        = make(chan error, 1)
 	)
 
 	defer func() {
@@ -79,13 +77,7 @@ func collect(ctx context.Context, s *formatter.ContainerStats, cli client.APICli
 	dec := json.NewDecoder(response.Body)
 	go func() {
 		for {
-			var (
-				v                      *types.StatsJSON
-				memPercent, cpuPercent float64
-				blkRead, blkWrite      uint64 // Only used on Linux
-				mem, memLimit          float64
-				pidsStatsCurrent       uint64
-			)
+			var v *types.StatsJSON
 
 			if err := dec.Decode(&v); err != nil {
 				dec = json.NewDecoder(io.MultiReader(dec.Buffered(), response.Body))
@@ -98,36 +90,7 @@ func collect(ctx context.Context, s *formatter.ContainerStats, cli client.APICli
 			}
 
 			daemonOSType = response.OSType
-
-			if daemonOSType != "windows" {
-				previousCPU = v.PreCPUStats.CPUUsage.TotalUsage
-				previousSystem = v.PreCPUStats.SystemUsage
-				cpuPercent = calculateCPUPercentUnix(previousCPU, previousSystem, v)
-				blkRead, blkWrite = calculateBlockIO(v.BlkioStats)
-				mem = calculateMemUsageUnixNoCache(v.MemoryStats)
-				memLimit = float64(v.MemoryStats.Limit)
-				memPercent = calculateMemPercentUnixNoCache(memLimit, mem)
-				pidsStatsCurrent = v.PidsStats.Current
-			} else {
-				cpuPercent = calculateCPUPercentWindows(v)
-				blkRead = v.StorageStats.ReadSizeBytes
-				blkWrite = v.StorageStats.WriteSizeBytes
-				mem = float64(v.MemoryStats.PrivateWorkingSet)
-			}
-			netRx, netTx := calculateNetwork(v.Networks)
-			s.SetStatistics(formatter.StatsEntry{
-				Name:             v.Name,
-				ID:               v.ID,
-				CPUPercentage:    cpuPercent,
-				Memory:           mem,
-				MemoryPercentage: memPercent,
-				MemoryLimit:      memLimit,
-				NetworkRx:        netRx,
-				NetworkTx:        netTx,
-				BlockRead:        float64(blkRead),
-				BlockWrite:       float64(blkWrite),
-				PidsCurrent:      pidsStatsCurrent,
-			})
+			s.SetStatistics(calculateStatsEntry(v, daemonOSType))
 			u <- nil
 			if !streamStats {
 				return
@@ -165,6 +128,47 @@ func collect(ctx context.Context, s *formatter.ContainerStats, cli client.APICli
 	}
 }
 
+// calculateStatsEntry returns the statistics of v, read from a daemon of the
+// given OS type.
+func calculateStatsEntry(v *types.StatsJSON, osType string) formatter.StatsEntry {
+	var (
+		memPercent, cpuPercent float64
+		blkRead, blkWrite      uint64 // Only used on Linux
+		mem, memLimit          float64
+		pidsStatsCurrent       uint64
+	)
+
+	if osType != "windows" {
+		previousCPU := v.PreCPUStats.CPUUsage.TotalUsage
+		previousSystem := v.PreCPUStats.SystemUsage
+		cpuPercent = calculateCPUPercentUnix(previousCPU, previousSystem, v)
+		blkRead, blkWrite = calculateBlockIO(v.BlkioStats)
+		mem = calculateMemUsageUnixNoCache(v.MemoryStats)
+		memLimit = float64(v.MemoryStats.Limit)
+		memPercent = calculateMemPercentUnixNoCache(memLimit, mem)
+		pidsStatsCurrent = v.PidsStats.Current
+	} else {
+		cpuPercent = calculateCPUPercentWindows(v)
+		blkRead = v.StorageStats.ReadSizeBytes
+		blkWrite = v.StorageStats.WriteSizeBytes
+		mem = float64(v.MemoryStats.PrivateWorkingSet)
+	}
+	netRx, netTx := calculateNetwork(v.Networks)
+	return formatter.StatsEntry{
+		Name:             v.Name,
+		ID:               v.ID,
+		CPUPercentage:    cpuPercent,
+		Memory:           mem,
+		MemoryPercentage: memPercent,
+		MemoryLimit:      memLimit,
+		NetworkRx:        netRx,
+		NetworkTx:        netTx,
+		BlockRead:        float64(blkRead),
+		BlockWrite:       float64(blkWrite),
+		PidsCurrent:      pidsStatsCurrent,
+	}
+}
+
 func calculateCPUPercentUnix(previousCPU, previousSystem uint64, v *types.StatsJSON) float64 {
 	var (
 		cpuPercent = 0.0
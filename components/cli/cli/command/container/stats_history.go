@@ -0,0 +1,93 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+)
+
+// runStatsHistory displays the stats history kept by the daemon for one or
+// more containers, or for all the running containers if none are specified.
+func runStatsHistory(dockerCli command.Cli, opts *statsOptions) error {
+	ctx := context.Background()
+
+	containers := opts.containers
+	if len(containers) == 0 {
+		cs, err := dockerCli.Client().ContainerList(ctx, types.ContainerListOptions{All: opts.all})
+		if err != nil {
+			return err
+		}
+		for _, c := range cs {
+			containers = append(containers, c.ID)
+		}
+	}
+
+	var (
+		entries []formatter.StatsHistoryEntry
+		osType  string
+		errs    []string
+	)
+	for _, container := range containers {
+		samples, containerOSType, err := getStatsHistory(ctx, dockerCli, container, opts)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		osType = containerOSType
+		entries = append(entries, samples...)
+	}
+
+	if len(entries) == 0 && len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+
+	format := opts.format
+	if len(format) == 0 {
+		format = formatter.TableFormatKey
+	}
+	statsCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: formatter.NewStatsHistoryFormat(format, osType),
+	}
+	if err := formatter.StatsHistoryWrite(statsCtx, entries, osType, !opts.noTrunc); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// getStatsHistory returns the samples of the stats history of the container
+func getStatsHistory(ctx context.Context, dockerCli command.Cli, container string, opts *statsOptions) ([]formatter.StatsHistoryEntry, string, error) {
+	response, err := dockerCli.Client().ContainerStatsWithOptions(ctx, container, types.ContainerStatsOptions{
+		Since: opts.since,
+		Until: opts.until,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+
+	var entries []formatter.StatsHistoryEntry
+	dec := json.NewDecoder(response.Body)
+	for {
+		var v types.StatsJSON
+		if err := dec.Decode(&v); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, "", err
+		}
+		entry := calculateStatsEntry(&v, response.OSType)
+		entry.Container = container
+		entries = append(entries, formatter.StatsHistoryEntry{Time: v.Read, StatsEntry: entry})
+	}
+	return entries, response.OSType, nil
+}
@@ -0,0 +1,84 @@
+package container
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func statsHistoryResponse(t *testing.T, samples ...types.StatsJSON) types.ContainerStats {
+	var body strings.Builder
+	enc := json.NewEncoder(&body)
+	for _, s := range samples {
+		assert.NilError(t, enc.Encode(s))
+	}
+	return types.ContainerStats{Body: ioutil.NopCloser(strings.NewReader(body.String())), OSType: "linux"}
+}
+
+func TestRunStatsHistory(t *testing.T) {
+	read := time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)
+	var sample types.StatsJSON
+	sample.Name = "/web"
+	sample.ID = "aaaaaaaaaaaabbbbbbbbbbbb"
+	sample.Read = read
+	sample.PreRead = read.Add(-10 * time.Second)
+	sample.CPUStats.CPUUsage.TotalUsage = 200
+	sample.CPUStats.SystemUsage = 2000
+	sample.CPUStats.OnlineCPUs = 2
+	sample.PreCPUStats.CPUUsage.TotalUsage = 100
+	sample.PreCPUStats.SystemUsage = 1000
+	sample.MemoryStats.Usage = 1024
+	sample.MemoryStats.Limit = 4096
+	sample.PidsStats.Current = 4
+
+	var requested []string
+	cli := test.NewFakeCli(&fakeClient{
+		containerListFunc: func(options types.ContainerListOptions) ([]types.Container, error) {
+			assert.Check(t, !options.All)
+			return []types.Container{{ID: "web"}, {ID: "gone"}}, nil
+		},
+		containerStatsFunc: func(container string, options types.ContainerStatsOptions) (types.ContainerStats, error) {
+			requested = append(requested, container)
+			assert.Check(t, is.DeepEqual(types.ContainerStatsOptions{Since: "10m"}, options))
+			if container == "gone" {
+				return types.ContainerStats{}, errors.New("No such container: gone")
+			}
+			return statsHistoryResponse(t, sample), nil
+		},
+	})
+	err := runStats(cli, &statsOptions{since: "10m", format: "csv"})
+	assert.Error(t, err, "No such container: gone")
+	assert.Check(t, is.DeepEqual([]string{"web", "gone"}, requested))
+	expected := `time,container,name,id,cpu_percent,memory_usage,memory_limit,memory_percent,net_rx,net_tx,block_read,block_write,pids
+2019-01-01T12:00:00Z,web,web,aaaaaaaaaaaa,20.00,1024,4096,25.00,0,0,0,0,4
+`
+	assert.Check(t, is.Equal(expected, cli.OutBuffer().String()))
+}
+
+func TestRunStatsHistoryContainers(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		containerStatsFunc: func(container string, options types.ContainerStatsOptions) (types.ContainerStats, error) {
+			assert.Check(t, is.DeepEqual(types.ContainerStatsOptions{Since: "1h", Until: "30m"}, options))
+			var sample types.StatsJSON
+			sample.Name = "/" + container
+			return statsHistoryResponse(t, sample, sample), nil
+		},
+	})
+	err := runStats(cli, &statsOptions{since: "1h", until: "30m", containers: []string{"db"}, format: "{{.Container}} {{.Name}}"})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("db db\ndb db\n", cli.OutBuffer().String()))
+}
+
+func TestRunStatsHistoryInteractive(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	err := runStats(cli, &statsOptions{since: "10m", interactive: true})
+	assert.Error(t, err, "--interactive cannot be used with --since or --until")
+}
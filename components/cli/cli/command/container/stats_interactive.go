@@ -0,0 +1,804 @@
+package container
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/morikuni/aec"
+)
+
+const (
+	// statsHistorySize is the number of samples kept for the history of
+	// each container, one per refresh of the view
+	statsHistorySize = 120
+	winOSType        = "windows"
+	// sparklineWidth is the number of samples displayed in the history columns
+	sparklineWidth = 20
+	// logsTail is the number of lines of logs displayed when drilling into
+	// the logs of a container
+	logsTail = "100"
+)
+
+var sparklineTicks = []rune("▁▂▃▄▅▆▇█")
+
+// statsSortKey is the column the interactive view is sorted by
+type statsSortKey string
+
+const (
+	sortByName    statsSortKey = "name"
+	sortByCPU     statsSortKey = "cpu"
+	sortByMemory  statsSortKey = "mem"
+	sortByNetIO   statsSortKey = "net"
+	sortByBlockIO statsSortKey = "block"
+)
+
+// statsSortKeys are the sort keys, indexed by the key selecting them
+var statsSortKeys = map[string]statsSortKey{
+	"s": sortByName,
+	"c": sortByCPU,
+	"m": sortByMemory,
+	"n": sortByNetIO,
+	"b": sortByBlockIO,
+}
+
+// statsViewMode is what the interactive view displays
+type statsViewMode string
+
+const (
+	modeTable   statsViewMode = "table"
+	modeTop     statsViewMode = "top"
+	modeLogs    statsViewMode = "logs"
+	modeInspect statsViewMode = "inspect"
+)
+
+// statsDrillKeys are the drill-down modes, indexed by the key selecting them
+var statsDrillKeys = map[string]statsViewMode{
+	"t": modeTop,
+	"l": modeLogs,
+	"i": modeInspect,
+}
+
+// Keys which are not a single printable character
+const (
+	keyUp        = "up"
+	keyDown      = "down"
+	keyPageUp    = "pgup"
+	keyPageDown  = "pgdown"
+	keyHome      = "home"
+	keyEnd       = "end"
+	keyEnter     = "enter"
+	keyEscape    = "esc"
+	keyBackspace = "backspace"
+	keyInterrupt = "ctrl-c"
+)
+
+// statsHistory is the history of the usage of a container, oldest first
+type statsHistory struct {
+	cpu []float64
+	mem []float64
+}
+
+func (h *statsHistory) add(entry formatter.StatsEntry) {
+	h.cpu = appendSample(h.cpu, entry.CPUPercentage)
+	h.mem = appendSample(h.mem, entry.MemoryPercentage)
+}
+
+func appendSample(samples []float64, value float64) []float64 {
+	samples = append(samples, value)
+	if len(samples) > statsHistorySize {
+		samples = samples[len(samples)-statsHistorySize:]
+	}
+	return samples
+}
+
+// statsView is the state of the interactive view of `docker stats`
+type statsView struct {
+	osType string
+	trunc  bool
+
+	sortBy    statsSortKey
+	ascending bool
+	filter    string
+	// editing is true while the filter is typed, in input
+	editing bool
+	input   string
+
+	// selected is the ID of the container selected in the table
+	selected string
+	// rows are the containers displayed in the table, as last rendered
+	rows []formatter.StatsEntry
+
+	history map[string]*statsHistory
+	// labels of the containers, indexed by ID, for filtering by label
+	labels map[string]map[string]string
+
+	mode statsViewMode
+	// target is the ID of the container of the drill-down view
+	target string
+	detail string
+	// fetched is true once the content of the drill-down view is received
+	fetched bool
+	// offset is the first line of the content of the drill-down view that
+	// is displayed, and pageSize the number of lines displayed, as last
+	// rendered
+	offset   int
+	pageSize int
+	// follow anchors the drill-down view to the end of its content, which
+	// is the default for the logs, until the user scrolls up
+	follow bool
+}
+
+func newStatsView(osType string, trunc bool) *statsView {
+	return &statsView{
+		osType:  osType,
+		trunc:   trunc,
+		sortBy:  sortByCPU,
+		history: map[string]*statsHistory{},
+		labels:  map[string]map[string]string{},
+		mode:    modeTable,
+	}
+}
+
+// record adds the current statistics of the containers to their history
+func (v *statsView) record(entries []formatter.StatsEntry) {
+	for _, entry := range entries {
+		key := statsKey(entry)
+		h, ok := v.history[key]
+		if !ok {
+			h = &statsHistory{}
+			v.history[key] = h
+		}
+		h.add(entry)
+	}
+}
+
+// statsKey returns the key identifying the container of entry, which is its
+// ID once its first statistics are received
+func statsKey(entry formatter.StatsEntry) string {
+	if entry.ID != "" {
+		return entry.ID
+	}
+	return entry.Container
+}
+
+// handleKey updates the view for a key pressed by the user. It returns true
+// if the user asked to quit.
+func (v *statsView) handleKey(key string) bool {
+	if key == keyInterrupt {
+		return true
+	}
+	if v.editing {
+		switch key {
+		case keyEnter:
+			v.filter = strings.TrimSpace(v.input)
+			v.editing = false
+		case keyEscape:
+			v.editing = false
+		case keyBackspace:
+			if _, size := utf8.DecodeLastRuneInString(v.input); size > 0 {
+				v.input = v.input[:len(v.input)-size]
+			}
+		default:
+			if utf8.RuneCountInString(key) == 1 {
+				v.input += key
+			}
+		}
+		return false
+	}
+	if v.mode != modeTable {
+		switch key {
+		case "q", keyEscape:
+			v.mode = modeTable
+			v.detail = ""
+		case keyUp, "k":
+			v.scroll(-1)
+		case keyDown, "j":
+			v.scroll(1)
+		case keyPageUp:
+			v.scroll(-v.pageSize)
+		case keyPageDown:
+			v.scroll(v.pageSize)
+		case keyHome, "g":
+			v.offset = 0
+			v.follow = false
+		case keyEnd, "G":
+			v.offset = v.maxOffset()
+			v.follow = true
+		}
+		return false
+	}
+
+	switch key {
+	case "q":
+		return true
+	case keyUp, "k":
+		v.moveSelection(-1)
+	case keyDown, "j":
+		v.moveSelection(1)
+	case "/":
+		v.editing = true
+		v.input = v.filter
+	case keyEscape:
+		v.filter = ""
+	case keyEnter:
+		v.drill(modeTop)
+	default:
+		if sortBy, ok := statsSortKeys[key]; ok {
+			if v.sortBy == sortBy {
+				v.ascending = !v.ascending
+			} else {
+				v.sortBy = sortBy
+				v.ascending = sortBy == sortByName
+			}
+		} else if mode, ok := statsDrillKeys[key]; ok {
+			v.drill(mode)
+		}
+	}
+	return false
+}
+
+func (v *statsView) moveSelection(delta int) {
+	if len(v.rows) == 0 {
+		return
+	}
+	i := v.selectedIndex() + delta
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(v.rows) {
+		i = len(v.rows) - 1
+	}
+	v.selected = statsKey(v.rows[i])
+}
+
+// selectedIndex returns the index of the selected container in the rows,
+// which is the first row if the selected container is not displayed
+func (v *statsView) selectedIndex() int {
+	for i, row := range v.rows {
+		if statsKey(row) == v.selected {
+			return i
+		}
+	}
+	return 0
+}
+
+func (v *statsView) drill(mode statsViewMode) {
+	if len(v.rows) == 0 {
+		return
+	}
+	v.target = statsKey(v.rows[v.selectedIndex()])
+	v.mode = mode
+	v.detail = ""
+	v.fetched = false
+	v.offset = 0
+	v.follow = mode == modeLogs
+}
+
+// scroll moves the drill-down view by delta lines. Scrolling to the end of
+// the content follows it again.
+func (v *statsView) scroll(delta int) {
+	if v.follow {
+		v.offset = v.maxOffset()
+	}
+	v.offset += delta
+	if v.offset < 0 {
+		v.offset = 0
+	}
+	v.follow = v.offset >= v.maxOffset()
+	if v.follow {
+		v.offset = v.maxOffset()
+	}
+}
+
+// maxOffset returns the offset displaying the last page of the content of
+// the drill-down view
+func (v *statsView) maxOffset() int {
+	if n := len(v.detailLines()) - v.pageSize; n > 0 {
+		return n
+	}
+	return 0
+}
+
+func (v *statsView) detailLines() []string {
+	return strings.Split(strings.TrimSuffix(v.detail, "\n"), "\n")
+}
+
+// needsLabels returns whether the filter matches on labels
+func (v *statsView) needsLabels() bool {
+	for _, term := range strings.Fields(v.filter) {
+		if strings.HasPrefix(term, "label=") {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns whether entry matches all the terms of the filter. A term
+// is either "label=<key>" or "label=<key>=<value>", or a part of the name or
+// ID of the container.
+func (v *statsView) matches(entry formatter.StatsEntry) bool {
+	for _, term := range strings.Fields(v.filter) {
+		if strings.HasPrefix(term, "label=") {
+			key, value, hasValue := strings.TrimPrefix(term, "label="), "", false
+			if i := strings.Index(key, "="); i >= 0 {
+				key, value, hasValue = key[:i], key[i+1:], true
+			}
+			actual, ok := v.labels[entry.ID][key]
+			if !ok || (hasValue && actual != value) {
+				return false
+			}
+			continue
+		}
+		term = strings.ToLower(term)
+		if !strings.Contains(strings.ToLower(entry.Name), term) &&
+			!strings.Contains(strings.ToLower(entry.Container), term) &&
+			!strings.HasPrefix(entry.ID, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// visibleRows returns the entries matching the filter, sorted
+func (v *statsView) visibleRows(entries []formatter.StatsEntry) []formatter.StatsEntry {
+	rows := []formatter.StatsEntry{}
+	for _, entry := range entries {
+		if v.matches(entry) {
+			rows = append(rows, entry)
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if a, b := statsSortValue(rows[i], v.sortBy), statsSortValue(rows[j], v.sortBy); a != b {
+			return (a < b) == v.ascending
+		}
+		if v.sortBy == sortByName && !v.ascending {
+			return rows[i].Name > rows[j].Name
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows
+}
+
+func statsSortValue(entry formatter.StatsEntry, sortBy statsSortKey) float64 {
+	switch sortBy {
+	case sortByCPU:
+		return entry.CPUPercentage
+	case sortByMemory:
+		return entry.Memory
+	case sortByNetIO:
+		return entry.NetworkRx + entry.NetworkTx
+	case sortByBlockIO:
+		return entry.BlockRead + entry.BlockWrite
+	}
+	return 0
+}
+
+// sparkline returns the last width samples as a line of bars, scaled to max
+// or to the largest sample if it is larger
+func sparkline(samples []float64, width int, max float64) string {
+	if len(samples) > width {
+		samples = samples[len(samples)-width:]
+	}
+	for _, s := range samples {
+		if s > max {
+			max = s
+		}
+	}
+	var b strings.Builder
+	for _, s := range samples {
+		i := 0
+		if max > 0 && s > 0 {
+			i = int(s / max * float64(len(sparklineTicks)-1))
+		}
+		b.WriteRune(sparklineTicks[i])
+	}
+	return b.String()
+}
+
+// render writes a frame of the view for a terminal of the given size
+func (v *statsView) render(w io.Writer, entries []formatter.StatsEntry, width, height int) error {
+	var lines []string
+	if v.mode == modeTable {
+		var err error
+		if lines, err = v.renderTable(entries); err != nil {
+			return err
+		}
+	} else {
+		lines = v.renderDetail(height)
+	}
+
+	if height > 0 && len(lines) > height {
+		lines = lines[:height]
+	}
+	var frame bytes.Buffer
+	frame.WriteString(aec.EmptyBuilder.Position(1, 1).EraseDisplay(aec.EraseModes.All).ANSI.String())
+	for i, line := range lines {
+		if i > 0 {
+			// the terminal is in raw mode, which does not translate new lines
+			frame.WriteString("\r\n")
+		}
+		frame.WriteString(truncateLine(line, width))
+	}
+	_, err := w.Write(frame.Bytes())
+	return err
+}
+
+func (v *statsView) renderTable(entries []formatter.StatsEntry) ([]string, error) {
+	v.rows = v.visibleRows(entries)
+	selected := v.selectedIndex()
+	if len(v.rows) > 0 {
+		v.selected = statsKey(v.rows[selected])
+	}
+
+	var table bytes.Buffer
+	statsCtx := formatter.Context{
+		Output: &table,
+		Format: formatter.NewStatsFormat(formatter.TableFormatKey, v.osType),
+	}
+	if err := formatter.ContainerStatsWrite(statsCtx, v.rows, v.osType, v.trunc); err != nil {
+		return nil, err
+	}
+	tableLines := strings.Split(strings.TrimSuffix(table.String(), "\n"), "\n")
+
+	// Add the history columns after the columns of the formatter
+	var withHistory bytes.Buffer
+	tw := tabwriter.NewWriter(&withHistory, 20, 1, 3, ' ', 0)
+	for i, line := range tableLines {
+		columns := []string{line, "CPU HISTORY", "MEM HISTORY"}
+		if i > 0 {
+			h := v.history[statsKey(v.rows[i-1])]
+			if h == nil {
+				h = &statsHistory{}
+			}
+			columns = []string{line, sparkline(h.cpu, sparklineWidth, 100), sparkline(h.mem, sparklineWidth, 100)}
+		}
+		if v.osType == winOSType {
+			// the memory percentage is not reported on Windows
+			columns = columns[:2]
+		}
+		fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	}
+	tw.Flush()
+
+	order := "descending"
+	if v.ascending {
+		order = "ascending"
+	}
+	lines := []string{
+		fmt.Sprintf("Sort: %s (%s)   Filter: %s", v.sortBy, order, v.filter),
+		"[c]pu [m]em [n]et [b]lock [s] name: sort   [/] filter   [t]op [l]ogs [i]nspect: drill down   [q]uit",
+		"",
+	}
+	for i, line := range strings.Split(strings.TrimSuffix(withHistory.String(), "\n"), "\n") {
+		if i > 0 && i-1 == selected {
+			line = aec.Apply(line, aec.Inverse)
+		}
+		lines = append(lines, line)
+	}
+	if v.editing {
+		lines = append(lines, "", "Filter (name, ID or label=<key>[=<value>]): "+v.input+"_")
+	}
+	return lines, nil
+}
+
+// renderDetail renders the page of the content of the drill-down view at
+// the current offset, below a header of two lines
+func (v *statsView) renderDetail(height int) []string {
+	name := v.target
+	for _, row := range v.rows {
+		if statsKey(row) == v.target && len(row.Name) > 1 {
+			name = row.Name[1:]
+		}
+	}
+	content := v.detailLines()
+	v.pageSize = len(content)
+	if height > 2 && height-2 < v.pageSize {
+		v.pageSize = height - 2
+	}
+	if v.follow || v.offset > v.maxOffset() {
+		v.offset = v.maxOffset()
+	}
+	end := v.offset + v.pageSize
+	if end > len(content) {
+		end = len(content)
+	}
+
+	header := fmt.Sprintf("%s: %s   [j/k pgup/pgdown g/G] scroll   [q] back", v.mode, name)
+	if v.pageSize < len(content) {
+		header += fmt.Sprintf("   lines %d-%d of %d", v.offset+1, end, len(content))
+	}
+	lines := []string{header, ""}
+	return append(lines, content[v.offset:end]...)
+}
+
+// truncateLine truncates line to width characters, not counting the escape
+// sequences of the selected row
+func truncateLine(line string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(line) <= width {
+		return line
+	}
+	inverse := strings.HasPrefix(line, aec.Inverse.String())
+	if inverse {
+		line = strings.TrimSuffix(strings.TrimPrefix(line, aec.Inverse.String()), aec.Reset)
+	}
+	runes := []rune(line)
+	if len(runes) > width {
+		line = string(runes[:width])
+	}
+	if inverse {
+		line = aec.Apply(line, aec.Inverse)
+	}
+	return line
+}
+
+// statsDetail is the content of a drill-down view of a container
+type statsDetail struct {
+	mode      statsViewMode
+	container string
+	content   string
+}
+
+// fetchDetail sends the content of the drill-down view of the container to
+// details
+func fetchDetail(ctx context.Context, apiClient client.APIClient, mode statsViewMode, container string, details chan<- statsDetail) {
+	content, err := fetchStatsDetail(ctx, apiClient, mode, container)
+	if err != nil {
+		content = "Error: " + err.Error()
+	}
+	details <- statsDetail{mode: mode, container: container, content: content}
+}
+
+func fetchStatsDetail(ctx context.Context, apiClient client.APIClient, mode statsViewMode, container string) (string, error) {
+	switch mode {
+	case modeTop:
+		procList, err := apiClient.ContainerTop(ctx, container, nil)
+		if err != nil {
+			return "", err
+		}
+		var b bytes.Buffer
+		w := tabwriter.NewWriter(&b, 20, 1, 3, ' ', 0)
+		fmt.Fprintln(w, strings.Join(procList.Titles, "\t"))
+		for _, proc := range procList.Processes {
+			fmt.Fprintln(w, strings.Join(proc, "\t"))
+		}
+		w.Flush()
+		return b.String(), nil
+	case modeLogs:
+		c, err := apiClient.ContainerInspect(ctx, container)
+		if err != nil {
+			return "", err
+		}
+		responseBody, err := apiClient.ContainerLogs(ctx, container, types.ContainerLogsOptions{
+			ShowStdout: true,
+			ShowStderr: true,
+			Tail:       logsTail,
+		})
+		if err != nil {
+			return "", err
+		}
+		defer responseBody.Close()
+		var b bytes.Buffer
+		if c.Config != nil && c.Config.Tty {
+			_, err = io.Copy(&b, responseBody)
+		} else {
+			_, err = stdcopy.StdCopy(&b, &b, responseBody)
+		}
+		return b.String(), err
+	case modeInspect:
+		_, raw, err := apiClient.ContainerInspectWithRaw(ctx, container, false)
+		if err != nil {
+			return "", err
+		}
+		var b bytes.Buffer
+		if err := json.Indent(&b, raw, "", "    "); err != nil {
+			return "", err
+		}
+		return b.String(), nil
+	}
+	return "", nil
+}
+
+// updateLabels fetches the labels of the containers which are not known yet
+func (v *statsView) updateLabels(ctx context.Context, apiClient client.APIClient, entries []formatter.StatsEntry) {
+	missing := false
+	for _, entry := range entries {
+		if _, ok := v.labels[entry.ID]; !ok && entry.ID != "" {
+			missing = true
+		}
+	}
+	if !missing {
+		return
+	}
+	containers, err := apiClient.ContainerList(ctx, types.ContainerListOptions{All: true})
+	if err != nil {
+		return
+	}
+	for _, c := range containers {
+		v.labels[c.ID] = c.Labels
+	}
+}
+
+// readKeys sends the keys read from the terminal in raw mode to keys
+func readKeys(in io.Reader, keys chan<- string) {
+	buf := make([]byte, 32)
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			close(keys)
+			return
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+// parseKeys returns the keys of input read from the terminal in raw mode
+func parseKeys(input []byte) []string {
+	var keys []string
+	for len(input) > 0 {
+		switch {
+		case bytes.HasPrefix(input, []byte("\x1b[A")), bytes.HasPrefix(input, []byte("\x1bOA")):
+			keys = append(keys, keyUp)
+			input = input[3:]
+		case bytes.HasPrefix(input, []byte("\x1b[B")), bytes.HasPrefix(input, []byte("\x1bOB")):
+			keys = append(keys, keyDown)
+			input = input[3:]
+		case bytes.HasPrefix(input, []byte("\x1b[5~")):
+			keys = append(keys, keyPageUp)
+			input = input[4:]
+		case bytes.HasPrefix(input, []byte("\x1b[6~")):
+			keys = append(keys, keyPageDown)
+			input = input[4:]
+		case bytes.HasPrefix(input, []byte("\x1b[H")), bytes.HasPrefix(input, []byte("\x1bOH")):
+			keys = append(keys, keyHome)
+			input = input[3:]
+		case bytes.HasPrefix(input, []byte("\x1b[F")), bytes.HasPrefix(input, []byte("\x1bOF")):
+			keys = append(keys, keyEnd)
+			input = input[3:]
+		case bytes.HasPrefix(input, []byte("\x1b[")):
+			// ignore the other escape sequences, e.g. the other arrow keys,
+			// up to their final byte
+			n := 2
+			for n < len(input) && (input[n] < 0x40 || input[n] > 0x7e) {
+				n++
+			}
+			input = input[min(n+1, len(input)):]
+		case bytes.HasPrefix(input, []byte("\x1bO")):
+			input = input[min(3, len(input)):]
+		case input[0] == '\x1b':
+			keys = append(keys, keyEscape)
+			input = input[1:]
+		case input[0] == '\r', input[0] == '\n':
+			keys = append(keys, keyEnter)
+			input = input[1:]
+		case input[0] == '\x7f', input[0] == '\b':
+			keys = append(keys, keyBackspace)
+			input = input[1:]
+		case input[0] == '\x03':
+			keys = append(keys, keyInterrupt)
+			input = input[1:]
+		default:
+			r, size := utf8.DecodeRune(input)
+			if r != utf8.RuneError && r >= ' ' {
+				keys = append(keys, string(r))
+			}
+			input = input[size:]
+		}
+	}
+	return keys
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// runInteractiveStats displays the statistics of cStats in an interactive
+// view until the user quits, or closeChan reports an error.
+func runInteractiveStats(ctx context.Context, dockerCli command.Cli, cStats *stats, closeChan chan error, showAll, trunc bool) error {
+	if err := dockerCli.In().SetRawTerminal(); err != nil {
+		return err
+	}
+	defer dockerCli.In().RestoreTerminal()
+
+	// Use the alternate screen, and hide the cursor
+	fmt.Fprint(dockerCli.Out(), "\x1b[?1049h", aec.Hide)
+	defer fmt.Fprint(dockerCli.Out(), aec.Show, "\x1b[?1049l")
+
+	keys := make(chan string)
+	go readKeys(dockerCli.In(), keys)
+
+	view := newStatsView(daemonOSType, trunc)
+	snapshot := func() []formatter.StatsEntry {
+		entries := []formatter.StatsEntry{}
+		cStats.mu.Lock()
+		for _, c := range cStats.cs {
+			entries = append(entries, c.GetStatistics())
+		}
+		cStats.mu.Unlock()
+		return entries
+	}
+
+	// The content of the drill-down views is fetched in the background, one
+	// request at a time, to keep the view responsive. The low-level
+	// information of a container is only fetched once.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	details := make(chan statsDetail, 1)
+	fetching := false
+	refreshDetail := func() {
+		if view.mode == modeTable || fetching || (view.mode == modeInspect && view.fetched) {
+			return
+		}
+		fetching = true
+		go fetchDetail(ctx, dockerCli.Client(), view.mode, view.target, details)
+	}
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	entries := snapshot()
+	view.record(entries)
+	for {
+		if view.needsLabels() {
+			view.updateLabels(ctx, dockerCli.Client(), entries)
+		}
+		height, width := dockerCli.Out().GetTtySize()
+		if err := view.render(dockerCli.Out(), entries, int(width), int(height)); err != nil {
+			return err
+		}
+		if len(entries) == 0 && !showAll {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+			entries = snapshot()
+			view.record(entries)
+			refreshDetail()
+		case key, ok := <-keys:
+			if !ok || view.handleKey(key) {
+				return nil
+			}
+			refreshDetail()
+		case detail := <-details:
+			fetching = false
+			if detail.mode == view.mode && detail.container == view.target {
+				view.detail = detail.content
+				view.fetched = true
+			} else {
+				// the user drilled into another view in the meantime
+				refreshDetail()
+			}
+		case err, ok := <-closeChan:
+			if !ok {
+				// no asynchronous errors are expected
+				closeChan = nil
+				continue
+			}
+			if err != nil {
+				// this is suppressing "unexpected EOF" in the cli when the
+				// daemon restarts so it shutdowns cleanly
+				if err == io.ErrUnexpectedEOF {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package container
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/cli/internal/test"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func testStatsEntries() []formatter.StatsEntry {
+	return []formatter.StatsEntry{
+		{Container: "aaa", ID: "aaa111", Name: "/web", CPUPercentage: 10, Memory: 300, MemoryPercentage: 30, NetworkRx: 1, BlockRead: 50},
+		{Container: "bbb", ID: "bbb222", Name: "/db", CPUPercentage: 50, Memory: 100, MemoryPercentage: 10, NetworkRx: 30, BlockRead: 10},
+		{Container: "ccc", ID: "ccc333", Name: "/cache", CPUPercentage: 20, Memory: 200, MemoryPercentage: 20, NetworkTx: 20, BlockWrite: 100},
+	}
+}
+
+func rowNames(rows []formatter.StatsEntry) []string {
+	names := []string{}
+	for _, row := range rows {
+		names = append(names, row.Name)
+	}
+	return names
+}
+
+func TestStatsViewSort(t *testing.T) {
+	testCases := []struct {
+		keys     []string
+		expected []string
+	}{
+		{expected: []string{"/db", "/cache", "/web"}},
+		{keys: []string{"c"}, expected: []string{"/web", "/cache", "/db"}},
+		{keys: []string{"m"}, expected: []string{"/web", "/cache", "/db"}},
+		{keys: []string{"n"}, expected: []string{"/db", "/cache", "/web"}},
+		{keys: []string{"b"}, expected: []string{"/cache", "/web", "/db"}},
+		{keys: []string{"b", "b"}, expected: []string{"/db", "/web", "/cache"}},
+		{keys: []string{"s"}, expected: []string{"/cache", "/db", "/web"}},
+		{keys: []string{"s", "s"}, expected: []string{"/web", "/db", "/cache"}},
+	}
+	for _, tc := range testCases {
+		t.Run(strings.Join(tc.keys, ","), func(t *testing.T) {
+			view := newStatsView("linux", true)
+			for _, key := range tc.keys {
+				assert.Check(t, !view.handleKey(key))
+			}
+			assert.Check(t, is.DeepEqual(tc.expected, rowNames(view.visibleRows(testStatsEntries()))))
+		})
+	}
+}
+
+func TestStatsViewFilter(t *testing.T) {
+	view := newStatsView("linux", true)
+	view.labels = map[string]map[string]string{
+		"aaa111": {"tier": "front"},
+		"bbb222": {"tier": "back", "backup": ""},
+		"ccc333": {"tier": "back"},
+	}
+
+	testCases := []struct {
+		filter   string
+		expected []string
+	}{
+		{filter: "", expected: []string{"/db", "/cache", "/web"}},
+		{filter: "WE", expected: []string{"/web"}},
+		{filter: "bbb", expected: []string{"/db"}},
+		{filter: "label=tier=back", expected: []string{"/db", "/cache"}},
+		{filter: "label=backup", expected: []string{"/db"}},
+		{filter: "label=tier=back c", expected: []string{"/cache"}},
+		{filter: "nomatch", expected: []string{}},
+	}
+	for _, tc := range testCases {
+		view.filter = tc.filter
+		assert.Check(t, is.DeepEqual(tc.expected, rowNames(view.visibleRows(testStatsEntries()))), tc.filter)
+	}
+	view.filter = "label=tier"
+	assert.Check(t, view.needsLabels())
+	view.filter = "web"
+	assert.Check(t, !view.needsLabels())
+}
+
+func TestStatsViewEditFilter(t *testing.T) {
+	view := newStatsView("linux", true)
+	for _, key := range []string{"/", "d", "x", keyBackspace, "b", keyEnter} {
+		assert.Check(t, !view.handleKey(key))
+	}
+	assert.Check(t, is.Equal("db", view.filter))
+	assert.Check(t, !view.editing)
+
+	for _, key := range []string{"/", "q", keyEscape} {
+		assert.Check(t, !view.handleKey(key))
+	}
+	assert.Check(t, is.Equal("db", view.filter))
+
+	assert.Check(t, !view.handleKey(keyEscape))
+	assert.Check(t, is.Equal("", view.filter))
+}
+
+func TestStatsViewNavigation(t *testing.T) {
+	view := newStatsView("linux", true)
+	assert.NilError(t, view.render(&bytes.Buffer{}, testStatsEntries(), 0, 0))
+	assert.Check(t, is.Equal("bbb222", view.selected))
+
+	view.handleKey(keyDown)
+	view.handleKey("j")
+	view.handleKey("j")
+	assert.Check(t, is.Equal("aaa111", view.selected))
+	view.handleKey(keyUp)
+	assert.Check(t, is.Equal("ccc333", view.selected))
+
+	view.handleKey("l")
+	assert.Check(t, is.Equal(modeLogs, view.mode))
+	assert.Check(t, is.Equal("ccc333", view.target))
+	// sorting keys are ignored in the drill-down views
+	view.handleKey("c")
+	assert.Check(t, !view.ascending)
+	assert.Check(t, !view.handleKey("q"))
+	assert.Check(t, is.Equal(modeTable, view.mode))
+
+	assert.Check(t, view.handleKey("q"))
+	assert.Check(t, view.handleKey(keyInterrupt))
+}
+
+func TestStatsViewRender(t *testing.T) {
+	view := newStatsView("linux", true)
+	entries := testStatsEntries()
+	view.record(entries)
+	entries[1].CPUPercentage = 100
+	view.record(entries)
+
+	out := &bytes.Buffer{}
+	assert.NilError(t, view.render(out, entries, 200, 0))
+	lines := strings.Split(out.String(), "\r\n")
+	assert.Assert(t, is.Len(lines, 7))
+	assert.Check(t, is.Contains(lines[0], "Sort: cpu (descending)"))
+	assert.Check(t, is.Contains(lines[3], "CPU HISTORY"))
+	assert.Check(t, is.Contains(lines[3], "MEM HISTORY"))
+	assert.Check(t, is.Contains(lines[4], "db"))
+	assert.Check(t, is.Contains(lines[4], "▄█"))
+	assert.Check(t, strings.HasPrefix(lines[4], "\x1b[7m"), "selected row is highlighted")
+	assert.Check(t, is.Contains(lines[6], "web"))
+
+	out.Reset()
+	assert.NilError(t, view.render(out, entries, 20, 2))
+	lines = strings.Split(out.String(), "\r\n")
+	assert.Check(t, is.Len(lines, 2))
+	assert.Check(t, is.Equal("[c]pu [m]em [n]et [b", lines[1]))
+}
+
+func TestStatsViewScroll(t *testing.T) {
+	view := newStatsView("linux", true)
+	assert.NilError(t, view.render(&bytes.Buffer{}, testStatsEntries(), 0, 0))
+	view.handleKey("l")
+	var content []string
+	for i := 1; i <= 10; i++ {
+		content = append(content, fmt.Sprintf("line %d", i))
+	}
+	view.detail = strings.Join(content, "\n") + "\n"
+
+	page := func() []string {
+		out := &bytes.Buffer{}
+		assert.NilError(t, view.render(out, testStatsEntries(), 0, 6))
+		lines := strings.Split(out.String(), "\r\n")
+		assert.Assert(t, is.Len(lines, 6))
+		return lines
+	}
+
+	// the logs are anchored to their end
+	lines := page()
+	assert.Check(t, is.Contains(lines[0], "lines 7-10 of 10"))
+	assert.Check(t, is.DeepEqual([]string{"line 7", "line 8", "line 9", "line 10"}, lines[2:]))
+	view.detail += "line 11\n"
+	assert.Check(t, is.Equal("line 11", page()[5]))
+
+	view.handleKey("k")
+	assert.Check(t, !view.follow)
+	assert.Check(t, is.Equal("line 10", page()[5]))
+	view.detail += "line 12\n"
+	assert.Check(t, is.Equal("line 10", page()[5]), "the view stays in place once scrolled")
+
+	view.handleKey(keyPageUp)
+	assert.Check(t, is.Equal("line 3", page()[2]))
+	view.handleKey("g")
+	assert.Check(t, is.Equal("line 1", page()[2]))
+	view.handleKey(keyPageUp)
+	assert.Check(t, is.Equal("line 1", page()[2]))
+
+	view.handleKey("G")
+	assert.Check(t, is.Equal("line 12", page()[5]))
+	view.handleKey(keyUp)
+	view.handleKey(keyDown)
+	assert.Check(t, view.follow, "scrolling to the end follows the logs again")
+
+	// other drill-down views start at the top of their content
+	view.handleKey("q")
+	view.handleKey("i")
+	view.detail = strings.Join(content, "\n")
+	assert.Check(t, is.Equal("line 1", page()[2]))
+}
+
+func TestStatsViewRenderWindows(t *testing.T) {
+	view := newStatsView("windows", true)
+	out := &bytes.Buffer{}
+	assert.NilError(t, view.render(out, testStatsEntries(), 0, 0))
+	assert.Check(t, is.Contains(out.String(), "CPU HISTORY"))
+	assert.Check(t, !strings.Contains(out.String(), "MEM HISTORY"))
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Check(t, is.Equal("", sparkline(nil, 5, 100)))
+	assert.Check(t, is.Equal("▁▄█", sparkline([]float64{0, 50, 100}, 5, 100)))
+	assert.Check(t, is.Equal("▄█", sparkline([]float64{0, 50, 100}, 2, 100)))
+	// samples larger than max are scaled to the largest one
+	assert.Check(t, is.Equal("▁▄█", sparkline([]float64{0, 100, 200}, 5, 100)))
+}
+
+func TestStatsHistorySize(t *testing.T) {
+	view := newStatsView("linux", true)
+	entries := testStatsEntries()
+	for i := 0; i < statsHistorySize+10; i++ {
+		view.record(entries)
+	}
+	assert.Check(t, is.Len(view.history["aaa111"].cpu, statsHistorySize))
+}
+
+func TestParseKeys(t *testing.T) {
+	keys := parseKeys([]byte("c\x1b[A\x1b[Bq\x1b\r\x7f\x03é\x1b[C\x1b[5~\x1b[6~\x1b[H\x1bOF\x1b[2~G"))
+	expected := []string{"c", keyUp, keyDown, "q", keyEscape, keyEnter, keyBackspace, keyInterrupt, "é", keyPageUp, keyPageDown, keyHome, keyEnd, "G"}
+	assert.Check(t, is.DeepEqual(expected, keys))
+}
+
+func TestRunStatsInteractiveConflicts(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	err := runStats(cli, &statsOptions{interactive: true, noStream: true})
+	assert.Error(t, err, "--interactive cannot be used with --no-stream or --format")
+
+	err = runStats(cli, &statsOptions{interactive: true, format: "{{.Name}}"})
+	assert.Error(t, err, "--interactive cannot be used with --no-stream or --format")
+
+	err = runStats(cli, &statsOptions{interactive: true})
+	assert.Error(t, err, "--interactive requires a terminal")
+}
@@ -27,6 +27,7 @@ type updateOptions struct {
 	memorySwap         opts.MemSwapBytes
 	kernelMemory       opts.MemBytes
 	restartPolicy      string
+	restartBackoff     restartBackoffOptions
 	cpus               opts.NanoCPUs
 
 	nFlag int
@@ -65,6 +66,7 @@ func NewUpdateCommand(dockerCli command.Cli) *cobra.Command {
 	flags.Var(&options.memorySwap, "memory-swap", "Swap limit equal to memory plus swap: '-1' to enable unlimited swap")
 	flags.Var(&options.kernelMemory, "kernel-memory", "Kernel memory limit")
 	flags.StringVar(&options.restartPolicy, "restart", "", "Restart policy to apply when a container exits")
+	addRestartBackoffFlags(flags, &options.restartBackoff)
 
 	flags.Var(&options.cpus, "cpus", "Number of CPUs")
 	flags.SetAnnotation("cpus", "version", []string{"1.29"})
@@ -85,6 +87,11 @@ func runUpdate(dockerCli command.Cli, options *updateOptions) error {
 		if err != nil {
 			return err
 		}
+		if err := options.restartBackoff.apply(&restartPolicy); err != nil {
+			return err
+		}
+	} else if options.restartBackoff.isSet() {
+		return errors.New("--restart-* options require --restart")
 	}
 
 	resources := containertypes.Resources{
@@ -0,0 +1,89 @@
+package command
+
+import (
+	"os"
+
+	"github.com/docker/cli/cli/config/configfile"
+	contextstore "github.com/docker/cli/cli/context/store"
+	cliflags "github.com/docker/cli/cli/flags"
+	"github.com/docker/go-connections/tlsconfig"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultContextName is the name of the context connecting to the daemon
+	// set with the -H flag, the DOCKER_HOST environment variable and the TLS
+	// flags and environment variables.
+	DefaultContextName = "default"
+
+	envVarDockerContext = "DOCKER_CONTEXT"
+	envVarDockerHost    = "DOCKER_HOST"
+)
+
+// resolveContextName returns the name of the context to use. The --context
+// flag has precedence over the -H flag and the DOCKER_HOST environment
+// variable, which select the default context, then over the DOCKER_CONTEXT
+// environment variable and the current context of the configuration file.
+func resolveContextName(opts *cliflags.CommonOptions, configFile *configfile.ConfigFile) (string, error) {
+	if opts.Context != "" && len(opts.Hosts) > 0 {
+		return "", errors.New("Conflicting options: either specify --host or --context, not both")
+	}
+	if opts.Context != "" {
+		return opts.Context, nil
+	}
+	if len(opts.Hosts) > 0 || os.Getenv(envVarDockerHost) != "" {
+		return DefaultContextName, nil
+	}
+	if name := os.Getenv(envVarDockerContext); name != "" {
+		return name, nil
+	}
+	if configFile != nil && configFile.CurrentContext != "" {
+		return configFile.CurrentContext, nil
+	}
+	return DefaultContextName, nil
+}
+
+// contextCommonOptions returns the options connecting to the daemon of the
+// context named name, which replace the host and TLS options of opts.
+func contextCommonOptions(opts *cliflags.CommonOptions, store contextstore.Store, name string) (*cliflags.CommonOptions, error) {
+	if name == DefaultContextName {
+		return opts, nil
+	}
+	meta, err := store.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	tlsFiles, err := store.TLSFiles(name)
+	if err != nil {
+		return nil, err
+	}
+
+	contextOpts := *opts
+	contextOpts.Hosts = []string{meta.Endpoint.Host}
+	contextOpts.TLS = !tlsFiles.IsEmpty() || meta.Endpoint.SkipTLSVerify
+	contextOpts.TLSVerify = contextOpts.TLS && !meta.Endpoint.SkipTLSVerify
+	contextOpts.TLSOptions = nil
+	if contextOpts.TLS {
+		contextOpts.TLSOptions = &tlsconfig.Options{
+			CAFile:             tlsFiles.CAFile,
+			CertFile:           tlsFiles.CertFile,
+			KeyFile:            tlsFiles.KeyFile,
+			InsecureSkipVerify: meta.Endpoint.SkipTLSVerify,
+		}
+	}
+	return &contextOpts, nil
+}
+
+// ContextStackOrchestrator returns the default stack orchestrator of the
+// current context of dockerCli, or an empty string if it has none.
+func ContextStackOrchestrator(dockerCli Cli) string {
+	name := dockerCli.CurrentContext()
+	if name == "" || name == DefaultContextName {
+		return ""
+	}
+	meta, err := dockerCli.ContextStore().Get(name)
+	if err != nil {
+		return ""
+	}
+	return meta.StackOrchestrator
+}
@@ -0,0 +1,54 @@
+package context
+
+import (
+	"os"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	contextstore "github.com/docker/cli/cli/context/store"
+	"github.com/docker/cli/opts"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewContextCommand returns a cobra command for `context` subcommands
+func NewContextCommand(dockerCli command.Cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "Manage contexts",
+		Args:  cli.NoArgs,
+		RunE:  command.ShowHelp(dockerCli.Err()),
+	}
+	cmd.AddCommand(
+		newCreateCommand(dockerCli),
+		newExportCommand(dockerCli),
+		newImportCommand(dockerCli),
+		newInspectCommand(dockerCli),
+		newListCommand(dockerCli),
+		newRemoveCommand(dockerCli),
+		newUseCommand(dockerCli),
+	)
+	return cmd
+}
+
+// defaultContext returns the metadata of the default context, which connects
+// to the daemon set with the DOCKER_HOST environment variable.
+func defaultContext() contextstore.Metadata {
+	host, err := opts.ParseHost(false, os.Getenv("DOCKER_HOST"))
+	if err != nil {
+		host = os.Getenv("DOCKER_HOST")
+	}
+	return contextstore.Metadata{
+		Name:        command.DefaultContextName,
+		Description: "Current DOCKER_HOST based configuration",
+		Endpoint:    contextstore.Endpoint{Host: host},
+	}
+}
+
+// validateNewName returns an error if no new context can be named name
+func validateNewName(name string) error {
+	if name == command.DefaultContextName {
+		return errors.Errorf("%q is a reserved context name", name)
+	}
+	return contextstore.ValidateName(name)
+}
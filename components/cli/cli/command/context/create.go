@@ -0,0 +1,133 @@
+package context
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/connhelper"
+	contextstore "github.com/docker/cli/cli/context/store"
+	"github.com/docker/cli/opts"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	keyHost          = "host"
+	keyCA            = "ca"
+	keyCert          = "cert"
+	keyKey           = "key"
+	keySkipTLSVerify = "skip-tls-verify"
+)
+
+type createOptions struct {
+	name                     string
+	description              string
+	defaultStackOrchestrator string
+	docker                   map[string]string
+}
+
+func newCreateCommand(dockerCli command.Cli) *cobra.Command {
+	options := createOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create [OPTIONS] CONTEXT",
+		Short: "Create a context",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.name = args[0]
+			return runCreate(dockerCli, options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.description, "description", "", "Description of the context")
+	flags.StringVar(&options.defaultStackOrchestrator, "default-stack-orchestrator", "", "Default orchestrator for stack operations to use with this context (swarm|kubernetes|all)")
+	flags.StringToStringVar(&options.docker, "docker", nil, "Set the docker endpoint (e.g. host=tcp://myserver:2376,ca=ca.pem,cert=cert.pem,key=key.pem)")
+	return cmd
+}
+
+func runCreate(dockerCli command.Cli, options createOptions) error {
+	if err := validateNewName(options.name); err != nil {
+		return err
+	}
+	if options.defaultStackOrchestrator != "" {
+		if _, err := command.NormalizeOrchestrator(options.defaultStackOrchestrator); err != nil {
+			return err
+		}
+	}
+	endpoint, tlsData, err := parseDockerEndpoint(options.docker)
+	if err != nil {
+		return err
+	}
+
+	meta := contextstore.Metadata{
+		Name:              options.name,
+		Description:       options.description,
+		StackOrchestrator: options.defaultStackOrchestrator,
+		Endpoint:          endpoint,
+	}
+	if err := dockerCli.ContextStore().Create(meta, tlsData); err != nil {
+		return err
+	}
+	fmt.Fprintln(dockerCli.Out(), options.name)
+	fmt.Fprintf(dockerCli.Err(), "Successfully created context %q\n", options.name)
+	return nil
+}
+
+// parseDockerEndpoint returns the endpoint and the TLS material set with the
+// --docker flag.
+func parseDockerEndpoint(config map[string]string) (contextstore.Endpoint, *contextstore.TLSData, error) {
+	var (
+		endpoint contextstore.Endpoint
+		tlsData  contextstore.TLSData
+		hasTLS   bool
+	)
+	for key, value := range config {
+		switch key {
+		case keyHost:
+			endpoint.Host = value
+		case keySkipTLSVerify:
+			skip, err := strconv.ParseBool(value)
+			if err != nil {
+				return endpoint, nil, errors.Wrapf(err, "invalid value for %s", keySkipTLSVerify)
+			}
+			endpoint.SkipTLSVerify = skip
+		case keyCA, keyCert, keyKey:
+			data, err := ioutil.ReadFile(value)
+			if err != nil {
+				return endpoint, nil, err
+			}
+			switch key {
+			case keyCA:
+				tlsData.CA = data
+			case keyCert:
+				tlsData.Cert = data
+			case keyKey:
+				tlsData.Key = data
+			}
+			hasTLS = true
+		default:
+			return endpoint, nil, errors.Errorf("unrecognized docker endpoint option: %s", key)
+		}
+	}
+	if endpoint.Host == "" {
+		return endpoint, nil, errors.Errorf("the docker endpoint requires a %s", keyHost)
+	}
+	if (tlsData.Cert == nil) != (tlsData.Key == nil) {
+		return endpoint, nil, errors.Errorf("the docker endpoint requires both a %s and a %s, or none", keyCert, keyKey)
+	}
+	if !connhelper.IsConnectionHelperURL(endpoint.Host) {
+		host, err := opts.ParseHost(hasTLS || endpoint.SkipTLSVerify, endpoint.Host)
+		if err != nil {
+			return endpoint, nil, err
+		}
+		endpoint.Host = host
+	}
+	if !hasTLS {
+		return endpoint, nil, nil
+	}
+	return endpoint, &tlsData, nil
+}
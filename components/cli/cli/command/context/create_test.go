@@ -0,0 +1,151 @@
+package context
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/cli/cli/config/configfile"
+	contextstore "github.com/docker/cli/cli/context/store"
+	"github.com/docker/cli/internal/test"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/fs"
+)
+
+func makeFakeCli(t *testing.T) (*test.FakeCli, func()) {
+	dir := fs.NewDir(t, "context-test")
+	cli := test.NewFakeCli(nil)
+	cli.SetContextStore(contextstore.NewStore(dir.Join("contexts")))
+	cli.SetConfigFile(configfile.New(dir.Join("config.json")))
+	return cli, dir.Remove
+}
+
+func createTestContext(t *testing.T, cli *test.FakeCli, name string) {
+	cmd := newCreateCommand(cli)
+	cmd.SetArgs([]string{name, "--docker", "host=tcp://" + name + ":2375"})
+	cmd.SetOutput(ioutil.Discard)
+	assert.NilError(t, cmd.Execute())
+}
+
+func TestCreateErrors(t *testing.T) {
+	testCases := []struct {
+		args          []string
+		expectedError string
+	}{
+		{
+			args:          []string{},
+			expectedError: "requires exactly 1 argument",
+		},
+		{
+			args:          []string{"default", "--docker", "host=tcp://foo:2375"},
+			expectedError: `"default" is a reserved context name`,
+		},
+		{
+			args:          []string{"in/valid", "--docker", "host=tcp://foo:2375"},
+			expectedError: "is invalid",
+		},
+		{
+			args:          []string{"foo"},
+			expectedError: "the docker endpoint requires a host",
+		},
+		{
+			args:          []string{"foo", "--docker", "host=tcp://foo:2375,bar=baz"},
+			expectedError: "unrecognized docker endpoint option: bar",
+		},
+		{
+			args:          []string{"foo", "--docker", "host=http://foo"},
+			expectedError: "Invalid bind address format",
+		},
+		{
+			args:          []string{"foo", "--docker", "host=tcp://foo:2375", "--default-stack-orchestrator", "invalid"},
+			expectedError: `specified orchestrator "invalid" is invalid`,
+		},
+	}
+	for _, tc := range testCases {
+		cli, cleanup := makeFakeCli(t)
+		cmd := newCreateCommand(cli)
+		cmd.SetArgs(tc.args)
+		cmd.SetOutput(ioutil.Discard)
+		assert.ErrorContains(t, cmd.Execute(), tc.expectedError)
+		cleanup()
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	cmd := newCreateCommand(cli)
+	cmd.SetArgs([]string{"prod",
+		"--description", "production",
+		"--default-stack-orchestrator", "kubernetes",
+		"--docker", "host=tcp://prod:2376,skip-tls-verify=true",
+	})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal("prod\n", cli.OutBuffer().String()))
+	assert.Check(t, is.Equal("Successfully created context \"prod\"\n", cli.ErrBuffer().String()))
+
+	meta, err := cli.ContextStore().Get("prod")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(contextstore.Metadata{
+		Name:              "prod",
+		Description:       "production",
+		StackOrchestrator: "kubernetes",
+		Endpoint:          contextstore.Endpoint{Host: "tcp://prod:2376", SkipTLSVerify: true},
+	}, meta))
+}
+
+func TestCreateWithConnectionHelper(t *testing.T) {
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	cmd := newCreateCommand(cli)
+	cmd.SetArgs([]string{"dind", "--docker", "host=cmd://kubectl exec -i dind -- docker system dial-stdio"})
+	assert.NilError(t, cmd.Execute())
+
+	meta, err := cli.ContextStore().Get("dind")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("cmd://kubectl exec -i dind -- docker system dial-stdio", meta.Endpoint.Host))
+}
+
+func TestCreateWithTLS(t *testing.T) {
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	certs := fs.NewDir(t, "context-test-certs",
+		fs.WithFile("ca.pem", "ca"),
+		fs.WithFile("cert.pem", "cert"),
+		fs.WithFile("key.pem", "key"),
+	)
+	defer certs.Remove()
+
+	cmd := newCreateCommand(cli)
+	cmd.SetArgs([]string{"secure", "--docker", "host=secure:2376,ca=" + certs.Join("ca.pem") +
+		",cert=" + certs.Join("cert.pem") + ",key=" + certs.Join("key.pem")})
+	assert.NilError(t, cmd.Execute())
+
+	meta, err := cli.ContextStore().Get("secure")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("tcp://secure:2376", meta.Endpoint.Host))
+
+	tlsFiles, err := cli.ContextStore().TLSFiles("secure")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("key.pem", filepath.Base(tlsFiles.KeyFile)))
+	content, err := ioutil.ReadFile(tlsFiles.CAFile)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("ca", string(content)))
+}
+
+func TestCreateCertWithoutKey(t *testing.T) {
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	certs := fs.NewDir(t, "context-test-certs", fs.WithFile("cert.pem", "cert"))
+	defer certs.Remove()
+
+	cmd := newCreateCommand(cli)
+	cmd.SetArgs([]string{"secure", "--docker", "host=tcp://secure:2376,cert=" + certs.Join("cert.pem")})
+	cmd.SetOutput(ioutil.Discard)
+	assert.ErrorContains(t, cmd.Execute(), "requires both a cert and a key")
+}
@@ -0,0 +1,55 @@
+package context
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type exportOptions struct {
+	name   string
+	output string
+}
+
+func newExportCommand(dockerCli command.Cli) *cobra.Command {
+	var options exportOptions
+
+	cmd := &cobra.Command{
+		Use:   "export [OPTIONS] CONTEXT",
+		Short: "Export a context, including its TLS material, as a tar archive",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.name = args[0]
+			return runExport(dockerCli, options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.output, "output", "o", "", "Write to a file, instead of STDOUT")
+
+	return cmd
+}
+
+func runExport(dockerCli command.Cli, options exportOptions) error {
+	if options.name == command.DefaultContextName {
+		return errors.New("the default context cannot be exported")
+	}
+	if options.output == "" && dockerCli.Out().IsTerminal() {
+		return errors.New("cowardly refusing to save to a terminal. Use the -o flag or redirect")
+	}
+
+	var archive bytes.Buffer
+	if err := dockerCli.ContextStore().Export(options.name, &archive); err != nil {
+		return err
+	}
+
+	if options.output == "" {
+		_, err := io.Copy(dockerCli.Out(), &archive)
+		return err
+	}
+	return command.CopyToFile(options.output, &archive)
+}
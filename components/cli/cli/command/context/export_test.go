@@ -0,0 +1,103 @@
+package context
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/cli/command"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/fs"
+)
+
+func TestExportImport(t *testing.T) {
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	dir := fs.NewDir(t, "context-export-test")
+	defer dir.Remove()
+
+	createTestContext(t, cli, "remote")
+
+	cmd := newExportCommand(cli)
+	cmd.SetArgs([]string{"-o", dir.Join("remote.tar"), "remote"})
+	assert.NilError(t, cmd.Execute())
+
+	cli.OutBuffer().Reset()
+	cmd = newImportCommand(cli)
+	cmd.SetArgs([]string{"-i", dir.Join("remote.tar"), "copy"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal("copy\n", cli.OutBuffer().String()))
+
+	meta, err := cli.ContextStore().Get("copy")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("tcp://remote:2375", meta.Endpoint.Host))
+}
+
+func TestExportDefault(t *testing.T) {
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	cmd := newExportCommand(cli)
+	cmd.SetArgs([]string{"default"})
+	cmd.SetOutput(ioutil.Discard)
+	assert.ErrorContains(t, cmd.Execute(), "the default context cannot be exported")
+}
+
+func TestImportReservedName(t *testing.T) {
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	cmd := newImportCommand(cli)
+	cmd.SetArgs([]string{"default"})
+	cmd.SetOutput(ioutil.Discard)
+	assert.ErrorContains(t, cmd.Execute(), `"default" is a reserved context name`)
+}
+
+func TestImportCommandContext(t *testing.T) {
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	dir := fs.NewDir(t, "context-export-test")
+	defer dir.Remove()
+
+	cmd := newCreateCommand(cli)
+	cmd.SetArgs([]string{"remote", "--docker", "host=cmd://kubectl exec -i dind -- docker system dial-stdio"})
+	assert.NilError(t, cmd.Execute())
+	cmd = newExportCommand(cli)
+	cmd.SetArgs([]string{"-o", dir.Join("remote.tar"), "remote"})
+	assert.NilError(t, cmd.Execute())
+
+	importContext := func(input string, args ...string) error {
+		cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader(input))))
+		cli.ErrBuffer().Reset()
+		cmd := newImportCommand(cli)
+		cmd.SetArgs(args)
+		cmd.SetOutput(ioutil.Discard)
+		return cmd.Execute()
+	}
+	exists := func(name string) bool {
+		_, err := cli.ContextStore().Get(name)
+		return err == nil
+	}
+
+	err := importContext("n", "-i", dir.Join("remote.tar"), "declined")
+	assert.Check(t, is.ErrorContains(err, `context "declined" was not imported`))
+	assert.Check(t, is.Contains(cli.ErrBuffer().String(), "connects to cmd://kubectl exec"))
+	assert.Check(t, !exists("declined"))
+
+	assert.NilError(t, importContext("y", "-i", dir.Join("remote.tar"), "confirmed"))
+	assert.Check(t, exists("confirmed"))
+
+	// the confirmation cannot be read from STDIN if the archive is read from it
+	archive, err := ioutil.ReadFile(dir.Join("remote.tar"))
+	assert.NilError(t, err)
+	err = importContext(string(archive), "stdin")
+	assert.Check(t, is.ErrorContains(err, "use --force to import it"))
+	assert.Check(t, !exists("stdin"))
+
+	assert.NilError(t, importContext(string(archive), "--force", "forced"))
+	assert.Check(t, exists("forced"))
+	assert.Check(t, is.Contains(cli.ErrBuffer().String(), "connects to cmd://kubectl exec"))
+}
@@ -0,0 +1,95 @@
+package context
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/connhelper"
+	contextstore "github.com/docker/cli/cli/context/store"
+	"github.com/docker/docker/pkg/system"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type importOptions struct {
+	name  string
+	input string
+	force bool
+}
+
+func newImportCommand(dockerCli command.Cli) *cobra.Command {
+	var options importOptions
+
+	cmd := &cobra.Command{
+		Use:   "import [OPTIONS] CONTEXT",
+		Short: "Import a context from a tar archive or STDIN",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.name = args[0]
+			return runImport(dockerCli, options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.input, "input", "i", "", "Read from tar archive file, instead of STDIN")
+	flags.BoolVarP(&options.force, "force", "f", false, "Do not prompt for confirmation if the context runs a command to connect")
+
+	return cmd
+}
+
+func runImport(dockerCli command.Cli, options importOptions) error {
+	if err := validateNewName(options.name); err != nil {
+		return err
+	}
+
+	var input io.Reader = dockerCli.In()
+	if options.input != "" {
+		file, err := system.OpenSequential(options.input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	if options.input == "" && dockerCli.In().IsTerminal() {
+		return errors.Errorf("requested import from stdin, but stdin is empty")
+	}
+
+	// The context is only saved once it is confirmed, as it may come from
+	// another user.
+	imported, err := dockerCli.ContextStore().ReadImport(options.name, input)
+	if err != nil {
+		return err
+	}
+	if err := confirmImport(dockerCli, options, imported.Metadata); err != nil {
+		return err
+	}
+	if err := dockerCli.ContextStore().CommitImport(imported); err != nil {
+		return err
+	}
+	fmt.Fprintln(dockerCli.Out(), options.name)
+	fmt.Fprintf(dockerCli.Err(), "Successfully imported context %q\n", options.name)
+	return nil
+}
+
+// confirmImport prints the host the imported context connects to, and
+// requires a confirmation if connecting to it runs a command chosen by the
+// context, as contexts may be received from other users.
+func confirmImport(dockerCli command.Cli, options importOptions, meta contextstore.Metadata) error {
+	host := meta.Endpoint.Host
+	fmt.Fprintf(dockerCli.Err(), "Context %q connects to %s\n", options.name, host)
+	if options.force || !connhelper.RunsCommand(host) {
+		return nil
+	}
+	if options.input == "" {
+		return errors.Errorf("context %q runs a command to connect to the daemon, use --force to import it", options.name)
+	}
+	warning := fmt.Sprintf("WARNING! Context %q runs a command on this machine to connect to the daemon: %s\nOnly import it if you trust its source.\nAre you sure you want to continue?", options.name, host)
+	if !command.PromptForConfirmation(dockerCli.In(), dockerCli.Out(), warning) {
+		return errors.Errorf("context %q was not imported", options.name)
+	}
+	return nil
+}
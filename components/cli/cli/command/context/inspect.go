@@ -0,0 +1,58 @@
+package context
+
+import (
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/inspect"
+	contextstore "github.com/docker/cli/cli/context/store"
+	"github.com/spf13/cobra"
+)
+
+type inspectOptions struct {
+	format string
+	names  []string
+}
+
+// contextInspect is the inspect output of a context
+type contextInspect struct {
+	contextstore.Metadata
+	TLSMaterial contextstore.TLSFiles
+}
+
+func newInspectCommand(dockerCli command.Cli) *cobra.Command {
+	var opts inspectOptions
+
+	cmd := &cobra.Command{
+		Use:   "inspect [OPTIONS] [CONTEXT] [CONTEXT...]",
+		Short: "Display detailed information on one or more contexts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.names = args
+			if len(opts.names) == 0 {
+				opts.names = []string{dockerCli.CurrentContext()}
+			}
+			return runInspect(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.format, "format", "f", "", "Format the output using the given Go template")
+	return cmd
+}
+
+func runInspect(dockerCli command.Cli, opts inspectOptions) error {
+	getContextFunc := func(name string) (interface{}, []byte, error) {
+		if name == command.DefaultContextName {
+			return contextInspect{Metadata: defaultContext()}, nil, nil
+		}
+		meta, err := dockerCli.ContextStore().Get(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		tlsFiles, err := dockerCli.ContextStore().TLSFiles(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		return contextInspect{Metadata: meta, TLSMaterial: tlsFiles}, nil, nil
+	}
+
+	return inspect.Inspect(dockerCli.Out(), opts.names, opts.format, getContextFunc)
+}
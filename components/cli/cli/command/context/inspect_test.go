@@ -0,0 +1,30 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/docker/cli/opts"
+	"gotest.tools/assert"
+	"gotest.tools/env"
+)
+
+func TestInspect(t *testing.T) {
+	defer env.Patch(t, "DOCKER_HOST", "")()
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	createTestContext(t, cli, "remote")
+	cli.SetCurrentContext("remote")
+	cli.OutBuffer().Reset()
+
+	cmd := newInspectCommand(cli)
+	cmd.SetArgs([]string{"--format", "{{.Name}} {{.Endpoint.Host}}"})
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, "remote tcp://remote:2375\n", cli.OutBuffer().String())
+
+	cli.OutBuffer().Reset()
+	cmd = newInspectCommand(cli)
+	cmd.SetArgs([]string{"--format", "{{.Name}} {{.Endpoint.Host}}", "default"})
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, "default "+opts.DefaultHost+"\n", cli.OutBuffer().String())
+}
@@ -0,0 +1,62 @@
+package context
+
+import (
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	quiet  bool
+	format string
+}
+
+func newListCommand(dockerCli command.Cli) *cobra.Command {
+	options := listOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List contexts",
+		Args:    cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(dockerCli, options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Only show context names")
+	flags.StringVar(&options.format, "format", "", "Pretty-print contexts using a Go template")
+	return cmd
+}
+
+func runList(dockerCli command.Cli, options listOptions) error {
+	contexts, err := dockerCli.ContextStore().List()
+	if err != nil {
+		return err
+	}
+
+	current := dockerCli.CurrentContext()
+	items := []formatter.ContextListItem{{
+		Metadata: defaultContext(),
+		Current:  current == command.DefaultContextName,
+	}}
+	for _, c := range contexts {
+		items = append(items, formatter.ContextListItem{
+			Metadata: c,
+			Current:  c.Name == current,
+		})
+	}
+
+	format := options.format
+	if len(format) == 0 {
+		format = formatter.TableFormatKey
+	}
+
+	contextCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: formatter.NewContextFormat(format, options.quiet),
+	}
+	return formatter.ContextWrite(contextCtx, items)
+}
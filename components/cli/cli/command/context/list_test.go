@@ -0,0 +1,48 @@
+package context
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	"gotest.tools/env"
+	"gotest.tools/golden"
+)
+
+func TestListContexts(t *testing.T) {
+	defer env.Patch(t, "DOCKER_HOST", "tcp://localhost:2375")()
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	createTestContext(t, cli, "remote")
+	createTestContext(t, cli, "other")
+	cli.SetCurrentContext("remote")
+	cli.OutBuffer().Reset()
+
+	cmd := newListCommand(cli)
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "list.golden")
+}
+
+func TestListContextsQuiet(t *testing.T) {
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	createTestContext(t, cli, "remote")
+	cli.OutBuffer().Reset()
+
+	cmd := newListCommand(cli)
+	cmd.SetArgs([]string{"-q"})
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, "default\nremote\n", cli.OutBuffer().String())
+}
+
+func TestListContextsEmptyStore(t *testing.T) {
+	defer env.Patch(t, "DOCKER_HOST", "tcp://example.com:2375")()
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	cmd := newListCommand(cli)
+	cmd.SetArgs([]string{"--format", "{{.Name}}{{if .Current}} *{{end}} {{.DockerEndpoint}}"})
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, "default * tcp://example.com:2375\n", cli.OutBuffer().String())
+}
@@ -0,0 +1,70 @@
+package context
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type removeOptions struct {
+	force bool
+
+	contexts []string
+}
+
+func newRemoveCommand(dockerCli command.Cli) *cobra.Command {
+	var opts removeOptions
+
+	cmd := &cobra.Command{
+		Use:     "rm [OPTIONS] CONTEXT [CONTEXT...]",
+		Aliases: []string{"remove"},
+		Short:   "Remove one or more contexts",
+		Args:    cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.contexts = args
+			return runRemove(dockerCli, &opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.force, "force", "f", false, "Force the removal of a context in use")
+	return cmd
+}
+
+func runRemove(dockerCli command.Cli, opts *removeOptions) error {
+	var errs []string
+
+	configFile := dockerCli.ConfigFile()
+	for _, name := range opts.contexts {
+		if err := removeContext(dockerCli, name, opts.force); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if configFile.CurrentContext == name {
+			configFile.CurrentContext = ""
+			if err := configFile.Save(); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+		fmt.Fprintf(dockerCli.Out(), "%s\n", name)
+	}
+
+	if len(errs) > 0 {
+		return errors.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+func removeContext(dockerCli command.Cli, name string, force bool) error {
+	if name == command.DefaultContextName {
+		return errors.Errorf("default context cannot be removed")
+	}
+	if name == dockerCli.CurrentContext() && !force {
+		return errors.Errorf("context %q is in use, set -f flag to force remove", name)
+	}
+	return dockerCli.ContextStore().Remove(name)
+}
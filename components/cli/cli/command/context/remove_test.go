@@ -0,0 +1,63 @@
+package context
+
+import (
+	"io/ioutil"
+	"testing"
+
+	contextstore "github.com/docker/cli/cli/context/store"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestRemove(t *testing.T) {
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	createTestContext(t, cli, "first")
+	createTestContext(t, cli, "second")
+	cli.OutBuffer().Reset()
+
+	cmd := newRemoveCommand(cli)
+	cmd.SetArgs([]string{"first", "second"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal("first\nsecond\n", cli.OutBuffer().String()))
+
+	contexts, err := cli.ContextStore().List()
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(contexts, 0))
+}
+
+func TestRemoveErrors(t *testing.T) {
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	createTestContext(t, cli, "current")
+	cli.SetCurrentContext("current")
+
+	cmd := newRemoveCommand(cli)
+	cmd.SetArgs([]string{"default", "current", "missing"})
+	cmd.SetOutput(ioutil.Discard)
+	assert.Error(t, cmd.Execute(), "default context cannot be removed\n"+
+		"context \"current\" is in use, set -f flag to force remove\n"+
+		"context \"missing\" does not exist")
+
+	_, err := cli.ContextStore().Get("current")
+	assert.NilError(t, err)
+}
+
+func TestRemoveCurrentForce(t *testing.T) {
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	createTestContext(t, cli, "current")
+	cli.SetCurrentContext("current")
+	cli.ConfigFile().CurrentContext = "current"
+
+	cmd := newRemoveCommand(cli)
+	cmd.SetArgs([]string{"-f", "current"})
+	assert.NilError(t, cmd.Execute())
+
+	_, err := cli.ContextStore().Get("current")
+	assert.Check(t, contextstore.IsNotFound(err))
+	assert.Check(t, is.Equal("", cli.ConfigFile().CurrentContext))
+}
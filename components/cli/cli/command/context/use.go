@@ -0,0 +1,46 @@
+package context
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+)
+
+func newUseCommand(dockerCli command.Cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "use CONTEXT",
+		Short: "Set the current docker context",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUse(dockerCli, args[0])
+		},
+	}
+	return cmd
+}
+
+func runUse(dockerCli command.Cli, name string) error {
+	if name != command.DefaultContextName {
+		if _, err := dockerCli.ContextStore().Get(name); err != nil {
+			return err
+		}
+	}
+
+	configFile := dockerCli.ConfigFile()
+	configFile.CurrentContext = name
+	if name == command.DefaultContextName {
+		configFile.CurrentContext = ""
+	}
+	if err := configFile.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintln(dockerCli.Out(), name)
+	fmt.Fprintf(dockerCli.Err(), "Current context is now %q\n", name)
+	if os.Getenv("DOCKER_HOST") != "" {
+		fmt.Fprintf(dockerCli.Err(), "Warning: DOCKER_HOST environment variable overrides the active context. "+
+			"To use %q, either set the global --context flag, or unset DOCKER_HOST environment variable.\n", name)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package context
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/cli/cli/config"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/env"
+)
+
+func TestUse(t *testing.T) {
+	defer env.Patch(t, "DOCKER_HOST", "")()
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	createTestContext(t, cli, "remote")
+	cli.OutBuffer().Reset()
+	cli.ErrBuffer().Reset()
+
+	cmd := newUseCommand(cli)
+	cmd.SetArgs([]string{"remote"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal("remote\n", cli.OutBuffer().String()))
+	assert.Check(t, is.Equal("Current context is now \"remote\"\n", cli.ErrBuffer().String()))
+
+	configFile, err := config.Load(filepath.Dir(cli.ConfigFile().Filename))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("remote", configFile.CurrentContext))
+
+	cmd = newUseCommand(cli)
+	cmd.SetArgs([]string{"default"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal("", cli.ConfigFile().CurrentContext))
+}
+
+func TestUseNotFound(t *testing.T) {
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	cmd := newUseCommand(cli)
+	cmd.SetArgs([]string{"missing"})
+	cmd.SetOutput(ioutil.Discard)
+	assert.ErrorContains(t, cmd.Execute(), `context "missing" does not exist`)
+	assert.Check(t, is.Equal("", cli.ConfigFile().CurrentContext))
+}
+
+func TestUseWithDockerHost(t *testing.T) {
+	defer env.Patch(t, "DOCKER_HOST", "tcp://example.com:2375")()
+	cli, cleanup := makeFakeCli(t)
+	defer cleanup()
+
+	createTestContext(t, cli, "remote")
+	cli.ErrBuffer().Reset()
+
+	cmd := newUseCommand(cli)
+	cmd.SetArgs([]string{"remote"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Contains(cli.ErrBuffer().String(), "Warning: DOCKER_HOST environment variable overrides the active context"))
+}
@@ -0,0 +1,116 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/docker/cli/cli/config/configfile"
+	contextstore "github.com/docker/cli/cli/context/store"
+	cliflags "github.com/docker/cli/cli/flags"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/env"
+)
+
+func TestResolveContextName(t *testing.T) {
+	configFile := &configfile.ConfigFile{CurrentContext: "from-config"}
+
+	testCases := []struct {
+		doc        string
+		opts       cliflags.CommonOptions
+		env        map[string]string
+		configFile *configfile.ConfigFile
+		expected   string
+	}{
+		{
+			doc:      "nothing set",
+			expected: DefaultContextName,
+		},
+		{
+			doc:        "config file",
+			configFile: configFile,
+			expected:   "from-config",
+		},
+		{
+			doc:        "DOCKER_CONTEXT overrides config file",
+			env:        map[string]string{"DOCKER_CONTEXT": "from-env"},
+			configFile: configFile,
+			expected:   "from-env",
+		},
+		{
+			doc:        "DOCKER_HOST selects the default context",
+			env:        map[string]string{"DOCKER_CONTEXT": "from-env", "DOCKER_HOST": "tcp://foo:2375"},
+			configFile: configFile,
+			expected:   DefaultContextName,
+		},
+		{
+			doc:        "-H selects the default context",
+			opts:       cliflags.CommonOptions{Hosts: []string{"tcp://foo:2375"}},
+			env:        map[string]string{"DOCKER_CONTEXT": "from-env"},
+			configFile: configFile,
+			expected:   DefaultContextName,
+		},
+		{
+			doc:        "--context overrides everything",
+			opts:       cliflags.CommonOptions{Context: "from-flag"},
+			env:        map[string]string{"DOCKER_CONTEXT": "from-env", "DOCKER_HOST": "tcp://foo:2375"},
+			configFile: configFile,
+			expected:   "from-flag",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.doc, func(t *testing.T) {
+			defer env.PatchAll(t, tc.env)()
+			name, err := resolveContextName(&tc.opts, tc.configFile)
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(tc.expected, name))
+		})
+	}
+}
+
+func TestResolveContextNameConflict(t *testing.T) {
+	opts := &cliflags.CommonOptions{Context: "foo", Hosts: []string{"tcp://foo:2375"}}
+	_, err := resolveContextName(opts, nil)
+	assert.Error(t, err, "Conflicting options: either specify --host or --context, not both")
+}
+
+func TestContextCommonOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "context-test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	store := contextstore.NewStore(dir)
+
+	assert.NilError(t, store.Create(contextstore.Metadata{
+		Name:     "plain",
+		Endpoint: contextstore.Endpoint{Host: "tcp://plain:2375"},
+	}, nil))
+	assert.NilError(t, store.Create(contextstore.Metadata{
+		Name:     "secure",
+		Endpoint: contextstore.Endpoint{Host: "tcp://secure:2376"},
+	}, &contextstore.TLSData{CA: []byte("ca")}))
+
+	opts := &cliflags.CommonOptions{Debug: true}
+
+	contextOpts, err := contextCommonOptions(opts, store, DefaultContextName)
+	assert.NilError(t, err)
+	assert.Check(t, contextOpts == opts)
+
+	contextOpts, err = contextCommonOptions(opts, store, "plain")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"tcp://plain:2375"}, contextOpts.Hosts))
+	assert.Check(t, contextOpts.Debug)
+	assert.Check(t, !contextOpts.TLS)
+	assert.Check(t, is.Nil(contextOpts.TLSOptions))
+
+	contextOpts, err = contextCommonOptions(opts, store, "secure")
+	assert.NilError(t, err)
+	assert.Check(t, contextOpts.TLSVerify)
+	assert.Assert(t, contextOpts.TLSOptions != nil)
+	assert.Check(t, contextOpts.TLSOptions.CAFile != "")
+	assert.Check(t, is.Equal("", contextOpts.TLSOptions.CertFile))
+	assert.Check(t, !contextOpts.TLSOptions.InsecureSkipVerify)
+
+	_, err = contextCommonOptions(opts, store, "missing")
+	assert.Check(t, contextstore.IsNotFound(err))
+}
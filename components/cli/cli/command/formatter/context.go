@@ -0,0 +1,96 @@
+package formatter
+
+import (
+	contextstore "github.com/docker/cli/cli/context/store"
+)
+
+const (
+	defaultContextQuietFormat = "{{.Name}}"
+	defaultContextTableFormat = "table {{.Name}}{{if .Current}} *{{end}}\t{{.Description}}\t{{.DockerEndpoint}}\t{{.StackOrchestrator}}"
+
+	contextNameHeader              = "NAME"
+	contextDescriptionHeader       = "DESCRIPTION"
+	contextDockerEndpointHeader    = "DOCKER ENDPOINT"
+	contextStackOrchestratorHeader = "ORCHESTRATOR"
+)
+
+// ContextListItem is a context listed by docker context ls
+type ContextListItem struct {
+	contextstore.Metadata
+	Current bool
+}
+
+// NewContextFormat returns a format for use with a context Context
+func NewContextFormat(source string, quiet bool) Format {
+	switch source {
+	case TableFormatKey:
+		if quiet {
+			return defaultContextQuietFormat
+		}
+		return defaultContextTableFormat
+	case RawFormatKey:
+		if quiet {
+			return `name: {{.Name}}`
+		}
+		return `name: {{.Name}}
+current: {{.Current}}
+description: {{.Description}}
+docker_endpoint: {{.DockerEndpoint}}
+orchestrator: {{.StackOrchestrator}}
+`
+	}
+	return Format(source)
+}
+
+// ContextWrite writes formatted contexts using the Context
+func ContextWrite(ctx Context, contexts []ContextListItem) error {
+	render := func(format func(subContext subContext) error) error {
+		for _, c := range contexts {
+			if err := format(&contextContext{c: c}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return ctx.Write(newContextContext(), render)
+}
+
+type contextContext struct {
+	HeaderContext
+	c ContextListItem
+}
+
+func newContextContext() *contextContext {
+	contextCtx := contextContext{}
+	contextCtx.header = map[string]string{
+		"Name":              contextNameHeader,
+		"Description":       contextDescriptionHeader,
+		"DockerEndpoint":    contextDockerEndpointHeader,
+		"StackOrchestrator": contextStackOrchestratorHeader,
+	}
+	return &contextCtx
+}
+
+func (c *contextContext) MarshalJSON() ([]byte, error) {
+	return marshalJSON(c)
+}
+
+func (c *contextContext) Name() string {
+	return c.c.Name
+}
+
+func (c *contextContext) Current() bool {
+	return c.c.Current
+}
+
+func (c *contextContext) Description() string {
+	return c.c.Description
+}
+
+func (c *contextContext) DockerEndpoint() string {
+	return c.c.Endpoint.Host
+}
+
+func (c *contextContext) StackOrchestrator() string {
+	return c.c.Metadata.StackOrchestrator
+}
@@ -0,0 +1,78 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	contextstore "github.com/docker/cli/cli/context/store"
+	"gotest.tools/assert"
+)
+
+func TestContextContextWrite(t *testing.T) {
+	cases := []struct {
+		context  Context
+		expected string
+	}{
+		{
+			Context{Format: NewContextFormat("table", false)},
+			`NAME                DESCRIPTION         DOCKER ENDPOINT               ORCHESTRATOR
+default *           Default context     unix:///var/run/docker.sock   
+prod                Production          tcp://prod:2376               kubernetes
+`,
+		},
+		{
+			Context{Format: NewContextFormat("table", true)},
+			`default
+prod
+`,
+		},
+		{
+			Context{Format: NewContextFormat("{{.Name}}:{{.Current}}", false)},
+			`default:true
+prod:false
+`,
+		},
+		{
+			Context{Format: NewContextFormat("raw", false)},
+			`name: default
+current: true
+description: Default context
+docker_endpoint: unix:///var/run/docker.sock
+orchestrator: ` + `
+
+name: prod
+current: false
+description: Production
+docker_endpoint: tcp://prod:2376
+orchestrator: kubernetes
+
+`,
+		},
+	}
+
+	contexts := []ContextListItem{
+		{
+			Metadata: contextstore.Metadata{
+				Name:        "default",
+				Description: "Default context",
+				Endpoint:    contextstore.Endpoint{Host: "unix:///var/run/docker.sock"},
+			},
+			Current: true,
+		},
+		{
+			Metadata: contextstore.Metadata{
+				Name:              "prod",
+				Description:       "Production",
+				StackOrchestrator: "kubernetes",
+				Endpoint:          contextstore.Endpoint{Host: "tcp://prod:2376"},
+			},
+		},
+	}
+	for _, testcase := range cases {
+		out := bytes.NewBufferString("")
+		testcase.context.Output = out
+		err := ContextWrite(testcase.context, contexts)
+		assert.NilError(t, err)
+		assert.Equal(t, out.String(), testcase.expected)
+	}
+}
@@ -30,13 +30,16 @@ const (
 // DiskUsageContext contains disk usage specific information required by the formatter, encapsulate a Context struct.
 type DiskUsageContext struct {
 	Context
-	Verbose     bool
-	LayersSize  int64
-	Images      []*types.ImageSummary
-	Containers  []*types.Container
-	Volumes     []*types.Volume
-	BuildCache  []*types.BuildCache
-	BuilderSize int64
+	Verbose           bool
+	ShowLayers        bool
+	LayersSize        int64
+	Images            []*types.ImageSummary
+	Containers        []*types.Container
+	Volumes           []*types.Volume
+	BuildCache        []*types.BuildCache
+	BuilderSize       int64
+	Layers            []*types.LayerUsage
+	ImagesReclaimable []*types.ImageReclaimableSpace
 }
 
 func (ctx *DiskUsageContext) startSubsection(format string) (*template.Template, error) {
@@ -81,6 +84,9 @@ reclaimable: {{.Reclaimable}}
 }
 
 func (ctx *DiskUsageContext) Write() (err error) {
+	if ctx.ShowLayers {
+		return ctx.layersWrite()
+	}
 	if ctx.Verbose {
 		return ctx.verboseWrite()
 	}
@@ -92,35 +98,47 @@ func (ctx *DiskUsageContext) Write() (err error) {
 		return err
 	}
 
-	err = ctx.contextFormat(tmpl, &diskUsageImagesContext{
-		totalSize: ctx.LayersSize,
-		images:    ctx.Images,
-	})
-	if err != nil {
-		return err
-	}
-	err = ctx.contextFormat(tmpl, &diskUsageContainersContext{
-		containers: ctx.Containers,
-	})
-	if err != nil {
-		return err
-	}
-
-	err = ctx.contextFormat(tmpl, &diskUsageVolumesContext{
-		volumes: ctx.Volumes,
-	})
-	if err != nil {
-		return err
+	summaries := diskUsageSummaries(ctx.LayersSize, ctx.Images, ctx.Containers, ctx.Volumes, ctx.BuilderSize, ctx.BuildCache)
+	for _, summary := range summaries {
+		if err := ctx.contextFormat(tmpl, summary); err != nil {
+			return err
+		}
 	}
 
-	err = ctx.contextFormat(tmpl, &diskUsageBuilderContext{
-		builderSize: ctx.BuilderSize,
-		buildCache:  ctx.BuildCache,
-	})
-	if err != nil {
-		return err
-	}
+	ctx.postFormat(tmpl, newDiskUsageSummaryHeaderContext())
+	return nil
+}
 
+// diskUsageSummaryContext is the context of a row of the disk usage summary
+type diskUsageSummaryContext interface {
+	subContext
+	Type() string
+	TotalCount() string
+	Active() string
+	Size() string
+	Reclaimable() string
+}
+
+func diskUsageSummaries(layersSize int64, images []*types.ImageSummary, containers []*types.Container, volumes []*types.Volume, builderSize int64, buildCache []*types.BuildCache) []diskUsageSummaryContext {
+	return []diskUsageSummaryContext{
+		&diskUsageImagesContext{
+			totalSize: layersSize,
+			images:    images,
+		},
+		&diskUsageContainersContext{
+			containers: containers,
+		},
+		&diskUsageVolumesContext{
+			volumes: volumes,
+		},
+		&diskUsageBuilderContext{
+			builderSize: builderSize,
+			buildCache:  buildCache,
+		},
+	}
+}
+
+func newDiskUsageSummaryHeaderContext() *diskUsageContainersContext {
 	diskUsageContainersCtx := diskUsageContainersContext{containers: []*types.Container{}}
 	diskUsageContainersCtx.header = map[string]string{
 		"Type":        typeHeader,
@@ -129,9 +147,7 @@ func (ctx *DiskUsageContext) Write() (err error) {
 		"Size":        sizeHeader,
 		"Reclaimable": reclaimableHeader,
 	}
-	ctx.postFormat(tmpl, &diskUsageContainersCtx)
-
-	return err
+	return &diskUsageContainersCtx
 }
 
 type diskUsageContext struct {
@@ -0,0 +1,237 @@
+package formatter
+
+import (
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stringid"
+	units "github.com/docker/go-units"
+)
+
+const (
+	defaultDiskUsageLayerTableFormat            = "table {{.ChainID}}\t{{.Size}}\t{{.Images}}\t{{.Containers}}"
+	defaultDiskUsageImageReclaimableTableFormat = "table {{.Repository}}\t{{.Tag}}\t{{.ID}}\t{{.Size}}\t{{.Reclaimable}}"
+
+	chainIDHeader = "CHAIN ID"
+	imagesHeader  = "IMAGES"
+)
+
+// NewDiskUsageLayersFormat returns a format for rendering the layers of a
+// DiskUsageContext
+func NewDiskUsageLayersFormat(source string) Format {
+	if source == RawFormatKey {
+		return `{{range .Layers}}type: Layer
+chain_id: {{.ChainID}}
+parent: {{.Parent}}
+size: {{.Size}}
+images: {{.Images}}
+containers: {{.Containers}}
+
+{{end -}}
+{{range .Images}}type: Image
+image_id: {{.ID}}
+repository: {{.Repository}}
+tag: {{.Tag}}
+size: {{.Size}}
+reclaimable: {{.Reclaimable}}
+
+{{end -}}`
+	}
+	return Format(source)
+}
+
+type diskUsageLayersContext struct {
+	Layers []*layerUsageContext
+	Images []*imageReclaimableContext
+}
+
+func (ctx *DiskUsageContext) layersWrite() error {
+	trunc := ctx.Format.IsTable()
+	names := make(map[string]string, len(ctx.ImagesReclaimable))
+	dulc := &diskUsageLayersContext{
+		Layers: make([]*layerUsageContext, 0, len(ctx.Layers)),
+		Images: make([]*imageReclaimableContext, 0, len(ctx.ImagesReclaimable)),
+	}
+
+	for _, i := range ctx.ImagesReclaimable {
+		repo := "<none>"
+		tag := "<none>"
+		names[i.ID] = stringid.TruncateID(i.ID)
+		if len(i.RepoTags) > 0 {
+			// Only show the first tag
+			names[i.ID] = i.RepoTags[0]
+			ref, err := reference.ParseNormalizedNamed(i.RepoTags[0])
+			if err == nil {
+				if nt, ok := ref.(reference.NamedTagged); ok {
+					repo = reference.FamiliarName(ref)
+					tag = nt.Tag()
+				}
+			}
+		}
+		dulc.Images = append(dulc.Images, &imageReclaimableContext{
+			repo:  repo,
+			tag:   tag,
+			trunc: trunc,
+			i:     i,
+		})
+	}
+
+	for _, l := range ctx.Layers {
+		dulc.Layers = append(dulc.Layers, &layerUsageContext{
+			names: names,
+			trunc: trunc,
+			l:     l,
+		})
+	}
+
+	if ctx.Format == TableFormatKey {
+		return ctx.layersWriteTable(dulc)
+	}
+
+	ctx.preFormat()
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(ctx.Output, dulc)
+}
+
+func (ctx *DiskUsageContext) layersWriteTable(dulc *diskUsageLayersContext) error {
+	tmpl, err := ctx.startSubsection(defaultDiskUsageLayerTableFormat)
+	if err != nil {
+		return err
+	}
+	ctx.Output.Write([]byte("Layers space usage:\n\n"))
+	for _, l := range dulc.Layers {
+		if err := ctx.contextFormat(tmpl, l); err != nil {
+			return err
+		}
+	}
+	ctx.postFormat(tmpl, newLayerUsageContext())
+
+	tmpl, err = ctx.startSubsection(defaultDiskUsageImageReclaimableTableFormat)
+	if err != nil {
+		return err
+	}
+	ctx.Output.Write([]byte("\nImages reclaimable space:\n\n"))
+	for _, i := range dulc.Images {
+		if err := ctx.contextFormat(tmpl, i); err != nil {
+			return err
+		}
+	}
+	ctx.postFormat(tmpl, newImageReclaimableContext())
+
+	return nil
+}
+
+type layerUsageContext struct {
+	HeaderContext
+	names map[string]string
+	trunc bool
+	l     *types.LayerUsage
+}
+
+func newLayerUsageContext() *layerUsageContext {
+	layerCtx := layerUsageContext{}
+	layerCtx.header = map[string]string{
+		"ChainID":    chainIDHeader,
+		"Parent":     parentHeader,
+		"Size":       sizeHeader,
+		"Images":     imagesHeader,
+		"Containers": containersHeader,
+	}
+	return &layerCtx
+}
+
+func (c *layerUsageContext) MarshalJSON() ([]byte, error) {
+	return marshalJSON(c)
+}
+
+func (c *layerUsageContext) ChainID() string {
+	if c.trunc {
+		return stringid.TruncateID(c.l.ChainID)
+	}
+	return c.l.ChainID
+}
+
+func (c *layerUsageContext) Parent() string {
+	if c.trunc {
+		return stringid.TruncateID(c.l.Parent)
+	}
+	return c.l.Parent
+}
+
+func (c *layerUsageContext) Size() string {
+	return units.HumanSizeWithPrecision(float64(c.l.Size), 3)
+}
+
+func (c *layerUsageContext) Images() string {
+	images := make([]string, 0, len(c.l.Images))
+	for _, id := range c.l.Images {
+		name, ok := c.names[id]
+		if !ok {
+			name = stringid.TruncateID(id)
+		}
+		images = append(images, name)
+	}
+	return strings.Join(images, ", ")
+}
+
+func (c *layerUsageContext) Containers() string {
+	containers := make([]string, 0, len(c.l.Containers))
+	for _, id := range c.l.Containers {
+		if c.trunc {
+			id = stringid.TruncateID(id)
+		}
+		containers = append(containers, id)
+	}
+	return strings.Join(containers, ", ")
+}
+
+type imageReclaimableContext struct {
+	HeaderContext
+	repo  string
+	tag   string
+	trunc bool
+	i     *types.ImageReclaimableSpace
+}
+
+func newImageReclaimableContext() *imageReclaimableContext {
+	imageCtx := imageReclaimableContext{}
+	imageCtx.header = map[string]string{
+		"ID":          imageIDHeader,
+		"Repository":  repositoryHeader,
+		"Tag":         tagHeader,
+		"Size":        sizeHeader,
+		"Reclaimable": reclaimableHeader,
+	}
+	return &imageCtx
+}
+
+func (c *imageReclaimableContext) MarshalJSON() ([]byte, error) {
+	return marshalJSON(c)
+}
+
+func (c *imageReclaimableContext) ID() string {
+	if c.trunc {
+		return stringid.TruncateID(c.i.ID)
+	}
+	return c.i.ID
+}
+
+func (c *imageReclaimableContext) Repository() string {
+	return c.repo
+}
+
+func (c *imageReclaimableContext) Tag() string {
+	return c.tag
+}
+
+func (c *imageReclaimableContext) Size() string {
+	return units.HumanSizeWithPrecision(float64(c.i.Size), 3)
+}
+
+func (c *imageReclaimableContext) Reclaimable() string {
+	return units.HumanSizeWithPrecision(float64(c.i.Reclaimable), 3)
+}
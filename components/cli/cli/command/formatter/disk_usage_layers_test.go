@@ -0,0 +1,115 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestDiskUsageLayersContextFormatWrite(t *testing.T) {
+	layers := []*types.LayerUsage{
+		{
+			ChainID:    "sha256:4fe2ade4980c2dda4fc95858ebb981489baec8c1e4bd282ab1c3560be8ff9bde",
+			Size:       5000000,
+			Images:     []string{"sha256:1111111111111111111111111111111111111111111111111111111111111111", "sha256:2222222222222222222222222222222222222222222222222222222222222222"},
+			Containers: []string{"3333333333333333333333333333333333333333333333333333333333333333"},
+		},
+		{
+			ChainID:    "sha256:a8dbe8e36fe6b9d6f3ef5c1a2e3b2ddc1c2e5b2b13d1ef9a6b91f4cfa3e1e8d3",
+			Parent:     "sha256:4fe2ade4980c2dda4fc95858ebb981489baec8c1e4bd282ab1c3560be8ff9bde",
+			Size:       2000,
+			Images:     []string{"sha256:2222222222222222222222222222222222222222222222222222222222222222"},
+			Containers: []string{},
+		},
+	}
+	images := []*types.ImageReclaimableSpace{
+		{
+			ID:       "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+			RepoTags: []string{"alpine:latest"},
+			Size:     5000000,
+		},
+		{
+			ID:          "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+			RepoTags:    []string{},
+			Size:        5002000,
+			Reclaimable: 2000,
+		},
+	}
+
+	cases := []struct {
+		format   string
+		expected string
+	}{
+		{
+			"table",
+			`Layers space usage:
+
+CHAIN ID            SIZE                IMAGES                        CONTAINERS
+4fe2ade4980c        5MB                 alpine:latest, 222222222222   333333333333
+a8dbe8e36fe6        2kB                 222222222222                  
+
+Images reclaimable space:
+
+REPOSITORY          TAG                 IMAGE ID            SIZE                RECLAIMABLE
+alpine              latest              111111111111        5MB                 0B
+<none>              <none>              222222222222        5MB                 2kB
+`,
+		},
+		{
+			"{{range .Layers}}{{.ChainID}} {{.Parent}}\n{{end}}",
+			`sha256:4fe2ade4980c2dda4fc95858ebb981489baec8c1e4bd282ab1c3560be8ff9bde 
+sha256:a8dbe8e36fe6b9d6f3ef5c1a2e3b2ddc1c2e5b2b13d1ef9a6b91f4cfa3e1e8d3 sha256:4fe2ade4980c2dda4fc95858ebb981489baec8c1e4bd282ab1c3560be8ff9bde
+`,
+		},
+		{
+			"raw",
+			`type: Layer
+chain_id: sha256:4fe2ade4980c2dda4fc95858ebb981489baec8c1e4bd282ab1c3560be8ff9bde
+parent: 
+size: 5MB
+images: alpine:latest, 222222222222
+containers: 3333333333333333333333333333333333333333333333333333333333333333
+
+type: Layer
+chain_id: sha256:a8dbe8e36fe6b9d6f3ef5c1a2e3b2ddc1c2e5b2b13d1ef9a6b91f4cfa3e1e8d3
+parent: sha256:4fe2ade4980c2dda4fc95858ebb981489baec8c1e4bd282ab1c3560be8ff9bde
+size: 2kB
+images: 222222222222
+containers: 
+
+type: Image
+image_id: sha256:1111111111111111111111111111111111111111111111111111111111111111
+repository: alpine
+tag: latest
+size: 5MB
+reclaimable: 0B
+
+type: Image
+image_id: sha256:2222222222222222222222222222222222222222222222222222222222222222
+repository: <none>
+tag: <none>
+size: 5MB
+reclaimable: 2kB
+
+`,
+		},
+	}
+
+	for _, testcase := range cases {
+		out := bytes.NewBufferString("")
+		ctx := DiskUsageContext{
+			Context: Context{
+				Format: NewDiskUsageLayersFormat(testcase.format),
+				Output: out,
+			},
+			ShowLayers:        true,
+			Layers:            layers,
+			ImagesReclaimable: images,
+		}
+		assert.NilError(t, ctx.Write())
+		assert.Check(t, is.Equal(testcase.expected, out.String()))
+	}
+}
@@ -0,0 +1,152 @@
+package formatter
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+)
+
+const hostHeader = "HOST"
+
+// HostFormat returns the format for rendering the results of a command run
+// against several daemons: the host of the daemons is added as the first
+// column of table formats and as the first field of the raw format. Other
+// formats can use the {{.Host}} placeholder.
+func HostFormat(source string, format Format) Format {
+	switch {
+	case source == RawFormatKey:
+		return "host: {{.Host}}\n" + format
+	case format.IsTable() && !format.Contains("{{.Host}}"):
+		columns := strings.TrimLeft(string(format[len(TableFormatKey):]), " ")
+		return Format(TableFormatKey + " {{.Host}}\t" + columns)
+	}
+	return format
+}
+
+// HostContainers are the containers listed on one of several daemons
+type HostContainers struct {
+	Host       string
+	Containers []types.Container
+}
+
+// HostContainersWrite renders the context for the containers of several daemons
+func HostContainersWrite(ctx Context, hostContainers []HostContainers) error {
+	render := func(format func(subContext subContext) error) error {
+		for _, hc := range hostContainers {
+			for _, container := range hc.Containers {
+				err := format(&hostContainerContext{
+					containerContext: &containerContext{trunc: ctx.Trunc, c: container},
+					host:             hc.Host,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	containerCtx := newContainerContext()
+	containerCtx.header.(containerHeaderContext)["Host"] = hostHeader
+	return ctx.Write(containerCtx, render)
+}
+
+type hostContainerContext struct {
+	*containerContext
+	host string
+}
+
+func (c *hostContainerContext) MarshalJSON() ([]byte, error) {
+	return marshalJSON(c)
+}
+
+func (c *hostContainerContext) Host() string {
+	return c.host
+}
+
+// HostImages are the images listed on one of several daemons
+type HostImages struct {
+	Host   string
+	Images []types.ImageSummary
+}
+
+// HostImagesWrite renders the context for the images of several daemons
+func HostImagesWrite(ctx ImageContext, hostImages []HostImages) error {
+	render := func(format func(subContext subContext) error) error {
+		for _, hi := range hostImages {
+			err := imageFormat(ctx, hi.Images, func(sub subContext) error {
+				return format(&hostImageContext{imageContext: sub.(*imageContext), host: hi.Host})
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	imageCtx := newImageContext()
+	imageCtx.header.(map[string]string)["Host"] = hostHeader
+	return ctx.Write(imageCtx, render)
+}
+
+type hostImageContext struct {
+	*imageContext
+	host string
+}
+
+func (c *hostImageContext) MarshalJSON() ([]byte, error) {
+	return marshalJSON(c)
+}
+
+func (c *hostImageContext) Host() string {
+	return c.host
+}
+
+// HostDiskUsage is the disk usage of one of several daemons
+type HostDiskUsage struct {
+	Host        string
+	LayersSize  int64
+	Images      []*types.ImageSummary
+	Containers  []*types.Container
+	Volumes     []*types.Volume
+	BuildCache  []*types.BuildCache
+	BuilderSize int64
+}
+
+// HostDiskUsageWrite renders the context for the disk usage summaries of
+// several daemons
+func HostDiskUsageWrite(ctx Context, usages []HostDiskUsage) error {
+	ctx.buffer = bytes.NewBufferString("")
+	ctx.preFormat()
+
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return err
+	}
+
+	for _, u := range usages {
+		summaries := diskUsageSummaries(u.LayersSize, u.Images, u.Containers, u.Volumes, u.BuilderSize, u.BuildCache)
+		for _, summary := range summaries {
+			if err := ctx.contextFormat(tmpl, &hostDiskUsageContext{diskUsageSummaryContext: summary, host: u.Host}); err != nil {
+				return err
+			}
+		}
+	}
+
+	headerCtx := newDiskUsageSummaryHeaderContext()
+	headerCtx.header.(map[string]string)["Host"] = hostHeader
+	ctx.postFormat(tmpl, headerCtx)
+	return nil
+}
+
+type hostDiskUsageContext struct {
+	diskUsageSummaryContext
+	host string
+}
+
+func (c *hostDiskUsageContext) MarshalJSON() ([]byte, error) {
+	return marshalJSON(c)
+}
+
+func (c *hostDiskUsageContext) Host() string {
+	return c.host
+}
@@ -0,0 +1,129 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestHostFormat(t *testing.T) {
+	testCases := []struct {
+		source   string
+		format   Format
+		expected Format
+	}{
+		{
+			source:   TableFormatKey,
+			format:   "table {{.ID}}\t{{.Names}}",
+			expected: "table {{.Host}}\t{{.ID}}\t{{.Names}}",
+		},
+		{
+			source:   "table {{.Names}}",
+			format:   "table {{.Names}}",
+			expected: "table {{.Host}}\t{{.Names}}",
+		},
+		{
+			source:   "table {{.Names}}\t{{.Host}}",
+			format:   "table {{.Names}}\t{{.Host}}",
+			expected: "table {{.Names}}\t{{.Host}}",
+		},
+		{
+			source:   RawFormatKey,
+			format:   "container_id: {{.ID}}\n",
+			expected: "host: {{.Host}}\ncontainer_id: {{.ID}}\n",
+		},
+		{
+			source:   "{{.ID}}",
+			format:   "{{.ID}}",
+			expected: "{{.ID}}",
+		},
+	}
+	for _, tc := range testCases {
+		assert.Check(t, is.Equal(tc.expected, HostFormat(tc.source, tc.format)))
+	}
+}
+
+func TestHostContainersWrite(t *testing.T) {
+	hostContainers := []HostContainers{
+		{
+			Host: "host1",
+			Containers: []types.Container{
+				{ID: "containerID1", Names: []string{"/foobar_baz"}, Image: "ubuntu"},
+				{ID: "containerID2", Names: []string{"/foobar_bar"}, Image: "ubuntu"},
+			},
+		},
+		{Host: "host2"},
+		{
+			Host: "a-longer-host3",
+			Containers: []types.Container{
+				{ID: "containerID3", Names: []string{"/foobar_qux"}, Image: "busybox"},
+			},
+		},
+	}
+	out := bytes.NewBufferString("")
+	ctx := Context{
+		Format: HostFormat(TableFormatKey, "table {{.ID}}\t{{.Image}}\t{{.Names}}"),
+		Output: out,
+	}
+	assert.NilError(t, HostContainersWrite(ctx, hostContainers))
+	expected := `HOST                CONTAINER ID        IMAGE               NAMES
+host1               containerID1        ubuntu              foobar_baz
+host1               containerID2        ubuntu              foobar_bar
+a-longer-host3      containerID3        busybox             foobar_qux
+`
+	assert.Check(t, is.Equal(expected, out.String()))
+}
+
+func TestHostContainersWriteJSON(t *testing.T) {
+	hostContainers := []HostContainers{
+		{Host: "host1", Containers: []types.Container{{ID: "containerID1", Names: []string{"/foobar_baz"}, Image: "ubuntu"}}},
+	}
+	out := bytes.NewBufferString("")
+	assert.NilError(t, HostContainersWrite(Context{Format: "{{json .}}", Output: out}, hostContainers))
+
+	var m map[string]interface{}
+	assert.NilError(t, json.Unmarshal(out.Bytes(), &m))
+	assert.Check(t, is.Equal("host1", m["Host"]))
+	assert.Check(t, is.Equal("containerID1", m["ID"]))
+	assert.Check(t, is.Equal("foobar_baz", m["Names"]))
+}
+
+func TestHostImagesWrite(t *testing.T) {
+	hostImages := []HostImages{
+		{Host: "host1", Images: []types.ImageSummary{{ID: "imageID1", RepoTags: []string{"image:tag1"}}}},
+		{Host: "host2", Images: []types.ImageSummary{{ID: "imageID2", RepoTags: []string{"image:tag2"}}}},
+	}
+	out := bytes.NewBufferString("")
+	ctx := ImageContext{
+		Context: Context{
+			Format: HostFormat(TableFormatKey, "table {{.Repository}}\t{{.Tag}}\t{{.ID}}"),
+			Output: out,
+		},
+	}
+	assert.NilError(t, HostImagesWrite(ctx, hostImages))
+	expected := `HOST                REPOSITORY          TAG                 IMAGE ID
+host1               image               tag1                imageID1
+host2               image               tag2                imageID2
+`
+	assert.Check(t, is.Equal(expected, out.String()))
+}
+
+func TestHostDiskUsageWrite(t *testing.T) {
+	usages := []HostDiskUsage{{Host: "host1"}, {Host: "host2"}}
+	out := bytes.NewBufferString("")
+	ctx := Context{
+		Format: HostFormat(TableFormatKey, NewDiskUsageFormat(TableFormatKey, false)),
+		Output: out,
+	}
+	assert.NilError(t, HostDiskUsageWrite(ctx, usages))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Assert(t, is.Len(lines, 9))
+	assert.Check(t, is.Equal("HOST                TYPE                TOTAL               ACTIVE              SIZE                RECLAIMABLE", lines[0]))
+	assert.Check(t, strings.HasPrefix(lines[1], "host1               Images "))
+	assert.Check(t, strings.HasPrefix(lines[8], "host2               Build Cache "))
+}
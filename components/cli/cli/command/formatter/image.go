@@ -18,6 +18,7 @@ const (
 	repositoryHeader = "REPOSITORY"
 	tagHeader        = "TAG"
 	digestHeader     = "DIGEST"
+	lastUsedAtHeader = "LAST USED AT"
 )
 
 // ImageContext contains image specific information required by the formatter, encapsulate a Context struct.
@@ -201,6 +202,8 @@ func newImageContext() *imageContext {
 		"Digest":       digestHeader,
 		"CreatedSince": createdSinceHeader,
 		"CreatedAt":    createdAtHeader,
+		"LastUsed":     lastUsedSinceHeader,
+		"LastUsedAt":   lastUsedAtHeader,
 		"Size":         sizeHeader,
 		"Containers":   containersHeader,
 		"VirtualSize":  sizeHeader,
@@ -242,6 +245,21 @@ func (c *imageContext) CreatedAt() string {
 	return time.Unix(c.i.Created, 0).String()
 }
 
+func (c *imageContext) LastUsed() string {
+	if c.i.LastUsed == 0 {
+		return "N/A"
+	}
+	lastUsed := time.Unix(c.i.LastUsed, 0)
+	return units.HumanDuration(time.Now().UTC().Sub(lastUsed)) + " ago"
+}
+
+func (c *imageContext) LastUsedAt() string {
+	if c.i.LastUsed == 0 {
+		return "N/A"
+	}
+	return time.Unix(c.i.LastUsed, 0).String()
+}
+
 func (c *imageContext) Size() string {
 	return units.HumanSizeWithPrecision(float64(c.i.Size), 3)
 }
@@ -39,6 +39,18 @@ func TestImageContext(t *testing.T) {
 			i:     types.ImageSummary{Created: unix},
 			trunc: true,
 		}, time.Unix(unix, 0).String(), ctx.CreatedAt},
+		{imageContext{
+			i:     types.ImageSummary{LastUsed: unix},
+			trunc: true,
+		}, time.Unix(unix, 0).String(), ctx.LastUsedAt},
+		{imageContext{
+			i:     types.ImageSummary{},
+			trunc: true,
+		}, "N/A", ctx.LastUsed},
+		{imageContext{
+			i:     types.ImageSummary{},
+			trunc: true,
+		}, "N/A", ctx.LastUsedAt},
 		// FIXME
 		// {imageContext{
 		// 	i:     types.ImageSummary{Created: unix},
@@ -0,0 +1,184 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/pkg/stringid"
+)
+
+const (
+	// StatsHistoryJSONFormatKey is the key of the format exporting the stats
+	// history as JSON, one object per sample
+	StatsHistoryJSONFormatKey = "json"
+	// StatsHistoryCSVFormatKey is the key of the format exporting the stats
+	// history as CSV, one row per sample
+	StatsHistoryCSVFormatKey = "csv"
+
+	defaultStatsHistoryTableFormat    = "table {{.Time}}\t{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}\t{{.NetIO}}\t{{.BlockIO}}\t{{.PIDs}}"
+	winDefaultStatsHistoryTableFormat = "table {{.Time}}\t{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.NetIO}}\t{{.BlockIO}}"
+
+	timeHeader = "TIME"
+)
+
+// StatsHistoryEntry is a sample of the stats history of a container
+type StatsHistoryEntry struct {
+	Time time.Time
+	StatsEntry
+}
+
+// NewStatsHistoryFormat returns a format for rendering the stats history
+func NewStatsHistoryFormat(source, osType string) Format {
+	if source == TableFormatKey {
+		if osType == winOSType {
+			return Format(winDefaultStatsHistoryTableFormat)
+		}
+		return Format(defaultStatsHistoryTableFormat)
+	}
+	return Format(source)
+}
+
+// StatsHistoryWrite renders the context for the samples of the stats history
+// of containers. The json and csv formats export the raw values of the
+// samples, the other formats render them like the live stats.
+func StatsHistoryWrite(ctx Context, entries []StatsHistoryEntry, osType string, trunc bool) error {
+	switch ctx.Format {
+	case StatsHistoryJSONFormatKey:
+		enc := json.NewEncoder(ctx.Output)
+		for _, entry := range entries {
+			if err := enc.Encode(newStatsRecord(entry, trunc)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case StatsHistoryCSVFormatKey:
+		return writeStatsHistoryCSV(ctx.Output, entries, trunc)
+	}
+
+	render := func(format func(subContext subContext) error) error {
+		for _, entry := range entries {
+			err := format(&statsHistoryContext{
+				containerStatsContext: &containerStatsContext{s: entry.StatsEntry, os: osType, trunc: trunc},
+				time:                  entry.Time,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	memUsage := memUseHeader
+	if osType == winOSType {
+		memUsage = winMemUseHeader
+	}
+	statsHistoryCtx := statsHistoryContext{containerStatsContext: &containerStatsContext{os: osType}}
+	statsHistoryCtx.header = map[string]string{
+		"Time":      timeHeader,
+		"Container": containerHeader,
+		"Name":      nameHeader,
+		"ID":        containerIDHeader,
+		"CPUPerc":   cpuPercHeader,
+		"MemUsage":  memUsage,
+		"MemPerc":   memPercHeader,
+		"NetIO":     netIOHeader,
+		"BlockIO":   blockIOHeader,
+		"PIDs":      pidsHeader,
+	}
+	return ctx.Write(&statsHistoryCtx, render)
+}
+
+type statsHistoryContext struct {
+	*containerStatsContext
+	time time.Time
+}
+
+func (c *statsHistoryContext) MarshalJSON() ([]byte, error) {
+	return marshalJSON(c)
+}
+
+func (c *statsHistoryContext) Time() string {
+	return c.time.Local().Format(time.RFC3339)
+}
+
+// statsRecord is a sample of the stats history, as exported
+type statsRecord struct {
+	Time             time.Time
+	Container        string
+	Name             string
+	ID               string
+	CPUPercentage    float64
+	Memory           float64
+	MemoryLimit      float64
+	MemoryPercentage float64
+	NetworkRx        float64
+	NetworkTx        float64
+	BlockRead        float64
+	BlockWrite       float64
+	PidsCurrent      uint64
+}
+
+func newStatsRecord(entry StatsHistoryEntry, trunc bool) statsRecord {
+	record := statsRecord{
+		Time:             entry.Time,
+		Container:        entry.Container,
+		Name:             entry.Name,
+		ID:               entry.ID,
+		CPUPercentage:    entry.CPUPercentage,
+		Memory:           entry.Memory,
+		MemoryLimit:      entry.MemoryLimit,
+		MemoryPercentage: entry.MemoryPercentage,
+		NetworkRx:        entry.NetworkRx,
+		NetworkTx:        entry.NetworkTx,
+		BlockRead:        entry.BlockRead,
+		BlockWrite:       entry.BlockWrite,
+		PidsCurrent:      entry.PidsCurrent,
+	}
+	if len(record.Name) > 0 && record.Name[0] == '/' {
+		record.Name = record.Name[1:]
+	}
+	if trunc {
+		record.ID = stringid.TruncateID(record.ID)
+	}
+	return record
+}
+
+var statsHistoryCSVHeader = []string{
+	"time", "container", "name", "id", "cpu_percent", "memory_usage", "memory_limit",
+	"memory_percent", "net_rx", "net_tx", "block_read", "block_write", "pids",
+}
+
+func writeStatsHistoryCSV(out io.Writer, entries []StatsHistoryEntry, trunc bool) error {
+	w := csv.NewWriter(out)
+	if err := w.Write(statsHistoryCSVHeader); err != nil {
+		return err
+	}
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	for _, entry := range entries {
+		r := newStatsRecord(entry, trunc)
+		err := w.Write([]string{
+			r.Time.Format(time.RFC3339Nano),
+			r.Container,
+			r.Name,
+			r.ID,
+			strconv.FormatFloat(r.CPUPercentage, 'f', 2, 64),
+			formatFloat(r.Memory),
+			formatFloat(r.MemoryLimit),
+			strconv.FormatFloat(r.MemoryPercentage, 'f', 2, 64),
+			formatFloat(r.NetworkRx),
+			formatFloat(r.NetworkTx),
+			formatFloat(r.BlockRead),
+			formatFloat(r.BlockWrite),
+			strconv.FormatUint(r.PidsCurrent, 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
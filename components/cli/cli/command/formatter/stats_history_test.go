@@ -0,0 +1,98 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func testStatsHistoryEntries() []StatsHistoryEntry {
+	read := time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)
+	return []StatsHistoryEntry{
+		{
+			Time: read,
+			StatsEntry: StatsEntry{
+				Container:        "web",
+				Name:             "/web",
+				ID:               "aaaaaaaaaaaabbbbbbbbbbbb",
+				CPUPercentage:    12.345,
+				Memory:           2048,
+				MemoryLimit:      4096,
+				MemoryPercentage: 50,
+				NetworkRx:        10,
+				NetworkTx:        20,
+				BlockRead:        30,
+				BlockWrite:       40,
+				PidsCurrent:      3,
+			},
+		},
+		{
+			Time:       read.Add(10 * time.Second),
+			StatsEntry: StatsEntry{Container: "web", Name: "/web", ID: "aaaaaaaaaaaabbbbbbbbbbbb", CPUPercentage: 1.5},
+		},
+	}
+}
+
+func TestStatsHistoryWriteTable(t *testing.T) {
+	out := bytes.NewBufferString("")
+	ctx := Context{
+		Format: NewStatsHistoryFormat("table {{.Name}}\t{{.ID}}\t{{.CPUPerc}}", "linux"),
+		Output: out,
+	}
+	assert.NilError(t, StatsHistoryWrite(ctx, testStatsHistoryEntries(), "linux", true))
+	expected := `NAME                CONTAINER ID        CPU %
+web                 aaaaaaaaaaaa        12.35%
+web                 aaaaaaaaaaaa        1.50%
+`
+	assert.Check(t, is.Equal(expected, out.String()))
+}
+
+func TestStatsHistoryWriteTime(t *testing.T) {
+	entries := testStatsHistoryEntries()
+	out := bytes.NewBufferString("")
+	ctx := Context{Format: NewStatsHistoryFormat("{{.Time}}", "linux"), Output: out}
+	assert.NilError(t, StatsHistoryWrite(ctx, entries, "linux", true))
+	expected := entries[0].Time.Local().Format(time.RFC3339) + "\n" + entries[1].Time.Local().Format(time.RFC3339) + "\n"
+	assert.Check(t, is.Equal(expected, out.String()))
+}
+
+func TestStatsHistoryWriteDefaultTable(t *testing.T) {
+	out := bytes.NewBufferString("")
+	ctx := Context{Format: NewStatsHistoryFormat(TableFormatKey, "windows"), Output: out}
+	assert.NilError(t, StatsHistoryWrite(ctx, testStatsHistoryEntries(), "windows", true))
+	header := strings.Fields(strings.SplitN(out.String(), "\n", 2)[0])
+	assert.Check(t, is.DeepEqual([]string{"TIME", "NAME", "CPU", "%", "PRIV", "WORKING", "SET", "NET", "I/O", "BLOCK", "I/O"}, header))
+}
+
+func TestStatsHistoryWriteJSON(t *testing.T) {
+	out := bytes.NewBufferString("")
+	ctx := Context{Format: NewStatsHistoryFormat(StatsHistoryJSONFormatKey, "linux"), Output: out}
+	assert.NilError(t, StatsHistoryWrite(ctx, testStatsHistoryEntries(), "linux", false))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Assert(t, is.Len(lines, 2))
+	var m map[string]interface{}
+	assert.NilError(t, json.Unmarshal([]byte(lines[0]), &m))
+	assert.Check(t, is.Equal("2019-01-01T12:00:00Z", m["Time"]))
+	assert.Check(t, is.Equal("web", m["Name"]))
+	assert.Check(t, is.Equal("aaaaaaaaaaaabbbbbbbbbbbb", m["ID"]))
+	assert.Check(t, is.Equal(12.345, m["CPUPercentage"]))
+	assert.Check(t, is.Equal(float64(2048), m["Memory"]))
+	assert.Check(t, is.Equal(float64(3), m["PidsCurrent"]))
+}
+
+func TestStatsHistoryWriteCSV(t *testing.T) {
+	out := bytes.NewBufferString("")
+	ctx := Context{Format: NewStatsHistoryFormat(StatsHistoryCSVFormatKey, "linux"), Output: out}
+	assert.NilError(t, StatsHistoryWrite(ctx, testStatsHistoryEntries(), "linux", true))
+	expected := `time,container,name,id,cpu_percent,memory_usage,memory_limit,memory_percent,net_rx,net_tx,block_read,block_write,pids
+2019-01-01T12:00:00Z,web,web,aaaaaaaaaaaa,12.35,2048,4096,50.00,10,20,30,40,3
+2019-01-01T12:00:10Z,web,web,aaaaaaaaaaaa,1.50,0,0,0.00,0,0,0,0,0
+`
+	assert.Check(t, is.Equal(expected, out.String()))
+}
@@ -3,6 +3,7 @@ package formatter
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	units "github.com/docker/go-units"
@@ -66,13 +67,14 @@ type volumeContext struct {
 func newVolumeContext() *volumeContext {
 	volumeCtx := volumeContext{}
 	volumeCtx.header = volumeHeaderContext{
-		"Name":       volumeNameHeader,
-		"Driver":     driverHeader,
-		"Scope":      scopeHeader,
-		"Mountpoint": mountpointHeader,
-		"Labels":     labelsHeader,
-		"Links":      linksHeader,
-		"Size":       sizeHeader,
+		"Name":          volumeNameHeader,
+		"Driver":        driverHeader,
+		"Scope":         scopeHeader,
+		"Mountpoint":    mountpointHeader,
+		"Labels":        labelsHeader,
+		"Links":         linksHeader,
+		"Size":          sizeHeader,
+		"LastUsedSince": lastUsedSinceHeader,
 	}
 	return &volumeCtx
 }
@@ -127,5 +129,19 @@ func (c *volumeContext) Size() string {
 	if c.v.UsageData == nil {
 		return "N/A"
 	}
+	if c.v.UsageData.Size < 0 {
+		return "N/A"
+	}
 	return units.HumanSize(float64(c.v.UsageData.Size))
 }
+
+func (c *volumeContext) LastUsedSince() string {
+	if c.v.UsageData == nil || c.v.UsageData.LastUsedAt == "" {
+		return "N/A"
+	}
+	lastUsed, err := time.Parse(time.RFC3339, c.v.UsageData.LastUsedAt)
+	if err != nil {
+		return "N/A"
+	}
+	return units.HumanDuration(time.Now().UTC().Sub(lastUsed)) + " ago"
+}
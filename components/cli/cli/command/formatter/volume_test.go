@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/pkg/stringid"
@@ -40,6 +41,21 @@ func TestVolumeContext(t *testing.T) {
 		{volumeContext{
 			v: types.Volume{Labels: map[string]string{"label1": "value1", "label2": "value2"}},
 		}, "label1=value1,label2=value2", ctx.Labels},
+		{volumeContext{
+			v: types.Volume{},
+		}, "N/A", ctx.Size},
+		{volumeContext{
+			v: types.Volume{UsageData: &types.VolumeUsageData{Size: -1}},
+		}, "N/A", ctx.Size},
+		{volumeContext{
+			v: types.Volume{UsageData: &types.VolumeUsageData{Size: 2000}},
+		}, "2kB", ctx.Size},
+		{volumeContext{
+			v: types.Volume{UsageData: &types.VolumeUsageData{}},
+		}, "N/A", ctx.LastUsedSince},
+		{volumeContext{
+			v: types.Volume{UsageData: &types.VolumeUsageData{LastUsedAt: time.Now().Add(-2 * time.Hour).Format(time.RFC3339)}},
+		}, "2 hours ago", ctx.LastUsedSince},
 	}
 
 	for _, c := range cases {
@@ -146,8 +162,8 @@ func TestVolumeContextWriteJSON(t *testing.T) {
 		{Driver: "bar", Name: "foobar_bar"},
 	}
 	expectedJSONs := []map[string]interface{}{
-		{"Driver": "foo", "Labels": "", "Links": "N/A", "Mountpoint": "", "Name": "foobar_baz", "Scope": "", "Size": "N/A"},
-		{"Driver": "bar", "Labels": "", "Links": "N/A", "Mountpoint": "", "Name": "foobar_bar", "Scope": "", "Size": "N/A"},
+		{"Driver": "foo", "Labels": "", "Links": "N/A", "Mountpoint": "", "Name": "foobar_baz", "Scope": "", "Size": "N/A", "LastUsedSince": "N/A"},
+		{"Driver": "bar", "Labels": "", "Links": "N/A", "Mountpoint": "", "Name": "foobar_bar", "Scope": "", "Size": "N/A", "LastUsedSince": "N/A"},
 	}
 	out := bytes.NewBufferString("")
 	err := VolumeWrite(Context{Format: "{{json .}}", Output: out}, volumes)
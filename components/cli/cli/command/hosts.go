@@ -0,0 +1,156 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/docker/cli/cli/config/configfile"
+	contextstore "github.com/docker/cli/cli/context/store"
+	cliflags "github.com/docker/cli/cli/flags"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+)
+
+// HostClient is the API client of one of the daemons selected with the
+// --hosts flag.
+type HostClient struct {
+	// Host is the daemon address or the name of the context, as specified
+	// with the --hosts flag or in the host group of the configuration file.
+	Host   string
+	Client client.APIClient
+}
+
+// HostError is the error of a command on one of the daemons selected with
+// the --hosts flag.
+type HostError struct {
+	Host string
+	Err  error
+}
+
+func (e HostError) Error() string {
+	return e.Host + ": " + e.Err.Error()
+}
+
+// HostErrors are the errors of a command on the daemons selected with the
+// --hosts flag, one per daemon on which the command failed.
+type HostErrors []HostError
+
+func (e HostErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// RunOnHosts runs fn concurrently against each of the daemons of
+// hostClients, passing it the index of the daemon in hostClients. It waits
+// for all of them to complete, and returns a HostErrors listing the daemons
+// on which fn failed, or nil if it succeeded on all of them.
+func RunOnHosts(ctx context.Context, hostClients []HostClient, fn func(ctx context.Context, i int, apiClient client.APIClient) error) error {
+	errs := make([]error, len(hostClients))
+	var wg sync.WaitGroup
+	for i, hostClient := range hostClients {
+		wg.Add(1)
+		go func(i int, apiClient client.APIClient) {
+			defer wg.Done()
+			errs[i] = fn(ctx, i, apiClient)
+		}(i, hostClient.Client)
+	}
+	wg.Wait()
+
+	var hostErrs HostErrors
+	for i, err := range errs {
+		if err != nil {
+			hostErrs = append(hostErrs, HostError{Host: hostClients[i].Host, Err: err})
+		}
+	}
+	if len(hostErrs) == 0 {
+		return nil
+	}
+	return hostErrs
+}
+
+// resolveFanOutHosts returns the daemons selected with the --hosts flag, in
+// order and without duplicates, after replacing the names of the host groups
+// of the configuration file with their daemons.
+func resolveFanOutHosts(opts *cliflags.CommonOptions, configFile *configfile.ConfigFile) ([]string, error) {
+	if len(opts.FanOut) == 0 {
+		return nil, nil
+	}
+	if opts.Context != "" || len(opts.Hosts) > 0 {
+		return nil, errors.New("Conflicting options: --hosts cannot be used with --host or --context")
+	}
+
+	var (
+		hosts []string
+		seen  = map[string]bool{}
+	)
+	add := func(host string) {
+		host = strings.TrimSpace(host)
+		if host != "" && !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+	for _, host := range opts.FanOut {
+		group, ok := configFile.HostGroups[host]
+		if !ok {
+			add(host)
+			continue
+		}
+		for _, host := range group {
+			add(host)
+		}
+	}
+	if len(hosts) == 0 {
+		return nil, errors.New("no daemon specified with --hosts")
+	}
+	return hosts, nil
+}
+
+// fanOutCommonOptions returns the options connecting to host, which is
+// either the name of a context, or a daemon address using the TLS options
+// of opts.
+func fanOutCommonOptions(opts *cliflags.CommonOptions, store contextstore.Store, host string) (*cliflags.CommonOptions, error) {
+	if _, err := store.Get(host); err == nil {
+		return contextCommonOptions(opts, store, host)
+	}
+	hostOpts := *opts
+	hostOpts.Hosts = []string{host}
+	return &hostOpts, nil
+}
+
+// newHostClients returns the API clients of the daemons selected with the
+// --hosts flag, after negotiating their API version.
+func newHostClients(opts *cliflags.CommonOptions, configFile *configfile.ConfigFile, store contextstore.Store) ([]HostClient, error) {
+	hosts, err := resolveFanOutHosts(opts, configFile)
+	if err != nil || len(hosts) == 0 {
+		return nil, err
+	}
+
+	hostClients := make([]HostClient, 0, len(hosts))
+	for _, host := range hosts {
+		hostOpts, err := fanOutCommonOptions(opts, store, host)
+		if err != nil {
+			return nil, err
+		}
+		apiClient, err := NewAPIClientFromFlags(hostOpts, configFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s", host)
+		}
+		hostClients = append(hostClients, HostClient{Host: host, Client: apiClient})
+	}
+
+	var wg sync.WaitGroup
+	for _, hostClient := range hostClients {
+		wg.Add(1)
+		go func(apiClient client.APIClient) {
+			defer wg.Done()
+			apiClient.NegotiateAPIVersion(context.Background())
+		}(hostClient.Client)
+	}
+	wg.Wait()
+	return hostClients, nil
+}
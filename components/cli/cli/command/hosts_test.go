@@ -0,0 +1,107 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/cli/cli/config/configfile"
+	contextstore "github.com/docker/cli/cli/context/store"
+	cliflags "github.com/docker/cli/cli/flags"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/fs"
+)
+
+func TestResolveFanOutHosts(t *testing.T) {
+	configFile := &configfile.ConfigFile{
+		HostGroups: map[string][]string{
+			"fleet": {"tcp://h1:2375", "tcp://h2:2375"},
+		},
+	}
+
+	testCases := []struct {
+		doc      string
+		fanOut   []string
+		expected []string
+	}{
+		{
+			doc: "flag not set",
+		},
+		{
+			doc:      "list of daemons",
+			fanOut:   []string{"tcp://h1:2375", "remote"},
+			expected: []string{"tcp://h1:2375", "remote"},
+		},
+		{
+			doc:      "host group",
+			fanOut:   []string{"fleet"},
+			expected: []string{"tcp://h1:2375", "tcp://h2:2375"},
+		},
+		{
+			doc:      "duplicates are removed",
+			fanOut:   []string{"tcp://h2:2375", "fleet", "tcp://h3:2375"},
+			expected: []string{"tcp://h2:2375", "tcp://h1:2375", "tcp://h3:2375"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.doc, func(t *testing.T) {
+			hosts, err := resolveFanOutHosts(&cliflags.CommonOptions{FanOut: tc.fanOut}, configFile)
+			assert.NilError(t, err)
+			assert.Check(t, is.DeepEqual(tc.expected, hosts))
+		})
+	}
+}
+
+func TestResolveFanOutHostsConflict(t *testing.T) {
+	opts := &cliflags.CommonOptions{FanOut: []string{"tcp://h1:2375"}, Hosts: []string{"tcp://h2:2375"}}
+	_, err := resolveFanOutHosts(opts, &configfile.ConfigFile{})
+	assert.Error(t, err, "Conflicting options: --hosts cannot be used with --host or --context")
+
+	opts = &cliflags.CommonOptions{FanOut: []string{"tcp://h1:2375"}, Context: "remote"}
+	_, err = resolveFanOutHosts(opts, &configfile.ConfigFile{})
+	assert.Error(t, err, "Conflicting options: --hosts cannot be used with --host or --context")
+}
+
+func TestFanOutCommonOptions(t *testing.T) {
+	dir := fs.NewDir(t, "hosts-test")
+	defer dir.Remove()
+	store := contextstore.NewStore(dir.Path())
+	assert.NilError(t, store.Create(contextstore.Metadata{
+		Name:     "remote",
+		Endpoint: contextstore.Endpoint{Host: "tcp://remote:2375"},
+	}, nil))
+
+	opts := &cliflags.CommonOptions{FanOut: []string{"remote", "tcp://h1:2375"}}
+
+	hostOpts, err := fanOutCommonOptions(opts, store, "remote")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"tcp://remote:2375"}, hostOpts.Hosts))
+
+	hostOpts, err = fanOutCommonOptions(opts, store, "tcp://h1:2375")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"tcp://h1:2375"}, hostOpts.Hosts))
+}
+
+func TestRunOnHosts(t *testing.T) {
+	hostClients := []HostClient{{Host: "h1"}, {Host: "h2"}, {Host: "h3"}}
+	results := make([]string, len(hostClients))
+	err := RunOnHosts(context.Background(), hostClients, func(ctx context.Context, i int, apiClient client.APIClient) error {
+		if i == 1 {
+			return errors.New("connection refused")
+		}
+		results[i] = hostClients[i].Host
+		return nil
+	})
+	assert.Check(t, is.DeepEqual([]string{"h1", "", "h3"}, results))
+	assert.Error(t, err, "h2: connection refused")
+	hostErrs, ok := err.(HostErrors)
+	assert.Assert(t, ok)
+	assert.Check(t, is.Len(hostErrs, 1))
+
+	err = RunOnHosts(context.Background(), hostClients, func(ctx context.Context, i int, apiClient client.APIClient) error {
+		return nil
+	})
+	assert.NilError(t, err)
+}
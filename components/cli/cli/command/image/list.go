@@ -8,6 +8,7 @@ import (
 	"github.com/docker/cli/cli/command/formatter"
 	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
 	"github.com/spf13/cobra"
 )
 
@@ -36,6 +37,7 @@ func NewImagesCommand(dockerCli command.Cli) *cobra.Command {
 			}
 			return runImages(dockerCli, options)
 		},
+		Annotations: map[string]string{"hosts": ""},
 	}
 
 	flags := cmd.Flags()
@@ -70,11 +72,6 @@ func runImages(dockerCli command.Cli, options imagesOptions) error {
 		Filters: filters,
 	}
 
-	images, err := dockerCli.Client().ImageList(ctx, listOptions)
-	if err != nil {
-		return err
-	}
-
 	format := options.format
 	if len(format) == 0 {
 		if len(dockerCli.ConfigFile().ImagesFormat) > 0 && !options.quiet {
@@ -92,5 +89,27 @@ func runImages(dockerCli command.Cli, options imagesOptions) error {
 		},
 		Digest: options.showDigests,
 	}
+
+	if hostClients := dockerCli.HostClients(); len(hostClients) > 0 {
+		hostImages := make([]formatter.HostImages, len(hostClients))
+		err := command.RunOnHosts(ctx, hostClients, func(ctx context.Context, i int, apiClient client.APIClient) error {
+			images, err := apiClient.ImageList(ctx, listOptions)
+			if err != nil {
+				return err
+			}
+			hostImages[i] = formatter.HostImages{Host: hostClients[i].Host, Images: images}
+			return nil
+		})
+		imageCtx.Format = formatter.HostFormat(format, imageCtx.Format)
+		if writeErr := formatter.HostImagesWrite(imageCtx, hostImages); writeErr != nil {
+			return writeErr
+		}
+		return err
+	}
+
+	images, err := dockerCli.Client().ImageList(ctx, listOptions)
+	if err != nil {
+		return err
+	}
 	return formatter.ImageWrite(imageCtx, images)
 }
@@ -119,3 +119,18 @@ func TestNewPullCommandWithContentTrustErrors(t *testing.T) {
 		assert.ErrorContains(t, err, tc.expectedError)
 	}
 }
+
+func TestNewPullCommandLimitRate(t *testing.T) {
+	var limitRate int64
+	cli := test.NewFakeCli(&fakeClient{
+		imagePullFunc: func(ref string, options types.ImagePullOptions) (io.ReadCloser, error) {
+			limitRate = options.LimitRate
+			return ioutil.NopCloser(strings.NewReader("")), nil
+		},
+	})
+	cmd := NewPullCommand(cli)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"--limit-rate", "2m", "image:tag"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(int64(2*1024*1024), limitRate))
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/opts"
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/docker/registry"
@@ -14,6 +15,7 @@ import (
 type pushOptions struct {
 	remote    string
 	untrusted bool
+	limitRate opts.MemBytes
 }
 
 // NewPushCommand creates a new `docker push` command
@@ -32,6 +34,9 @@ func NewPushCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags := cmd.Flags()
 
+	flags.Var(&opts.limitRate, "limit-rate", "Limit the upload bandwidth of the push, in bytes per second")
+	flags.SetAnnotation("limit-rate", "version", []string{"1.40"})
+
 	command.AddTrustSigningFlags(flags, &opts.untrusted, dockerCli.ContentTrustEnabled())
 
 	return cmd
@@ -56,15 +61,14 @@ func RunPush(dockerCli command.Cli, opts pushOptions) error {
 	authConfig := command.ResolveAuthConfig(ctx, dockerCli, repoInfo.Index)
 	requestPrivilege := command.RegistryAuthenticationPrivilegedFunc(dockerCli, repoInfo.Index, "push")
 
-	if !opts.untrusted {
-		return TrustedPush(ctx, dockerCli, repoInfo, ref, authConfig, requestPrivilege)
-	}
-
-	responseBody, err := imagePushPrivileged(ctx, dockerCli, authConfig, ref, requestPrivilege)
+	responseBody, err := imagePushPrivileged(ctx, dockerCli, authConfig, ref, requestPrivilege, opts.limitRate.Value())
 	if err != nil {
 		return err
 	}
 
 	defer responseBody.Close()
+	if !opts.untrusted {
+		return PushTrustedReference(dockerCli, repoInfo, ref, authConfig, responseBody)
+	}
 	return jsonmessage.DisplayJSONMessagesToStream(responseBody, dockerCli.Out(), nil)
 }
@@ -69,3 +69,18 @@ func TestNewPushCommandSuccess(t *testing.T) {
 		assert.NilError(t, cmd.Execute())
 	}
 }
+
+func TestNewPushCommandLimitRate(t *testing.T) {
+	var limitRate int64
+	cli := test.NewFakeCli(&fakeClient{
+		imagePushFunc: func(ref string, options types.ImagePushOptions) (io.ReadCloser, error) {
+			limitRate = options.LimitRate
+			return ioutil.NopCloser(strings.NewReader("")), nil
+		},
+	})
+	cmd := NewPushCommand(cli)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"--limit-rate", "512k", "image:tag"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, limitRate == 512*1024, "expected a limit rate of 512k, got %d", limitRate)
+}
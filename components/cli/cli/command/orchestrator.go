@@ -53,6 +53,17 @@ func normalize(value string) (Orchestrator, error) {
 	}
 }
 
+// NormalizeOrchestrator returns the Orchestrator named value, or an error if
+// value is not a valid orchestrator name. An empty value is the default
+// orchestrator.
+func NormalizeOrchestrator(value string) (Orchestrator, error) {
+	o, err := normalize(value)
+	if o == orchestratorUnset {
+		return defaultOrchestrator, nil
+	}
+	return o, err
+}
+
 // GetStackOrchestrator checks DOCKER_STACK_ORCHESTRATOR environment variable and configuration file
 // orchestrator value and returns user defined Orchestrator.
 func GetStackOrchestrator(flagValue, value string, stderr io.Writer) (Orchestrator, error) {
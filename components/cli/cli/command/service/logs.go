@@ -32,6 +32,8 @@ type logsOptions struct {
 	tail       string
 	details    bool
 	raw        bool
+	grep       string
+	exclude    string
 
 	target string
 }
@@ -64,6 +66,10 @@ func newLogsCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVar(&opts.details, "details", false, "Show extra details provided to logs")
 	flags.SetAnnotation("details", "version", []string{"1.30"})
 	flags.StringVar(&opts.tail, "tail", "all", "Number of lines to show from the end of the logs")
+	flags.StringVar(&opts.grep, "grep", "", "Only show lines matching a regular expression")
+	flags.SetAnnotation("grep", "version", []string{"1.40"})
+	flags.StringVar(&opts.exclude, "exclude", "", "Do not show lines matching a regular expression")
+	flags.SetAnnotation("exclude", "version", []string{"1.40"})
 	return cmd
 }
 
@@ -77,6 +83,8 @@ func runLogs(dockerCli command.Cli, opts *logsOptions) error {
 		Timestamps: opts.timestamps,
 		Follow:     opts.follow,
 		Tail:       opts.tail,
+		Grep:       opts.grep,
+		Exclude:    opts.exclude,
 		// get the details if we request it OR if we're not doing raw mode
 		// (we need them for the context to pretty print)
 		Details: opts.details || !opts.raw,
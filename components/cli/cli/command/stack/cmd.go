@@ -3,7 +3,6 @@ package stack
 import (
 	"errors"
 	"fmt"
-	"io"
 	"strings"
 
 	"github.com/docker/cli/cli"
@@ -32,7 +31,7 @@ func NewStackCommand(dockerCli command.Cli) *cobra.Command {
 			if configFile == nil {
 				configFile = cliconfig.LoadDefaultConfigFile(dockerCli.Err())
 			}
-			orchestrator, err := getOrchestrator(configFile, cmd, dockerCli.Err())
+			orchestrator, err := getOrchestrator(dockerCli, configFile, cmd)
 			if err != nil {
 				return err
 			}
@@ -81,12 +80,16 @@ func NewTopLevelDeployCommand(dockerCli command.Cli) *cobra.Command {
 	return cmd
 }
 
-func getOrchestrator(config *configfile.ConfigFile, cmd *cobra.Command, stderr io.Writer) (command.Orchestrator, error) {
+func getOrchestrator(dockerCli command.Cli, config *configfile.ConfigFile, cmd *cobra.Command) (command.Orchestrator, error) {
 	var orchestratorFlag string
 	if o, err := cmd.Flags().GetString("orchestrator"); err == nil {
 		orchestratorFlag = o
 	}
-	return command.GetStackOrchestrator(orchestratorFlag, config.StackOrchestrator, stderr)
+	orchestrator := command.ContextStackOrchestrator(dockerCli)
+	if orchestrator == "" {
+		orchestrator = config.StackOrchestrator
+	}
+	return command.GetStackOrchestrator(orchestratorFlag, orchestrator, dockerCli.Err())
 }
 
 func hideOrchestrationFlags(cmd *cobra.Command, orchestrator command.Orchestrator) {
@@ -12,6 +12,7 @@ type fakeClient struct {
 
 	version       string
 	serverVersion func(ctx context.Context) (types.Version, error)
+	diskUsageFunc func(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error)
 }
 
 func (cli *fakeClient) ServerVersion(ctx context.Context) (types.Version, error) {
@@ -21,3 +22,10 @@ func (cli *fakeClient) ServerVersion(ctx context.Context) (types.Version, error)
 func (cli *fakeClient) ClientVersion() string {
 	return cli.version
 }
+
+func (cli *fakeClient) DiskUsageWithOptions(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error) {
+	if cli.diskUsageFunc != nil {
+		return cli.diskUsageFunc(ctx, options)
+	}
+	return types.DiskUsage{}, nil
+}
@@ -6,11 +6,15 @@ import (
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 type diskUsageOptions struct {
 	verbose bool
+	layers  bool
 	format  string
 }
 
@@ -25,19 +29,25 @@ func newDiskUsageCommand(dockerCli command.Cli) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runDiskUsage(dockerCli, opts)
 		},
-		Annotations: map[string]string{"version": "1.25"},
+		Annotations: map[string]string{"version": "1.25", "hosts": ""},
 	}
 
 	flags := cmd.Flags()
 
 	flags.BoolVarP(&opts.verbose, "verbose", "v", false, "Show detailed information on space usage")
+	flags.BoolVar(&opts.layers, "layers", false, "Show space usage of each image layer")
+	flags.SetAnnotation("layers", "version", []string{"1.40"})
 	flags.StringVar(&opts.format, "format", "", "Pretty-print images using a Go template")
 
 	return cmd
 }
 
 func runDiskUsage(dockerCli command.Cli, opts diskUsageOptions) error {
-	du, err := dockerCli.Client().DiskUsage(context.Background())
+	if hostClients := dockerCli.HostClients(); len(hostClients) > 0 {
+		return runHostsDiskUsage(dockerCli, hostClients, opts)
+	}
+
+	du, err := dockerCli.Client().DiskUsageWithOptions(context.Background(), types.DiskUsageOptions{Layers: opts.layers})
 	if err != nil {
 		return err
 	}
@@ -47,13 +57,21 @@ func runDiskUsage(dockerCli command.Cli, opts diskUsageOptions) error {
 		format = formatter.TableFormatKey
 	}
 
-	var bsz int64
-	for _, bc := range du.BuildCache {
-		if !bc.Shared {
-			bsz += bc.Size
+	if opts.layers {
+		duCtx := formatter.DiskUsageContext{
+			Context: formatter.Context{
+				Output: dockerCli.Out(),
+				Format: formatter.NewDiskUsageLayersFormat(format),
+			},
+			ShowLayers:        true,
+			Layers:            du.Layers,
+			ImagesReclaimable: du.ImagesReclaimable,
 		}
+		return duCtx.Write()
 	}
 
+	bsz := builderSize(du.BuildCache)
+
 	duCtx := formatter.DiskUsageContext{
 		Context: formatter.Context{
 			Output: dockerCli.Out(),
@@ -70,3 +88,58 @@ func runDiskUsage(dockerCli command.Cli, opts diskUsageOptions) error {
 
 	return duCtx.Write()
 }
+
+func runHostsDiskUsage(dockerCli command.Cli, hostClients []command.HostClient, opts diskUsageOptions) error {
+	if opts.verbose || opts.layers {
+		return errors.New("--verbose and --layers cannot be used with the --hosts flag")
+	}
+
+	usages := make([]formatter.HostDiskUsage, len(hostClients))
+	err := command.RunOnHosts(context.Background(), hostClients, func(ctx context.Context, i int, apiClient client.APIClient) error {
+		du, err := apiClient.DiskUsageWithOptions(ctx, types.DiskUsageOptions{})
+		if err != nil {
+			return err
+		}
+		usages[i] = formatter.HostDiskUsage{
+			Host:        hostClients[i].Host,
+			LayersSize:  du.LayersSize,
+			Images:      du.Images,
+			Containers:  du.Containers,
+			Volumes:     du.Volumes,
+			BuildCache:  du.BuildCache,
+			BuilderSize: builderSize(du.BuildCache),
+		}
+		return nil
+	})
+
+	var succeeded []formatter.HostDiskUsage
+	for _, u := range usages {
+		if u.Host != "" {
+			succeeded = append(succeeded, u)
+		}
+	}
+
+	format := opts.format
+	if len(format) == 0 {
+		format = formatter.TableFormatKey
+	}
+	duCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: formatter.HostFormat(format, formatter.NewDiskUsageFormat(format, false)),
+	}
+	if writeErr := formatter.HostDiskUsageWrite(duCtx, succeeded); writeErr != nil {
+		return writeErr
+	}
+	return err
+}
+
+// builderSize returns the size of the build cache which is not shared
+func builderSize(buildCache []*types.BuildCache) int64 {
+	var bsz int64
+	for _, bc := range buildCache {
+		if !bc.Shared {
+			bsz += bc.Size
+		}
+	}
+	return bsz
+}
@@ -0,0 +1,78 @@
+package system
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestDiskUsageLayers(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		diskUsageFunc: func(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error) {
+			assert.Check(t, options.Layers)
+			return types.DiskUsage{
+				Layers: []*types.LayerUsage{{
+					ChainID: "sha256:4fe2ade4980c2dda4fc95858ebb981489baec8c1e4bd282ab1c3560be8ff9bde",
+					Size:    2000,
+					Images:  []string{"sha256:1111111111111111111111111111111111111111111111111111111111111111"},
+				}},
+				ImagesReclaimable: []*types.ImageReclaimableSpace{{
+					ID:          "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+					RepoTags:    []string{"alpine:latest"},
+					Size:        2000,
+					Reclaimable: 2000,
+				}},
+			}, nil
+		},
+	})
+	cmd := newDiskUsageCommand(cli)
+	cmd.SetArgs([]string{})
+	cmd.Flags().Set("layers", "true")
+	cmd.Flags().Set("format", "{{range .Layers}}{{.ChainID}} {{.Images}}{{end}}")
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal("sha256:4fe2ade4980c2dda4fc95858ebb981489baec8c1e4bd282ab1c3560be8ff9bde alpine:latest", cli.OutBuffer().String()))
+}
+
+func TestDiskUsageWithHosts(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	cli.SetHostClients([]command.HostClient{
+		{
+			Host: "host1",
+			Client: &fakeClient{
+				diskUsageFunc: func(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error) {
+					return types.DiskUsage{LayersSize: 2000, Images: []*types.ImageSummary{{ID: "image1"}}}, nil
+				},
+			},
+		},
+		{
+			Host: "host2",
+			Client: &fakeClient{
+				diskUsageFunc: func(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error) {
+					return types.DiskUsage{}, errors.New("connection refused")
+				},
+			},
+		},
+	})
+	cmd := newDiskUsageCommand(cli)
+	cmd.SetArgs([]string{})
+	cmd.Flags().Set("format", "{{.Host}} {{.Type}} {{.TotalCount}} {{.Size}}")
+	assert.Error(t, cmd.Execute(), "host2: connection refused")
+	expected := `host1 Images 1 2kB
+host1 Containers 0 0B
+host1 Local Volumes 0 0B
+host1 Build Cache 0 0B
+`
+	assert.Check(t, is.Equal(expected, cli.OutBuffer().String()))
+
+	cmd = newDiskUsageCommand(cli)
+	cmd.SetArgs([]string{"--verbose"})
+	cmd.SetOutput(ioutil.Discard)
+	assert.Error(t, cmd.Execute(), "--verbose and --layers cannot be used with the --hosts flag")
+}
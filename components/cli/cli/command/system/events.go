@@ -22,6 +22,8 @@ import (
 type eventsOptions struct {
 	since  string
 	until  string
+	limit  int
+	after  string
 	filter opts.FilterOpt
 	format string
 }
@@ -42,6 +44,10 @@ func NewEventsCommand(dockerCli command.Cli) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&options.since, "since", "", "Show all events created since timestamp")
 	flags.StringVar(&options.until, "until", "", "Stream events until this timestamp")
+	flags.IntVar(&options.limit, "limit", 0, "Show at most this number of past events, without streaming new events")
+	flags.SetAnnotation("limit", "version", []string{"1.40"})
+	flags.StringVar(&options.after, "after", "", "Show the events after the event with this cursor (requires --limit)")
+	flags.SetAnnotation("after", "version", []string{"1.40"})
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
 	flags.StringVar(&options.format, "format", "", "Format the output using the given Go template")
 
@@ -49,6 +55,12 @@ func NewEventsCommand(dockerCli command.Cli) *cobra.Command {
 }
 
 func runEvents(dockerCli command.Cli, options *eventsOptions) error {
+	if options.limit < 0 {
+		return fmt.Errorf("invalid limit %d: must be a positive integer", options.limit)
+	}
+	if options.after != "" && options.limit == 0 {
+		return fmt.Errorf("--after requires --limit")
+	}
 	tmpl, err := makeTemplate(options.format)
 	if err != nil {
 		return cli.StatusError{
@@ -59,6 +71,8 @@ func runEvents(dockerCli command.Cli, options *eventsOptions) error {
 		Since:   options.since,
 		Until:   options.until,
 		Filters: options.filter.Value(),
+		Limit:   options.limit,
+		After:   options.after,
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
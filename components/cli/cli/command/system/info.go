@@ -13,6 +13,7 @@ import (
 	"github.com/docker/cli/templates"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/client"
 	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
 )
@@ -32,6 +33,7 @@ func NewInfoCommand(dockerCli command.Cli) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runInfo(dockerCli, &opts)
 		},
+		Annotations: map[string]string{"hosts": ""},
 	}
 
 	flags := cmd.Flags()
@@ -41,8 +43,19 @@ func NewInfoCommand(dockerCli command.Cli) *cobra.Command {
 	return cmd
 }
 
+// hostInfo is the information of one of several daemons, which can be
+// formatted with the {{.Host}} placeholder in addition to the fields of
+// types.Info.
+type hostInfo struct {
+	Host string
+	types.Info
+}
+
 func runInfo(dockerCli command.Cli, opts *infoOptions) error {
 	ctx := context.Background()
+	if hostClients := dockerCli.HostClients(); len(hostClients) > 0 {
+		return runHostsInfo(ctx, dockerCli, hostClients, opts)
+	}
 	info, err := dockerCli.Client().Info(ctx)
 	if err != nil {
 		return err
@@ -53,6 +66,40 @@ func runInfo(dockerCli command.Cli, opts *infoOptions) error {
 	return formatInfo(dockerCli, info, opts.format)
 }
 
+func runHostsInfo(ctx context.Context, dockerCli command.Cli, hostClients []command.HostClient, opts *infoOptions) error {
+	infos := make([]hostInfo, len(hostClients))
+	err := command.RunOnHosts(ctx, hostClients, func(ctx context.Context, i int, apiClient client.APIClient) error {
+		info, err := apiClient.Info(ctx)
+		if err != nil {
+			return err
+		}
+		infos[i] = hostInfo{Host: hostClients[i].Host, Info: info}
+		return nil
+	})
+
+	first := true
+	for _, info := range infos {
+		if info.Host == "" {
+			continue
+		}
+		if opts.format != "" {
+			if err := formatInfo(dockerCli, info, opts.format); err != nil {
+				return err
+			}
+			continue
+		}
+		if !first {
+			fmt.Fprintln(dockerCli.Out())
+		}
+		first = false
+		fmt.Fprintln(dockerCli.Out(), "Host:", info.Host)
+		if err := prettyPrintInfo(dockerCli, info.Info); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
 // nolint: gocyclo
 func prettyPrintInfo(dockerCli command.Cli, info types.Info) error {
 	fmt.Fprintln(dockerCli.Out(), "Containers:", info.Containers)
@@ -379,7 +426,7 @@ func getBackingFs(info types.Info) string {
 	return ""
 }
 
-func formatInfo(dockerCli command.Cli, info types.Info, format string) error {
+func formatInfo(dockerCli command.Cli, info interface{}, format string) error {
 	tmpl, err := templates.Parse(format)
 	if err != nil {
 		return cli.StatusError{StatusCode: 64,
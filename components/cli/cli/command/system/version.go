@@ -126,7 +126,11 @@ func runVersion(dockerCli command.Cli, opts *versionOptions) error {
 		return cli.StatusError{StatusCode: 64, Status: err.Error()}
 	}
 
-	orchestrator, err := command.GetStackOrchestrator("", dockerCli.ConfigFile().StackOrchestrator, dockerCli.Err())
+	configuredOrchestrator := command.ContextStackOrchestrator(dockerCli)
+	if configuredOrchestrator == "" {
+		configuredOrchestrator = dockerCli.ConfigFile().StackOrchestrator
+	}
+	orchestrator, err := command.GetStackOrchestrator("", configuredOrchestrator, dockerCli.Err())
 	if err != nil {
 		return cli.StatusError{StatusCode: 64, Status: err.Error()}
 	}
@@ -2,6 +2,7 @@ package volume
 
 import (
 	"context"
+	"io"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
@@ -13,9 +14,13 @@ type fakeClient struct {
 	client.Client
 	volumeCreateFunc  func(volumetypes.VolumeCreateBody) (types.Volume, error)
 	volumeInspectFunc func(volumeID string) (types.Volume, error)
+	volumeSizeFunc    func(volumeID string) (types.Volume, error)
 	volumeListFunc    func(filter filters.Args) (volumetypes.VolumeListOKBody, error)
 	volumeRemoveFunc  func(volumeID string, force bool) error
 	volumePruneFunc   func(filter filters.Args) (types.VolumesPruneReport, error)
+	volumeCloneFunc   func(volumeID string, options volumetypes.VolumeCloneBody) (types.Volume, error)
+	volumeExportFunc  func(volumeID string) (io.ReadCloser, error)
+	volumeImportFunc  func(volumeID string, input io.Reader) error
 }
 
 func (c *fakeClient) VolumeCreate(ctx context.Context, options volumetypes.VolumeCreateBody) (types.Volume, error) {
@@ -32,6 +37,13 @@ func (c *fakeClient) VolumeInspect(ctx context.Context, volumeID string) (types.
 	return types.Volume{}, nil
 }
 
+func (c *fakeClient) VolumeInspectWithSize(ctx context.Context, volumeID string) (types.Volume, error) {
+	if c.volumeSizeFunc != nil {
+		return c.volumeSizeFunc(volumeID)
+	}
+	return types.Volume{}, nil
+}
+
 func (c *fakeClient) VolumeList(ctx context.Context, filter filters.Args) (volumetypes.VolumeListOKBody, error) {
 	if c.volumeListFunc != nil {
 		return c.volumeListFunc(filter)
@@ -52,3 +64,24 @@ func (c *fakeClient) VolumeRemove(ctx context.Context, volumeID string, force bo
 	}
 	return nil
 }
+
+func (c *fakeClient) VolumeClone(ctx context.Context, volumeID string, options volumetypes.VolumeCloneBody) (types.Volume, error) {
+	if c.volumeCloneFunc != nil {
+		return c.volumeCloneFunc(volumeID, options)
+	}
+	return types.Volume{}, nil
+}
+
+func (c *fakeClient) VolumeExport(ctx context.Context, volumeID string) (io.ReadCloser, error) {
+	if c.volumeExportFunc != nil {
+		return c.volumeExportFunc(volumeID)
+	}
+	return nil, nil
+}
+
+func (c *fakeClient) VolumeImport(ctx context.Context, volumeID string, input io.Reader) error {
+	if c.volumeImportFunc != nil {
+		return c.volumeImportFunc(volumeID, input)
+	}
+	return nil
+}
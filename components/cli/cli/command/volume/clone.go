@@ -0,0 +1,59 @@
+package volume
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/opts"
+	volumetypes "github.com/docker/docker/api/types/volume"
+	"github.com/spf13/cobra"
+)
+
+type cloneOptions struct {
+	source string
+	name   string
+	labels opts.ListOpts
+}
+
+func newCloneCommand(dockerCli command.Cli) *cobra.Command {
+	options := cloneOptions{
+		labels: opts.NewListOpts(opts.ValidateEnv),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "clone [OPTIONS] SOURCE [VOLUME]",
+		Short: "Create a volume holding a copy of the data of a volume",
+		Args:  cli.RequiresRangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.source = args[0]
+			if len(args) == 2 {
+				options.name = args[1]
+			}
+			return runClone(dockerCli, options)
+		},
+		Annotations: map[string]string{"version": "1.40"},
+	}
+	flags := cmd.Flags()
+	flags.Var(&options.labels, "label", "Set metadata for the volume, instead of the metadata of the source volume")
+
+	return cmd
+}
+
+func runClone(dockerCli command.Cli, options cloneOptions) error {
+	cloneReq := volumetypes.VolumeCloneBody{
+		Name: options.name,
+	}
+	if options.labels.Len() > 0 {
+		cloneReq.Labels = opts.ConvertKVStringsToMap(options.labels.GetAll())
+	}
+
+	vol, err := dockerCli.Client().VolumeClone(context.Background(), options.source, cloneReq)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(dockerCli.Out(), "%s\n", vol.Name)
+	return nil
+}
@@ -0,0 +1,88 @@
+package volume
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	volumetypes "github.com/docker/docker/api/types/volume"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestVolumeCloneErrors(t *testing.T) {
+	testCases := []struct {
+		args            []string
+		volumeCloneFunc func(string, volumetypes.VolumeCloneBody) (types.Volume, error)
+		expectedError   string
+	}{
+		{
+			args:          []string{},
+			expectedError: "requires at least 1 and at most 2 arguments",
+		},
+		{
+			args:          []string{"too", "many", "arguments"},
+			expectedError: "requires at least 1 and at most 2 arguments",
+		},
+		{
+			args: []string{"foo"},
+			volumeCloneFunc: func(volumeID string, options volumetypes.VolumeCloneBody) (types.Volume, error) {
+				return types.Volume{}, errors.Errorf("error cloning volume")
+			},
+			expectedError: "error cloning volume",
+		},
+	}
+	for _, tc := range testCases {
+		cmd := newCloneCommand(
+			test.NewFakeCli(&fakeClient{
+				volumeCloneFunc: tc.volumeCloneFunc,
+			}),
+		)
+		cmd.SetArgs(tc.args)
+		cmd.SetOutput(ioutil.Discard)
+		assert.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestVolumeClone(t *testing.T) {
+	var source string
+	var options volumetypes.VolumeCloneBody
+	cli := test.NewFakeCli(&fakeClient{
+		volumeCloneFunc: func(volumeID string, body volumetypes.VolumeCloneBody) (types.Volume, error) {
+			source = volumeID
+			options = body
+			return types.Volume{Name: body.Name}, nil
+		},
+	})
+	cmd := newCloneCommand(cli)
+	cmd.SetArgs([]string{"foo", "bar"})
+	assert.NilError(t, cmd.Execute())
+
+	assert.Check(t, is.Equal("foo", source))
+	assert.Check(t, is.Equal("bar", options.Name))
+	// the labels of the source volume are used
+	assert.Check(t, options.Labels == nil)
+	assert.Check(t, is.Equal("bar", strings.TrimSpace(cli.OutBuffer().String())))
+}
+
+func TestVolumeCloneWithLabels(t *testing.T) {
+	var options volumetypes.VolumeCloneBody
+	cli := test.NewFakeCli(&fakeClient{
+		volumeCloneFunc: func(volumeID string, body volumetypes.VolumeCloneBody) (types.Volume, error) {
+			options = body
+			return types.Volume{Name: "generated"}, nil
+		},
+	})
+	cmd := newCloneCommand(cli)
+	cmd.SetArgs([]string{"foo"})
+	cmd.Flags().Set("label", "lbl1=v1")
+	cmd.Flags().Set("label", "lbl2=v2")
+	assert.NilError(t, cmd.Execute())
+
+	assert.Check(t, is.Equal("", options.Name))
+	assert.Check(t, is.DeepEqual(map[string]string{"lbl1": "v1", "lbl2": "v2"}, options.Labels))
+	assert.Check(t, is.Equal("generated", strings.TrimSpace(cli.OutBuffer().String())))
+}
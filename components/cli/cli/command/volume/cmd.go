@@ -16,7 +16,10 @@ func NewVolumeCommand(dockerCli command.Cli) *cobra.Command {
 		Annotations: map[string]string{"version": "1.21"},
 	}
 	cmd.AddCommand(
+		newCloneCommand(dockerCli),
 		newCreateCommand(dockerCli),
+		newExportCommand(dockerCli),
+		newImportCommand(dockerCli),
 		newInspectCommand(dockerCli),
 		newListCommand(dockerCli),
 		newRemoveCommand(dockerCli),
@@ -0,0 +1,55 @@
+package volume
+
+import (
+	"context"
+	"io"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type exportOptions struct {
+	volume string
+	output string
+}
+
+func newExportCommand(dockerCli command.Cli) *cobra.Command {
+	var options exportOptions
+
+	cmd := &cobra.Command{
+		Use:   "export [OPTIONS] VOLUME",
+		Short: "Export the data of a volume as a tar archive",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.volume = args[0]
+			return runExport(dockerCli, options)
+		},
+		Annotations: map[string]string{"version": "1.40"},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.output, "output", "o", "", "Write to a file, instead of STDOUT")
+
+	return cmd
+}
+
+func runExport(dockerCli command.Cli, options exportOptions) error {
+	if options.output == "" && dockerCli.Out().IsTerminal() {
+		return errors.New("cowardly refusing to save to a terminal. Use the -o flag or redirect")
+	}
+
+	responseBody, err := dockerCli.Client().VolumeExport(context.Background(), options.volume)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	if options.output == "" {
+		_, err := io.Copy(dockerCli.Out(), responseBody)
+		return err
+	}
+
+	return command.CopyToFile(options.output, responseBody)
+}
@@ -0,0 +1,44 @@
+package volume
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/fs"
+)
+
+func TestVolumeExportErrors(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	cmd := newExportCommand(cli)
+	cmd.SetArgs([]string{})
+	cmd.SetOutput(ioutil.Discard)
+	assert.ErrorContains(t, cmd.Execute(), "requires exactly 1 argument")
+}
+
+func TestVolumeExportOutputToFile(t *testing.T) {
+	dir := fs.NewDir(t, "volume-export-test")
+	defer dir.Remove()
+
+	var volume string
+	cli := test.NewFakeCli(&fakeClient{
+		volumeExportFunc: func(volumeID string) (io.ReadCloser, error) {
+			volume = volumeID
+			return ioutil.NopCloser(strings.NewReader("volume archive")), nil
+		},
+	})
+	cmd := newExportCommand(cli)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"-o", dir.Join("volume.tar"), "foo"})
+	assert.NilError(t, cmd.Execute())
+
+	assert.Check(t, is.Equal("foo", volume))
+	expected := fs.Expected(t,
+		fs.WithFile("volume.tar", "volume archive", fs.MatchAnyFileMode),
+	)
+	assert.Assert(t, fs.Equal(dir.Path(), expected))
+}
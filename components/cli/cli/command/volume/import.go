@@ -0,0 +1,61 @@
+package volume
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/docker/pkg/system"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type importOptions struct {
+	volume string
+	input  string
+}
+
+func newImportCommand(dockerCli command.Cli) *cobra.Command {
+	var options importOptions
+
+	cmd := &cobra.Command{
+		Use:   "import [OPTIONS] VOLUME",
+		Short: "Import the data of a volume from a tar archive or STDIN",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.volume = args[0]
+			return runImport(dockerCli, options)
+		},
+		Annotations: map[string]string{"version": "1.40"},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.input, "input", "i", "", "Read from tar archive file, instead of STDIN")
+
+	return cmd
+}
+
+func runImport(dockerCli command.Cli, options importOptions) error {
+	var input io.Reader = dockerCli.In()
+	if options.input != "" {
+		file, err := system.OpenSequential(options.input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	if options.input == "" && dockerCli.In().IsTerminal() {
+		return errors.Errorf("requested import from stdin, but stdin is empty")
+	}
+
+	if err := dockerCli.Client().VolumeImport(context.Background(), options.volume, input); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(dockerCli.Out(), "%s\n", options.volume)
+	return nil
+}
@@ -0,0 +1,70 @@
+package volume
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/fs"
+)
+
+func TestVolumeImportErrors(t *testing.T) {
+	testCases := []struct {
+		args             []string
+		volumeImportFunc func(volumeID string, input io.Reader) error
+		expectedError    string
+	}{
+		{
+			args:          []string{},
+			expectedError: "requires exactly 1 argument",
+		},
+		{
+			args:          []string{"-i", "/does/not/exist", "foo"},
+			expectedError: "no such file or directory",
+		},
+		{
+			args: []string{"-i", "import_test.go", "foo"},
+			volumeImportFunc: func(volumeID string, input io.Reader) error {
+				return errors.Errorf("error importing volume")
+			},
+			expectedError: "error importing volume",
+		},
+	}
+
+	for _, tc := range testCases {
+		cli := test.NewFakeCli(&fakeClient{
+			volumeImportFunc: tc.volumeImportFunc,
+		})
+		cmd := newImportCommand(cli)
+		cmd.SetArgs(tc.args)
+		cmd.SetOutput(ioutil.Discard)
+		assert.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestVolumeImportFromFile(t *testing.T) {
+	file := fs.NewFile(t, "volume-import-test", fs.WithContent("volume archive"))
+	defer file.Remove()
+
+	var volume, content string
+	cli := test.NewFakeCli(&fakeClient{
+		volumeImportFunc: func(volumeID string, input io.Reader) error {
+			volume = volumeID
+			b, err := ioutil.ReadAll(input)
+			content = string(b)
+			return err
+		},
+	})
+	cmd := newImportCommand(cli)
+	cmd.SetArgs([]string{"-i", file.Path(), "foo"})
+	assert.NilError(t, cmd.Execute())
+
+	assert.Check(t, is.Equal("foo", volume))
+	assert.Check(t, is.Equal("volume archive", content))
+	assert.Check(t, is.Equal("foo", strings.TrimSpace(cli.OutBuffer().String())))
+}
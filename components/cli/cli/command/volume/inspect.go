@@ -11,6 +11,7 @@ import (
 
 type inspectOptions struct {
 	format string
+	size   bool
 	names  []string
 }
 
@@ -27,7 +28,10 @@ func newInspectCommand(dockerCli command.Cli) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.format, "format", "f", "", "Format the output using the given Go template")
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.format, "format", "f", "", "Format the output using the given Go template")
+	flags.BoolVarP(&opts.size, "size", "s", false, "Compute the size of the volume")
+	flags.SetAnnotation("size", "version", []string{"1.40"})
 
 	return cmd
 }
@@ -38,6 +42,10 @@ func runInspect(dockerCli command.Cli, opts inspectOptions) error {
 	ctx := context.Background()
 
 	getVolFunc := func(name string) (interface{}, []byte, error) {
+		if opts.size {
+			i, err := client.VolumeInspectWithSize(ctx, name)
+			return i, nil, err
+		}
 		i, err := client.VolumeInspect(ctx, name)
 		return i, nil, err
 	}
@@ -11,6 +11,7 @@ import (
 	// Import builders to get the builder function as package function
 	. "github.com/docker/cli/internal/test/builders"
 	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
 	"gotest.tools/golden"
 )
 
@@ -139,3 +140,20 @@ func TestVolumeInspectWithFormat(t *testing.T) {
 		golden.Assert(t, cli.OutBuffer().String(), fmt.Sprintf("volume-inspect-with-format.%s.golden", tc.name))
 	}
 }
+
+func TestVolumeInspectWithSize(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		volumeInspectFunc: func(volumeID string) (types.Volume, error) {
+			return types.Volume{}, errors.Errorf("expected the size of the volume to be computed")
+		},
+		volumeSizeFunc: func(volumeID string) (types.Volume, error) {
+			return types.Volume{Name: volumeID, UsageData: &types.VolumeUsageData{Size: 42, RefCount: 1}}, nil
+		},
+	})
+	cmd := newInspectCommand(cli)
+	cmd.SetArgs([]string{"foo"})
+	cmd.Flags().Set("size", "true")
+	cmd.Flags().Set("format", "{{.Name}} {{.UsageData.Size}}")
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal("foo 42\n", cli.OutBuffer().String()))
+}
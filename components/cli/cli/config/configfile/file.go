@@ -48,6 +48,8 @@ type ConfigFile struct {
 	Experimental         string                      `json:"experimental,omitempty"`
 	StackOrchestrator    string                      `json:"stackOrchestrator,omitempty"`
 	Kubernetes           *KubernetesConfig           `json:"kubernetes,omitempty"`
+	CurrentContext       string                      `json:"currentContext,omitempty"`
+	HostGroups           map[string][]string         `json:"hostGroups,omitempty"`
 }
 
 // ProxyConfig contains proxy configuration settings
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/docker/cli/cli/connhelper/ssh"
+	shlex "github.com/flynn-archive/go-shlex"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -27,10 +28,50 @@ type ConnectionHelper struct {
 	Host   string // dummy URL used for HTTP requests. e.g. "http://docker"
 }
 
+const (
+	// cmdScheme is the scheme of URLs running an arbitrary command, e.g.
+	// "cmd://kubectl exec -i dind -- docker system dial-stdio".
+	cmdScheme = "cmd://"
+	// externalHelperPrefix is the prefix of the name of external connection
+	// helper binaries, e.g. "docker-connhelper-serial" for "serial://" URLs.
+	externalHelperPrefix = "docker-connhelper-"
+	// dummyHost is the URL used for HTTP requests sent through a helper
+	dummyHost = "http://docker"
+)
+
+// builtinSchemes are the schemes that are handled by the Docker client
+// itself, and which cannot be overridden by an external connection helper.
+var builtinSchemes = map[string]bool{
+	"tcp":   true,
+	"unix":  true,
+	"npipe": true,
+	"fd":    true,
+	"http":  true,
+	"https": true,
+	"ssh":   true,
+}
+
 // GetConnectionHelper returns Docker-specific connection helper for the given URL.
 // GetConnectionHelper returns nil without error when no helper is registered for the scheme.
-// URL is like "ssh://me@server01".
+//
+// The following URLs are supported:
+//
+//   - "ssh://me@server01" runs "docker system dial-stdio" on the remote host through ssh.
+//   - "cmd://<command> [args...]" runs the given command, which must speak the
+//     Docker API on its stdin and stdout.
+//   - "<name>://..." runs "docker-connhelper-<name> dial <URL>" if such a binary
+//     is found in the PATH.
 func GetConnectionHelper(daemonURL string) (*ConnectionHelper, error) {
+	if strings.HasPrefix(daemonURL, cmdScheme) {
+		args, err := shlex.Split(strings.TrimPrefix(daemonURL, cmdScheme))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid command in %q", daemonURL)
+		}
+		if len(args) == 0 {
+			return nil, errors.Errorf("no command specified in %q", daemonURL)
+		}
+		return newCommandHelper(args[0], args[1:]...), nil
+	}
 	u, err := url.Parse(daemonURL)
 	if err != nil {
 		return nil, err
@@ -41,16 +82,64 @@ func GetConnectionHelper(daemonURL string) (*ConnectionHelper, error) {
 		if err != nil {
 			return nil, err
 		}
-		return &ConnectionHelper{
-			Dialer: func(ctx context.Context, network, addr string) (net.Conn, error) {
-				return newCommandConn(ctx, sshCmd, sshArgs...)
-			},
-			Host: "http://docker",
-		}, nil
-	}
-	// Future version may support plugins via ~/.docker/config.json. e.g. "dind"
-	// See docker/cli#889 for the previous discussion.
-	return nil, err
+		return newCommandHelper(sshCmd, sshArgs...), nil
+	}
+	if helper, ok := lookupExternalHelper(daemonURL); ok {
+		return newCommandHelper(helper, "dial", daemonURL), nil
+	}
+	return nil, nil
+}
+
+// IsConnectionHelperURL returns whether the given URL is handled by a
+// connection helper rather than by a direct connection to the daemon.
+func IsConnectionHelperURL(daemonURL string) bool {
+	if strings.HasPrefix(daemonURL, cmdScheme) || strings.HasPrefix(daemonURL, "ssh://") {
+		return true
+	}
+	_, ok := lookupExternalHelper(daemonURL)
+	return ok
+}
+
+// RunsCommand returns whether connecting to the given URL runs a command
+// chosen by the URL: the command of a "cmd://" URL, or the external
+// connection helper of a URL whose scheme is not built in, whether that
+// helper is installed or not. Such URLs should only be used if they come
+// from a trusted source.
+func RunsCommand(daemonURL string) bool {
+	if strings.HasPrefix(daemonURL, cmdScheme) {
+		return true
+	}
+	if !strings.Contains(daemonURL, "://") {
+		return false
+	}
+	u, err := url.Parse(daemonURL)
+	return err != nil || (u.Scheme != "" && !builtinSchemes[u.Scheme])
+}
+
+// lookupExternalHelper returns the path of the external connection helper
+// binary for the scheme of the given URL, if any.
+func lookupExternalHelper(daemonURL string) (string, bool) {
+	if !strings.Contains(daemonURL, "://") {
+		return "", false
+	}
+	u, err := url.Parse(daemonURL)
+	if err != nil || u.Scheme == "" || builtinSchemes[u.Scheme] {
+		return "", false
+	}
+	path, err := exec.LookPath(externalHelperPrefix + u.Scheme)
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+func newCommandHelper(cmd string, args ...string) *ConnectionHelper {
+	return &ConnectionHelper{
+		Dialer: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return newCommandConn(ctx, cmd, args...)
+		},
+		Host: dummyHost,
+	}
 }
 
 func newCommandConn(ctx context.Context, cmd string, args ...string) (net.Conn, error) {
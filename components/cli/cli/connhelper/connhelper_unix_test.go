@@ -5,10 +5,14 @@ package connhelper
 import (
 	"context"
 	"io"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"gotest.tools/assert"
 	is "gotest.tools/assert/cmp"
+	"gotest.tools/env"
+	"gotest.tools/fs"
 )
 
 // For https://github.com/docker/cli/pull/1014#issuecomment-409308139
@@ -42,3 +46,66 @@ func TestCommandConnEOFWithoutError(t *testing.T) {
 	assert.Check(t, is.Equal(0, n))
 	assert.Check(t, is.Equal(io.EOF, err))
 }
+
+func TestGetConnectionHelperCmd(t *testing.T) {
+	helper, err := GetConnectionHelper(`cmd://sh -c "echo hello from cmd"`)
+	assert.NilError(t, err)
+	assert.Assert(t, helper != nil)
+	assert.Check(t, is.Equal("http://docker", helper.Host))
+
+	c, err := helper.Dialer(context.TODO(), "tcp", "docker:80")
+	assert.NilError(t, err)
+	defer c.Close()
+	b, err := ioutil.ReadAll(c)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("hello from cmd\n", string(b)))
+}
+
+func TestGetConnectionHelperCmdErrors(t *testing.T) {
+	_, err := GetConnectionHelper("cmd://")
+	assert.Check(t, is.ErrorContains(err, "no command specified"))
+	_, err = GetConnectionHelper(`cmd://sh -c "unterminated`)
+	assert.Check(t, is.ErrorContains(err, "invalid command"))
+}
+
+func TestGetConnectionHelperExternal(t *testing.T) {
+	dir := fs.NewDir(t, "connhelper-test",
+		fs.WithFile("docker-connhelper-test", "#!/bin/sh\necho \"$@\"\n", fs.WithMode(0755)),
+		fs.WithFile("docker-connhelper-tcp", "#!/bin/sh\nexit 1\n", fs.WithMode(0755)),
+	)
+	defer dir.Remove()
+	defer env.Patch(t, "PATH", dir.Path()+string(os.PathListSeparator)+os.Getenv("PATH"))()
+
+	assert.Check(t, IsConnectionHelperURL("test://server01/console"))
+	assert.Check(t, !IsConnectionHelperURL("tcp://server01:2375"))
+	assert.Check(t, !IsConnectionHelperURL("missing://server01"))
+	assert.Check(t, !IsConnectionHelperURL("localhost:2375"))
+
+	helper, err := GetConnectionHelper("tcp://server01:2375")
+	assert.NilError(t, err)
+	assert.Check(t, is.Nil(helper))
+
+	helper, err = GetConnectionHelper("test://server01/console")
+	assert.NilError(t, err)
+	assert.Assert(t, helper != nil)
+
+	c, err := helper.Dialer(context.TODO(), "tcp", "docker:80")
+	assert.NilError(t, err)
+	defer c.Close()
+	b, err := ioutil.ReadAll(c)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("dial test://server01/console\n", string(b)))
+}
+
+func TestRunsCommand(t *testing.T) {
+	for url, expected := range map[string]bool{
+		"cmd://docker system dial-stdio": true,
+		"serial:///dev/ttyS0":            true,
+		"ssh://me@server01":              false,
+		"tcp://localhost:2375":           false,
+		"unix:///var/run/docker.sock":    false,
+		"localhost:2375":                 false,
+	} {
+		assert.Check(t, is.Equal(expected, RunsCommand(url)), url)
+	}
+}
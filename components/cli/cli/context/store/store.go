@@ -0,0 +1,388 @@
+package store
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	metaFile = "meta.json"
+	tlsDir   = "tls"
+
+	caFile   = "ca.pem"
+	certFile = "cert.pem"
+	keyFile  = "key.pem"
+
+	// maxImportFileSize is the maximum size of a file of an exported
+	// context, which only contains metadata and TLS material.
+	maxImportFileSize = 1 << 20
+)
+
+var validNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.+-]*$`)
+
+// Store manages the contexts, which are named connection settings, stored on
+// the local filesystem
+type Store interface {
+	List() ([]Metadata, error)
+	Get(name string) (Metadata, error)
+	Create(meta Metadata, tlsData *TLSData) error
+	Remove(name string) error
+	TLSFiles(name string) (TLSFiles, error)
+	Export(name string, w io.Writer) error
+	ReadImport(name string, r io.Reader) (ImportedContext, error)
+	CommitImport(imported ImportedContext) error
+}
+
+// Metadata describes a context
+type Metadata struct {
+	Name              string
+	Description       string   `json:",omitempty"`
+	StackOrchestrator string   `json:",omitempty"`
+	Endpoint          Endpoint `json:"Docker"`
+}
+
+// Endpoint describes the daemon a context connects to
+type Endpoint struct {
+	// Host is the address of the daemon, in any of the forms accepted by
+	// the -H flag, including the URLs handled by connection helpers such as
+	// "ssh://me@server01".
+	Host          string
+	SkipTLSVerify bool `json:",omitempty"`
+}
+
+// ImportedContext is a context read from a tar archive written by Export. It
+// is only saved once it is passed to CommitImport.
+type ImportedContext struct {
+	Metadata Metadata
+	files    map[string][]byte
+}
+
+// TLSData is the TLS material of a context, PEM encoded
+type TLSData struct {
+	CA   []byte
+	Cert []byte
+	Key  []byte
+}
+
+// TLSFiles are the paths of the TLS material of a context. Paths are empty
+// for the files that the context does not have.
+type TLSFiles struct {
+	CAFile   string `json:",omitempty"`
+	CertFile string `json:",omitempty"`
+	KeyFile  string `json:",omitempty"`
+}
+
+// IsEmpty returns true if the context has no TLS material
+func (f TLSFiles) IsEmpty() bool {
+	return f.CAFile == "" && f.CertFile == "" && f.KeyFile == ""
+}
+
+// ValidateName returns an error if name is not a valid context name
+func ValidateName(name string) error {
+	if !validNameRegexp.MatchString(name) {
+		return errors.Errorf("context name %q is invalid, names should only contain letters, digits, and the characters \"_.+-\", and start with a letter or a digit", name)
+	}
+	return nil
+}
+
+// fsStore manages contexts stored on the local filesystem, one directory
+// per context
+type fsStore struct {
+	root string
+}
+
+// NewStore returns a new store for a local file path
+func NewStore(root string) Store {
+	return &fsStore{root: root}
+}
+
+// List returns the contexts, sorted by name
+func (s *fsStore) List() ([]Metadata, error) {
+	fileInfos, err := ioutil.ReadDir(s.root)
+	switch {
+	case os.IsNotExist(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	var contexts []Metadata
+	for _, info := range fileInfos {
+		if !info.IsDir() || ValidateName(info.Name()) != nil {
+			continue
+		}
+		meta, err := s.Get(info.Name())
+		if IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		contexts = append(contexts, meta)
+	}
+	sort.Slice(contexts, func(i, j int) bool {
+		return contexts[i].Name < contexts[j].Name
+	})
+	return contexts, nil
+}
+
+// Get returns the metadata of a context
+func (s *fsStore) Get(name string) (Metadata, error) {
+	if err := ValidateName(name); err != nil {
+		return Metadata{}, err
+	}
+	bytes, err := ioutil.ReadFile(filepath.Join(s.root, name, metaFile))
+	switch {
+	case os.IsNotExist(err):
+		return Metadata{}, newNotFoundError(name)
+	case err != nil:
+		return Metadata{}, err
+	}
+	var meta Metadata
+	if err := json.Unmarshal(bytes, &meta); err != nil {
+		return Metadata{}, errors.Wrapf(err, "invalid context %s", name)
+	}
+	meta.Name = name
+	return meta, nil
+}
+
+// Create saves a new context, with its TLS material if tlsData is not nil
+func (s *fsStore) Create(meta Metadata, tlsData *TLSData) error {
+	if err := ValidateName(meta.Name); err != nil {
+		return err
+	}
+	files := map[string][]byte{}
+	bytes, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	files[metaFile] = bytes
+	if tlsData != nil {
+		for name, data := range map[string][]byte{caFile: tlsData.CA, certFile: tlsData.Cert, keyFile: tlsData.Key} {
+			if len(data) > 0 {
+				files[filepath.Join(tlsDir, name)] = data
+			}
+		}
+	}
+	return s.write(meta.Name, files)
+}
+
+// write atomically creates the directory of a context with files, which are
+// indexed by their path relative to that directory.
+func (s *fsStore) write(name string, files map[string][]byte) error {
+	dir := filepath.Join(s.root, name)
+	if _, err := os.Stat(dir); err == nil {
+		return errors.Errorf("context %q already exists", name)
+	}
+	if err := os.MkdirAll(s.root, 0755); err != nil {
+		return err
+	}
+	tmpDir, err := ioutil.TempDir(s.root, ".tmp-"+name)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for path, data := range files {
+		path = filepath.Join(tmpDir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, data, 0600); err != nil {
+			return err
+		}
+	}
+	if err := os.Chmod(tmpDir, 0755); err != nil {
+		return err
+	}
+	return os.Rename(tmpDir, dir)
+}
+
+// Remove deletes a context and its TLS material
+func (s *fsStore) Remove(name string) error {
+	if _, err := s.Get(name); err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(s.root, name))
+}
+
+// TLSFiles returns the paths of the TLS material of a context
+func (s *fsStore) TLSFiles(name string) (TLSFiles, error) {
+	if _, err := s.Get(name); err != nil {
+		return TLSFiles{}, err
+	}
+	dir := filepath.Join(s.root, name, tlsDir)
+	existing := func(file string) string {
+		path := filepath.Join(dir, file)
+		if _, err := os.Stat(path); err != nil {
+			return ""
+		}
+		return path
+	}
+	return TLSFiles{
+		CAFile:   existing(caFile),
+		CertFile: existing(certFile),
+		KeyFile:  existing(keyFile),
+	}, nil
+}
+
+// Export writes a tar archive of a context, including its TLS material, to w
+func (s *fsStore) Export(name string, w io.Writer) error {
+	meta, err := s.Get(name)
+	if err != nil {
+		return err
+	}
+	tlsFiles, err := s.TLSFiles(name)
+	if err != nil {
+		return err
+	}
+	// The name is given on import
+	meta.Name = ""
+	bytes, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	if err := writeTarFile(tw, metaFile, bytes); err != nil {
+		return err
+	}
+	for _, f := range []struct{ name, path string }{
+		{caFile, tlsFiles.CAFile},
+		{certFile, tlsFiles.CertFile},
+		{keyFile, tlsFiles.KeyFile},
+	} {
+		if f.path == "" {
+			continue
+		}
+		data, err := ioutil.ReadFile(f.path)
+		if err != nil {
+			return err
+		}
+		if err := writeTarFile(tw, tlsDir+"/"+f.name, data); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0600,
+		Size:     int64(len(data)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// ReadImport reads and validates a context named name from a tar archive
+// written by Export, without saving it.
+func (s *fsStore) ReadImport(name string, r io.Reader) (ImportedContext, error) {
+	if err := ValidateName(name); err != nil {
+		return ImportedContext{}, err
+	}
+	if _, err := s.Get(name); err == nil {
+		return ImportedContext{}, errors.Errorf("context %q already exists", name)
+	}
+	files := map[string][]byte{}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ImportedContext{}, errors.Wrap(err, "invalid context archive")
+		}
+		if hdr.Typeflag == tar.TypeDir {
+			continue
+		}
+		var path string
+		switch hdr.Name {
+		case metaFile:
+			path = metaFile
+		case tlsDir + "/" + caFile, tlsDir + "/" + certFile, tlsDir + "/" + keyFile:
+			path = filepath.Join(tlsDir, filepath.Base(hdr.Name))
+		default:
+			return ImportedContext{}, errors.Errorf("invalid context archive: unexpected file %s", hdr.Name)
+		}
+		if hdr.Typeflag != tar.TypeReg || hdr.Size > maxImportFileSize {
+			return ImportedContext{}, errors.Errorf("invalid context archive: invalid file %s", hdr.Name)
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return ImportedContext{}, errors.Wrap(err, "invalid context archive")
+		}
+		files[path] = data
+	}
+
+	raw, ok := files[metaFile]
+	if !ok {
+		return ImportedContext{}, errors.Errorf("invalid context archive: missing %s", metaFile)
+	}
+	var meta Metadata
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return ImportedContext{}, errors.Wrap(err, "invalid context archive")
+	}
+	meta.Name = name
+	return ImportedContext{Metadata: meta, files: files}, nil
+}
+
+// CommitImport saves a context read by ReadImport
+func (s *fsStore) CommitImport(imported ImportedContext) error {
+	if imported.files == nil {
+		return errors.New("context was not read from an archive")
+	}
+	if err := ValidateName(imported.Metadata.Name); err != nil {
+		return err
+	}
+	bytes, err := json.Marshal(imported.Metadata)
+	if err != nil {
+		return err
+	}
+	files := map[string][]byte{metaFile: bytes}
+	for path, data := range imported.files {
+		if path != metaFile {
+			files[path] = data
+		}
+	}
+	return s.write(imported.Metadata.Name, files)
+}
+
+type notFoundError struct {
+	name string
+}
+
+func newNotFoundError(name string) *notFoundError {
+	return &notFoundError{name: name}
+}
+
+func (n *notFoundError) Error() string {
+	return fmt.Sprintf("context %q does not exist", n.name)
+}
+
+// NotFound interface
+func (n *notFoundError) NotFound() {}
+
+// IsNotFound returns true if the error is a not found error
+func IsNotFound(err error) bool {
+	_, ok := errors.Cause(err).(notFound)
+	return ok
+}
+
+type notFound interface {
+	NotFound()
+}
@@ -0,0 +1,155 @@
+package store
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func newTestStore(t *testing.T) (Store, func()) {
+	tmpdir, err := ioutil.TempDir("", "context-store-test")
+	assert.NilError(t, err)
+
+	return NewStore(tmpdir), func() { os.RemoveAll(tmpdir) }
+}
+
+func TestStoreCreateGetList(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	contexts, err := store.List()
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(contexts, 0))
+
+	prod := Metadata{
+		Name:              "prod",
+		Description:       "production",
+		StackOrchestrator: "swarm",
+		Endpoint:          Endpoint{Host: "tcp://prod:2376"},
+	}
+	dev := Metadata{Name: "dev", Endpoint: Endpoint{Host: "ssh://me@dev"}}
+	assert.NilError(t, store.Create(prod, nil))
+	assert.NilError(t, store.Create(dev, nil))
+	assert.Check(t, is.ErrorContains(store.Create(dev, nil), `context "dev" already exists`))
+
+	meta, err := store.Get("prod")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(prod, meta))
+
+	contexts, err = store.List()
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]Metadata{dev, prod}, contexts))
+}
+
+func TestStoreGetNotFound(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	_, err := store.Get("missing")
+	assert.Check(t, IsNotFound(err))
+	assert.Check(t, is.Error(err, `context "missing" does not exist`))
+	assert.Check(t, IsNotFound(store.Remove("missing")))
+}
+
+func TestStoreInvalidName(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	for _, name := range []string{"", "../foo", ".hidden", "a/b", "-a"} {
+		assert.Check(t, is.ErrorContains(store.Create(Metadata{Name: name}, nil), "is invalid"), name)
+	}
+}
+
+func TestStoreRemove(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	assert.NilError(t, store.Create(Metadata{Name: "foo"}, &TLSData{CA: []byte("ca")}))
+	assert.NilError(t, store.Remove("foo"))
+	_, err := store.Get("foo")
+	assert.Check(t, IsNotFound(err))
+}
+
+func TestStoreTLSFiles(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	assert.NilError(t, store.Create(Metadata{Name: "plain"}, nil))
+	files, err := store.TLSFiles("plain")
+	assert.NilError(t, err)
+	assert.Check(t, files.IsEmpty())
+
+	assert.NilError(t, store.Create(Metadata{Name: "tls"}, &TLSData{CA: []byte("ca"), Cert: []byte("cert")}))
+	files, err = store.TLSFiles("tls")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("", files.KeyFile))
+	content, err := ioutil.ReadFile(files.CAFile)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("ca", string(content)))
+	content, err = ioutil.ReadFile(files.CertFile)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("cert", string(content)))
+}
+
+func TestStoreExportImport(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	meta := Metadata{
+		Name:     "src",
+		Endpoint: Endpoint{Host: "tcp://host:2376", SkipTLSVerify: true},
+	}
+	tlsData := &TLSData{CA: []byte("ca"), Cert: []byte("cert"), Key: []byte("key")}
+	assert.NilError(t, store.Create(meta, tlsData))
+
+	var buf bytes.Buffer
+	assert.NilError(t, store.Export("src", &buf))
+	read, err := store.ReadImport("dst", bytes.NewReader(buf.Bytes()))
+	assert.NilError(t, err)
+	meta.Name = "dst"
+	assert.Check(t, is.DeepEqual(meta, read.Metadata))
+	// nothing is saved until the import is committed
+	_, err = store.Get("dst")
+	assert.Check(t, IsNotFound(err))
+	assert.NilError(t, store.CommitImport(read))
+	_, err = store.ReadImport("dst", bytes.NewReader(buf.Bytes()))
+	assert.Check(t, is.ErrorContains(err, "already exists"))
+	assert.Check(t, is.ErrorContains(store.CommitImport(read), "already exists"))
+
+	imported, err := store.Get("dst")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(meta, imported))
+
+	files, err := store.TLSFiles("dst")
+	assert.NilError(t, err)
+	content, err := ioutil.ReadFile(files.KeyFile)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("key", string(content)))
+}
+
+func TestStoreImportInvalid(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	assert.NilError(t, writeTarFile(tw, "../meta.json", []byte("{}")))
+	assert.NilError(t, tw.Close())
+	_, err := store.ReadImport("foo", &buf)
+	assert.Check(t, is.ErrorContains(err, "unexpected file ../meta.json"))
+
+	buf.Reset()
+	tw = tar.NewWriter(&buf)
+	assert.NilError(t, writeTarFile(tw, "tls/ca.pem", []byte("ca")))
+	assert.NilError(t, tw.Close())
+	_, err = store.ReadImport("foo", &buf)
+	assert.Check(t, is.ErrorContains(err, "missing meta.json"))
+
+	_, err = store.Get("foo")
+	assert.Check(t, IsNotFound(err))
+}
@@ -31,8 +31,10 @@ var (
 
 // CommonOptions are options common to both the client and the daemon.
 type CommonOptions struct {
+	Context    string
 	Debug      bool
 	Hosts      []string
+	FanOut     []string
 	LogLevel   string
 	TLS        bool
 	TLSVerify  bool
@@ -70,6 +72,10 @@ func (commonOpts *CommonOptions) InstallFlags(flags *pflag.FlagSet) {
 	// opts.ValidateHost is not used here, so as to allow connection helpers
 	hostOpt := opts.NewNamedListOptsRef("hosts", &commonOpts.Hosts, nil)
 	flags.VarP(hostOpt, "host", "H", "Daemon socket(s) to connect to")
+	flags.StringVarP(&commonOpts.Context, "context", "c", "",
+		`Name of the context to use to connect to the daemon (overrides DOCKER_HOST env var and default context set with "docker context use")`)
+	flags.StringSliceVar(&commonOpts.FanOut, "hosts", nil,
+		"Comma-separated list of daemons or contexts, or host group of the configuration file, to run the command against")
 }
 
 // SetDefaultOptions sets default values for options after flag parsing is
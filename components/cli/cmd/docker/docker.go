@@ -43,6 +43,9 @@ func newDockerCommand(dockerCli *command.DockerCli) *cobra.Command {
 			if err := dockerCli.Initialize(opts); err != nil {
 				return err
 			}
+			if err := isFanOutSupported(cmd, dockerCli); err != nil {
+				return err
+			}
 			return isSupported(cmd, dockerCli)
 		},
 		Version:               fmt.Sprintf("%s, build %s", cli.Version, cli.GitCommit),
@@ -296,6 +299,18 @@ func findCommand(cmd *cobra.Command, commands []string) bool {
 	return findCommand(cmd.Parent(), commands)
 }
 
+// isFanOutSupported returns an error if the --hosts flag is set, and cmd
+// does not have the "hosts" annotation of the commands supporting it.
+func isFanOutSupported(cmd *cobra.Command, dockerCli command.Cli) error {
+	if len(dockerCli.HostClients()) == 0 {
+		return nil
+	}
+	if _, ok := cmd.Annotations["hosts"]; !ok {
+		return fmt.Errorf("%s does not support the --hosts flag", cmd.CommandPath())
+	}
+	return nil
+}
+
 func isSupported(cmd *cobra.Command, details versionDetails) error {
 	if err := areSubcommandsSupported(cmd, details); err != nil {
 		return err
@@ -9,6 +9,7 @@ import (
 
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/config/configfile"
+	contextstore "github.com/docker/cli/cli/context/store"
 	manifeststore "github.com/docker/cli/cli/manifest/store"
 	registryclient "github.com/docker/cli/cli/registry/client"
 	"github.com/docker/cli/cli/trust"
@@ -38,6 +39,9 @@ type FakeCli struct {
 	registryClient                registryclient.RegistryClient
 	contentTrust                  bool
 	containerizedEngineClientFunc containerizedEngineFuncType
+	contextStore                  contextstore.Store
+	currentContext                string
+	hostClients                   []command.HostClient
 }
 
 // NewFakeCli returns a fake for the command.Cli interface
@@ -52,7 +56,8 @@ func NewFakeCli(client client.APIClient, opts ...func(*FakeCli)) *FakeCli {
 		in:        command.NewInStream(ioutil.NopCloser(strings.NewReader(""))),
 		// Use an empty string for filename so that tests don't create configfiles
 		// Set cli.ConfigFile().Filename to a tempfile to support Save.
-		configfile: configfile.New(""),
+		configfile:     configfile.New(""),
+		currentContext: command.DefaultContextName,
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -183,3 +188,33 @@ func (c *FakeCli) NewContainerizedEngineClient(sockPath string) (clitypes.Contai
 func (c *FakeCli) SetContainerizedEngineClient(containerizedEngineClientFunc containerizedEngineFuncType) {
 	c.containerizedEngineClientFunc = containerizedEngineClientFunc
 }
+
+// ContextStore returns the context store set with SetContextStore
+func (c *FakeCli) ContextStore() contextstore.Store {
+	return c.contextStore
+}
+
+// SetContextStore on the fake cli
+func (c *FakeCli) SetContextStore(store contextstore.Store) {
+	c.contextStore = store
+}
+
+// CurrentContext returns the name of the current context of the fake cli
+func (c *FakeCli) CurrentContext() string {
+	return c.currentContext
+}
+
+// SetCurrentContext on the fake cli
+func (c *FakeCli) SetCurrentContext(name string) {
+	c.currentContext = name
+}
+
+// HostClients returns the clients set with SetHostClients
+func (c *FakeCli) HostClients() []command.HostClient {
+	return c.hostClients
+}
+
+// SetHostClients on the fake cli, as if the --hosts flag was set
+func (c *FakeCli) SetHostClients(hostClients []command.HostClient) {
+	c.hostClients = hostClients
+}
@@ -341,12 +341,20 @@ func (o *FilterOpt) Set(value string) error {
 	if value == "" {
 		return nil
 	}
-	if !strings.Contains(value, "=") {
+	var name string
+	switch {
+	case strings.Contains(value, "="):
+		f := strings.SplitN(value, "=", 2)
+		name, value = f[0], f[1]
+	case strings.Contains(value, ">"):
+		// a comparison such as "size>1GB" is sent as a "size>" filter
+		f := strings.SplitN(value, ">", 2)
+		name, value = f[0]+">", f[1]
+	default:
 		return errors.New("bad format of filter (expected name=value)")
 	}
-	f := strings.SplitN(value, "=", 2)
-	name := strings.ToLower(strings.TrimSpace(f[0]))
-	value = strings.TrimSpace(f[1])
+	name = strings.ToLower(strings.TrimSpace(name))
+	value = strings.TrimSpace(value)
 
 	o.filter.Add(name, value)
 	return nil
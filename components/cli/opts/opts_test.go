@@ -306,3 +306,25 @@ func TestParseLink(t *testing.T) {
 		t.Fatalf("Expected error 'bad format for links: link:alias:wrong' but got: %v", err)
 	}
 }
+
+func TestFilterOptSet(t *testing.T) {
+	o := NewFilterOpt()
+	for _, value := range []string{"label=foo=bar", " Size>1GB ", "size>=2GB", "unused-for=720h"} {
+		if err := o.Set(value); err != nil {
+			t.Fatalf("Expected %q to be a valid filter but got: %v", value, err)
+		}
+	}
+	f := o.Value()
+	if !f.ExactMatch("label", "foo=bar") {
+		t.Fatalf("Expected label filter foo=bar, got %v", f.Get("label"))
+	}
+	if sizes := f.Get("size>"); len(sizes) != 2 || !f.ExactMatch("size>", "1GB") || !f.ExactMatch("size>", "2GB") {
+		t.Fatalf("Expected size> filters 1GB and 2GB, got %v", sizes)
+	}
+	if !f.ExactMatch("unused-for", "720h") {
+		t.Fatalf("Expected unused-for filter 720h, got %v", f.Get("unused-for"))
+	}
+	if err := o.Set("dangling"); err == nil || !strings.Contains(err.Error(), "bad format of filter") {
+		t.Fatalf("Expected error 'bad format of filter' but got: %v", err)
+	}
+}
@@ -1,10 +1,16 @@
 package checkpoint // import "github.com/docker/docker/api/server/router/checkpoint"
 
-import "github.com/docker/docker/api/types"
+import (
+	"io"
+
+	"github.com/docker/docker/api/types"
+)
 
 // Backend for Checkpoint
 type Backend interface {
 	CheckpointCreate(container string, config types.CheckpointCreateOptions) error
 	CheckpointDelete(container string, config types.CheckpointDeleteOptions) error
 	CheckpointList(container string, config types.CheckpointListOptions) ([]types.Checkpoint, error)
+	CheckpointExport(container string, config types.CheckpointExportOptions, out io.Writer) error
+	CheckpointImport(container string, config types.CheckpointImportOptions, in io.Reader) error
 }
@@ -8,12 +8,14 @@ import (
 	"net/http"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/docker/docker/api/server/httputils"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	timetypes "github.com/docker/docker/api/types/time"
 	"github.com/docker/docker/api/types/versions"
 	containerpkg "github.com/docker/docker/container"
 	"github.com/docker/docker/errdefs"
@@ -102,19 +104,46 @@ func (s *containerRouter) getContainersStats(ctx context.Context, w http.Respons
 	}
 
 	stream := httputils.BoolValueOrDefault(r, "stream", true)
-	if !stream {
-		w.Header().Set("Content-Type", "application/json")
-	}
-
 	config := &backend.ContainerStatsConfig{
 		Stream:    stream,
 		OutStream: w,
 		Version:   httputils.VersionFromContext(ctx),
 	}
 
+	if versions.GreaterThanOrEqualTo(config.Version, "1.40") {
+		var err error
+		if config.Since, err = statsTime(r.Form.Get("since")); err != nil {
+			return errdefs.InvalidParameter(errors.Wrap(err, "invalid since"))
+		}
+		if config.Until, err = statsTime(r.Form.Get("until")); err != nil {
+			return errdefs.InvalidParameter(errors.Wrap(err, "invalid until"))
+		}
+		if !config.Since.IsZero() || !config.Until.IsZero() {
+			// the history of the stats is not streamed
+			config.Stream = false
+		}
+	}
+
+	if !config.Stream {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
 	return s.backend.ContainerStats(ctx, vars["name"], config)
 }
 
+// statsTime returns the time of a timestamp of the since and until
+// parameters of the stats, or a zero time if it is not set.
+func statsTime(formTime string) (time.Time, error) {
+	if formTime == "" {
+		return time.Time{}, nil
+	}
+	t, tNano, err := timetypes.ParseTimestamps(formTime, 0)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(t, tNano), nil
+}
+
 func (s *containerRouter) getContainersLogs(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -140,6 +169,8 @@ func (s *containerRouter) getContainersLogs(ctx context.Context, w http.Response
 		ShowStdout: stdout,
 		ShowStderr: stderr,
 		Details:    httputils.BoolValue(r, "details"),
+		Grep:       r.Form.Get("grep"),
+		Exclude:    r.Form.Get("exclude"),
 	}
 
 	msgs, tty, err := s.backend.ContainerLogs(ctx, containerName, logsConfig)
@@ -200,7 +231,7 @@ func (s *containerRouter) postContainersStart(ctx context.Context, w http.Respon
 
 	checkpoint := r.Form.Get("checkpoint")
 	checkpointDir := r.Form.Get("checkpoint-dir")
-	if err := s.backend.ContainerStart(vars["name"], hostConfig, checkpoint, checkpointDir); err != nil {
+	if err := s.backend.ContainerStart(ctx, vars["name"], hostConfig, checkpoint, checkpointDir); err != nil {
 		return err
 	}
 
@@ -429,6 +460,10 @@ func (s *containerRouter) postContainerUpdate(ctx context.Context, w http.Respon
 		return err
 	}
 
+	if versions.LessThan(httputils.VersionFromContext(ctx), "1.40") {
+		resetRestartBackoff(&updateConfig.RestartPolicy)
+	}
+
 	hostConfig := &container.HostConfig{
 		Resources:     updateConfig.Resources,
 		RestartPolicy: updateConfig.RestartPolicy,
@@ -465,6 +500,10 @@ func (s *containerRouter) postContainersCreate(ctx context.Context, w http.Respo
 		hostConfig.AutoRemove = false
 	}
 
+	if hostConfig != nil && versions.LessThan(version, "1.40") {
+		resetRestartBackoff(&hostConfig.RestartPolicy)
+	}
+
 	ccr, err := s.backend.ContainerCreate(types.ContainerCreateConfig{
 		Name:             name,
 		Config:           config,
@@ -479,6 +518,15 @@ func (s *containerRouter) postContainersCreate(ctx context.Context, w http.Respo
 	return httputils.WriteJSON(w, http.StatusCreated, ccr)
 }
 
+// resetRestartBackoff clears the fields of the restart policy that were
+// added in API 1.40, so that they are ignored for older clients.
+func resetRestartBackoff(p *container.RestartPolicy) {
+	p.InitialDelay = 0
+	p.MaxDelay = 0
+	p.Multiplier = 0
+	p.FailureWindow = 0
+}
+
 func (s *containerRouter) deleteContainers(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
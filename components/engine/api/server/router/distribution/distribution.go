@@ -17,6 +17,19 @@ func NewRouter(backend Backend) router.Router {
 	return r
 }
 
+// NewBlobRouter initializes a router serving only the layers pulled by the
+// daemon, to share them with peer daemons. Its routes are not part of the
+// Engine API, and are only served on the blob sharing listener.
+func NewBlobRouter(backend Backend) router.Router {
+	r := &distributionRouter{
+		backend: backend,
+	}
+	r.routes = []router.Route{
+		router.NewGetRoute("/distribution/blobs/{digest}", r.getBlob),
+	}
+	return r
+}
+
 // Routes returns the available routes
 func (r *distributionRouter) Routes() []router.Route {
 	return r.routes
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"io"
 	"net/http"
 	"strings"
 
@@ -14,8 +15,12 @@ import (
 	"github.com/docker/docker/api/server/httputils"
 	"github.com/docker/docker/api/types"
 	registrytypes "github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/opencontainers/go-digest"
 	"github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 func (s *distributionRouter) getDistributionInfo(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
@@ -136,3 +141,28 @@ func (s *distributionRouter) getDistributionInfo(ctx context.Context, w http.Res
 
 	return httputils.WriteJSON(w, http.StatusOK, distributionInspect)
 }
+
+func (s *distributionRouter) getBlob(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	dgst, err := digest.Parse(vars["digest"])
+	if err != nil {
+		return errdefs.InvalidParameter(err)
+	}
+	rc, diffID, err := s.backend.GetLayerBlob(ctx, dgst)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Docker-Diff-Id", diffID.String())
+
+	output := ioutils.NewWriteFlusher(w)
+	defer output.Close()
+	if _, err := io.Copy(output, rc); err != nil {
+		if !output.Flushed() {
+			return err
+		}
+		logrus.WithError(err).WithField("digest", dgst).Error("Error serving layer blob")
+	}
+	return nil
+}
@@ -7,12 +7,14 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/containerd/containerd/platforms"
 	"github.com/docker/docker/api/server/httputils"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/streamformatter"
@@ -76,6 +78,10 @@ func (s *imageRouter) postImagesCreate(ctx context.Context, w http.ResponseWrite
 					authConfig = &types.AuthConfig{}
 				}
 			}
+			ctx, err = withRateLimit(ctx, r)
+			if err != nil {
+				return err
+			}
 			err = s.backend.PullImage(ctx, image, tag, platform, metaHeaders, authConfig, output)
 		} else { //import
 			src := r.Form.Get("fromSrc")
@@ -99,6 +105,20 @@ func (s *imageRouter) postImagesCreate(ctx context.Context, w http.ResponseWrite
 	return nil
 }
 
+// withRateLimit returns a context limiting the throughput of a pull or a
+// push to the number of bytes per second of the limit-rate parameter.
+func withRateLimit(ctx context.Context, r *http.Request) (context.Context, error) {
+	limitRate := r.Form.Get("limit-rate")
+	if limitRate == "" || versions.LessThan(httputils.VersionFromContext(ctx), "1.40") {
+		return ctx, nil
+	}
+	limit, err := strconv.ParseInt(limitRate, 10, 64)
+	if err != nil || limit < 0 {
+		return nil, errdefs.InvalidParameter(errors.Errorf("invalid limit-rate: %q", limitRate))
+	}
+	return xfer.WithRateLimit(ctx, limit), nil
+}
+
 func (s *imageRouter) postImagesPush(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	metaHeaders := map[string][]string{}
 	for k, v := range r.Header {
@@ -129,6 +149,11 @@ func (s *imageRouter) postImagesPush(ctx context.Context, w http.ResponseWriter,
 	image := vars["name"]
 	tag := r.Form.Get("tag")
 
+	ctx, err := withRateLimit(ctx, r)
+	if err != nil {
+		return err
+	}
+
 	output := ioutils.NewWriteFlusher(w)
 	defer output.Close()
 
@@ -219,6 +244,9 @@ func (s *imageRouter) getImagesByName(ctx context.Context, w http.ResponseWriter
 	if err != nil {
 		return err
 	}
+	if versions.LessThan(httputils.VersionFromContext(ctx), "1.40") {
+		imageInspect.Metadata.LastUsedTime = time.Time{}
+	}
 
 	return httputils.WriteJSON(w, http.StatusOK, imageInspect)
 }
@@ -243,6 +271,11 @@ func (s *imageRouter) getImagesJSON(ctx context.Context, w http.ResponseWriter,
 	if err != nil {
 		return err
 	}
+	if versions.LessThan(httputils.VersionFromContext(ctx), "1.40") {
+		for _, img := range images {
+			img.LastUsed = 0
+		}
+	}
 
 	return httputils.WriteJSON(w, http.StatusOK, images)
 }
@@ -34,6 +34,8 @@ func (sr *swarmRouter) swarmLogs(ctx context.Context, w io.Writer, r *http.Reque
 		ShowStdout: stdout,
 		ShowStderr: stderr,
 		Details:    httputils.BoolValue(r, "details"),
+		Grep:       r.Form.Get("grep"),
+		Exclude:    r.Form.Get("exclude"),
 	}
 
 	tty := false
@@ -16,7 +16,9 @@ type Backend interface {
 	SystemInfo() (*types.Info, error)
 	SystemVersion() types.Version
 	SystemDiskUsage(ctx context.Context) (*types.DiskUsage, error)
+	LayersDiskUsage(ctx context.Context) ([]*types.LayerUsage, []*types.ImageReclaimableSpace, error)
 	SubscribeToEvents(since, until time.Time, ef filters.Args) ([]events.Message, chan interface{})
+	EventsHistory(since, until time.Time, after string, ef filters.Args, limit int) ([]events.Message, error)
 	UnsubscribeFromEvents(chan interface{})
 	AuthenticateToRegistry(ctx context.Context, authConfig *types.AuthConfig) (string, string, error)
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/docker/docker/api/server/httputils"
@@ -83,6 +84,11 @@ func (s *systemRouter) getVersion(ctx context.Context, w http.ResponseWriter, r
 }
 
 func (s *systemRouter) getDiskUsage(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+	withLayers := httputils.BoolValue(r, "layers") && !versions.LessThan(httputils.VersionFromContext(ctx), "1.40")
+
 	eg, ctx := errgroup.WithContext(ctx)
 
 	var du *types.DiskUsage
@@ -112,6 +118,18 @@ func (s *systemRouter) getDiskUsage(ctx context.Context, w http.ResponseWriter,
 		return nil
 	})
 
+	var (
+		layers            []*types.LayerUsage
+		imagesReclaimable []*types.ImageReclaimableSpace
+	)
+	if withLayers {
+		eg.Go(func() error {
+			var err error
+			layers, imagesReclaimable, err = s.backend.LayersDiskUsage(ctx)
+			return err
+		})
+	}
+
 	if err := eg.Wait(); err != nil {
 		return err
 	}
@@ -122,6 +140,8 @@ func (s *systemRouter) getDiskUsage(ctx context.Context, w http.ResponseWriter,
 
 	du.BuilderSize = builderSize
 	du.BuildCache = buildCache
+	du.Layers = layers
+	du.ImagesReclaimable = imagesReclaimable
 
 	return httputils.WriteJSON(w, http.StatusOK, du)
 }
@@ -174,6 +194,40 @@ func (s *systemRouter) getEvents(ctx context.Context, w http.ResponseWriter, r *
 		return err
 	}
 
+	var (
+		limit int
+		after string
+	)
+	if !versions.LessThan(httputils.VersionFromContext(ctx), "1.40") {
+		if l := r.Form.Get("limit"); l != "" {
+			limit, err = strconv.Atoi(l)
+			if err != nil || limit < 0 {
+				return invalidRequestError{fmt.Errorf("invalid limit %q: must be a positive integer", l)}
+			}
+		}
+		after = r.Form.Get("after")
+	}
+
+	if limit > 0 {
+		// A limit requests a single page of past events; new events are not
+		// streamed.
+		history, err := s.backend.EventsHistory(since, until, after, ef, limit)
+		if err != nil {
+			return err
+		}
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		for _, ev := range history {
+			if err := enc.Encode(ev); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if after != "" {
+		return invalidRequestError{fmt.Errorf("the after parameter requires a limit")}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	output := ioutils.NewWriteFlusher(w)
 	defer output.Close()
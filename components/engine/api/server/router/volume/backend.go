@@ -2,6 +2,7 @@ package volume // import "github.com/docker/docker/api/server/router/volume"
 
 import (
 	"context"
+	"io"
 
 	"github.com/docker/docker/volume/service/opts"
 	// TODO return types need to be refactored into pkg
@@ -17,4 +18,7 @@ type Backend interface {
 	Create(ctx context.Context, name, driverName string, opts ...opts.CreateOption) (*types.Volume, error)
 	Remove(ctx context.Context, name string, opts ...opts.RemoveOption) error
 	Prune(ctx context.Context, pruneFilters filters.Args) (*types.VolumesPruneReport, error)
+	Clone(ctx context.Context, source, name string, opts ...opts.CreateOption) (*types.Volume, error)
+	Export(ctx context.Context, name string, out io.Writer) error
+	Import(ctx context.Context, name string, in io.Reader) error
 }
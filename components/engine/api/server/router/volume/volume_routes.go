@@ -8,6 +8,7 @@ import (
 
 	"github.com/docker/docker/api/server/httputils"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/versions"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/volume/service/opts"
@@ -27,6 +28,11 @@ func (v *volumeRouter) getVolumesList(ctx context.Context, w http.ResponseWriter
 	if err != nil {
 		return err
 	}
+	if versions.LessThan(httputils.VersionFromContext(ctx), "1.40") {
+		for _, volume := range volumes {
+			volume.UsageData = nil
+		}
+	}
 	return httputils.WriteJSON(w, http.StatusOK, &volumetypes.VolumeListOKBody{Volumes: volumes, Warnings: warnings})
 }
 
@@ -35,10 +41,18 @@ func (v *volumeRouter) getVolumeByName(ctx context.Context, w http.ResponseWrite
 		return err
 	}
 
-	volume, err := v.backend.Get(ctx, vars["name"], opts.WithGetResolveStatus)
+	getOpts := []opts.GetOption{opts.WithGetResolveStatus}
+	legacy := versions.LessThan(httputils.VersionFromContext(ctx), "1.40")
+	if !legacy && httputils.BoolValue(r, "size") {
+		getOpts = append(getOpts, opts.WithGetSize)
+	}
+	volume, err := v.backend.Get(ctx, vars["name"], getOpts...)
 	if err != nil {
 		return err
 	}
+	if legacy {
+		volume.UsageData = nil
+	}
 	return httputils.WriteJSON(w, http.StatusOK, volume)
 }
 
@@ -66,6 +80,47 @@ func (v *volumeRouter) postVolumesCreate(ctx context.Context, w http.ResponseWri
 	return httputils.WriteJSON(w, http.StatusCreated, volume)
 }
 
+func (v *volumeRouter) postVolumeClone(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var req volumetypes.VolumeCloneBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if err == io.EOF {
+			return errdefs.InvalidParameter(errors.New("got EOF while reading request body"))
+		}
+		return errdefs.InvalidParameter(err)
+	}
+
+	var cloneOpts []opts.CreateOption
+	if req.Labels != nil {
+		cloneOpts = append(cloneOpts, opts.WithCreateLabels(req.Labels))
+	}
+	volume, err := v.backend.Clone(ctx, vars["name"], req.Name, cloneOpts...)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusCreated, volume)
+}
+
+func (v *volumeRouter) getVolumeExport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	w.Header().Set("Content-Type", "application/x-tar")
+	return v.backend.Export(ctx, vars["name"], w)
+}
+
+func (v *volumeRouter) postVolumeImport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := v.backend.Import(ctx, vars["name"], r.Body); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
 func (v *volumeRouter) deleteVolumes(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
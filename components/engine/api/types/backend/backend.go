@@ -47,6 +47,10 @@ type LogMessage struct {
 	Attrs        []LogAttr
 	PLogMetaData *PartialLogMetaData
 
+	// Fields holds the structured fields parsed from Line, if the log
+	// driver is configured to parse the logs of the container.
+	Fields map[string]string
+
 	// Err is an error associated with a message. Completeness of a message
 	// with Err is not expected, tho it may be partially complete (fields may
 	// be missing, gibberish, or nil)
@@ -75,6 +79,10 @@ type ContainerStatsConfig struct {
 	Stream    bool
 	OutStream io.Writer
 	Version   string
+	// Since and Until select the stats of the history of the container to
+	// return instead of the live stats, if either of them is not zero.
+	Since time.Time
+	Until time.Time
 }
 
 // ExecInspect holds information about a running process started
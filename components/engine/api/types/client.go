@@ -28,6 +28,18 @@ type CheckpointDeleteOptions struct {
 	CheckpointDir string
 }
 
+// CheckpointExportOptions holds parameters to export a checkpoint of a container
+type CheckpointExportOptions struct {
+	CheckpointID  string
+	CheckpointDir string
+}
+
+// CheckpointImportOptions holds parameters to import a checkpoint in a container
+type CheckpointImportOptions struct {
+	CheckpointID  string
+	CheckpointDir string
+}
+
 // ContainerAttachOptions holds parameters to attach to a container.
 type ContainerAttachOptions struct {
 	Stream     bool
@@ -69,6 +81,16 @@ type ContainerListOptions struct {
 	Filters filters.Args
 }
 
+// ContainerStatsOptions holds parameters to get the stats of a container.
+type ContainerStatsOptions struct {
+	Stream bool
+	// Since and Until, if either of them is set, return the stats of the
+	// history kept by the daemon read in this time range instead of the
+	// live stats.
+	Since string
+	Until string
+}
+
 // ContainerLogsOptions holds parameters to filter logs with.
 type ContainerLogsOptions struct {
 	ShowStdout bool
@@ -79,6 +101,11 @@ type ContainerLogsOptions struct {
 	Follow     bool
 	Tail       string
 	Details    bool
+
+	// Grep, if set, only returns the lines matching this regular expression.
+	Grep string
+	// Exclude, if set, skips the lines matching this regular expression.
+	Exclude string
 }
 
 // ContainerRemoveOptions holds parameters to remove containers.
@@ -106,6 +133,12 @@ type EventsOptions struct {
 	Since   string
 	Until   string
 	Filters filters.Args
+	// Limit is the maximum number of past events to return. If set,
+	// no new events are streamed.
+	Limit int
+	// After is the cursor of an event; only the events after it are
+	// returned. It requires Limit to be set.
+	After string
 }
 
 // NetworkListOptions holds parameters to filter the list of networks with.
@@ -246,6 +279,9 @@ type ImagePullOptions struct {
 	RegistryAuth  string // RegistryAuth is the base64 encoded credentials for the registry
 	PrivilegeFunc RequestPrivilegeFunc
 	Platform      string
+	// LimitRate is the maximum number of bytes per second transferred by
+	// the operation. The throughput is not limited if it is zero.
+	LimitRate int64
 }
 
 // RequestPrivilegeFunc is a function interface that
@@ -2,6 +2,7 @@ package container // import "github.com/docker/docker/api/types/container"
 
 import (
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types/blkiodev"
 	"github.com/docker/docker/api/types/mount"
@@ -255,6 +256,16 @@ type DeviceMapping struct {
 type RestartPolicy struct {
 	Name              string
 	MaximumRetryCount int
+
+	// The delays below are expressed as integer nanoseconds. Zero means
+	// to use the default value.
+	InitialDelay time.Duration `json:",omitempty"` // InitialDelay is the delay before the first restart (default 100ms).
+	MaxDelay     time.Duration `json:",omitempty"` // MaxDelay is the maximum delay between two restarts (default 1m).
+	Multiplier   float64       `json:",omitempty"` // Multiplier is the factor applied to the delay after each restart (default 2).
+
+	// FailureWindow, if set, only counts the failures that happened within
+	// this period of time towards MaximumRetryCount.
+	FailureWindow time.Duration `json:",omitempty"`
 }
 
 // IsNone indicates whether the container has the "no" restart policy.
@@ -284,7 +295,27 @@ func (rp *RestartPolicy) IsUnlessStopped() bool {
 
 // IsSame compares two RestartPolicy to see if they are the same
 func (rp *RestartPolicy) IsSame(tp *RestartPolicy) bool {
-	return rp.Name == tp.Name && rp.MaximumRetryCount == tp.MaximumRetryCount
+	return rp.Name == tp.Name && rp.MaximumRetryCount == tp.MaximumRetryCount &&
+		rp.InitialDelay == tp.InitialDelay && rp.MaxDelay == tp.MaxDelay &&
+		rp.Multiplier == tp.Multiplier && rp.FailureWindow == tp.FailureWindow
+}
+
+// DependencyCondition is the condition a dependency has to meet before
+// the container depending on it is started.
+type DependencyCondition string
+
+// Available dependency conditions
+const (
+	DependencyConditionUnset   DependencyCondition = ""
+	DependencyConditionStarted DependencyCondition = "started"
+	DependencyConditionHealthy DependencyCondition = "healthy"
+)
+
+// Dependency represents a container that has to be started before the
+// container that depends on it.
+type Dependency struct {
+	Container string              // Name or ID of the container
+	Condition DependencyCondition `json:",omitempty"` // Condition to wait for (default "started")
 }
 
 // LogMode is a type to define the available modes for logging
@@ -365,6 +396,7 @@ type HostConfig struct {
 	AutoRemove      bool          // Automatically remove container when it exits
 	VolumeDriver    string        // Name of the volume driver used to mount volumes
 	VolumesFrom     []string      // List of volumes to take from other container
+	DependsOn       []Dependency  `json:",omitempty"` // List of containers to start before this container
 
 	// Applicable to UNIX platforms
 	CapAdd          strslice.StrSlice // List of kernel capabilities to add to the container
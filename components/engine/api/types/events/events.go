@@ -49,4 +49,8 @@ type Message struct {
 
 	Time     int64 `json:"time,omitempty"`
 	TimeNano int64 `json:"timeNano,omitempty"`
+
+	// Cursor is the position of the event in the history of events of the
+	// daemon, which can be used to page through past events.
+	Cursor string `json:"cursor,omitempty"`
 }
@@ -50,7 +50,8 @@ type ImageInspect struct {
 
 // ImageMetadata contains engine-local data about the image
 type ImageMetadata struct {
-	LastTagTime time.Time `json:",omitempty"`
+	LastTagTime  time.Time `json:",omitempty"`
+	LastUsedTime time.Time `json:",omitempty"`
 }
 
 // Container contains response of Engine API:
@@ -311,6 +312,10 @@ type ContainerState struct {
 	StartedAt  string
 	FinishedAt string
 	Health     *Health `json:",omitempty"`
+
+	// NextRestartAt is the time at which a restarting container is
+	// scheduled to be started again.
+	NextRestartAt string `json:",omitempty"`
 }
 
 // ContainerNode stores information about the node that a container
@@ -511,12 +516,38 @@ type Runtime struct {
 // DiskUsage contains response of Engine API:
 // GET "/system/df"
 type DiskUsage struct {
-	LayersSize  int64
-	Images      []*ImageSummary
-	Containers  []*Container
-	Volumes     []*Volume
-	BuildCache  []*BuildCache
-	BuilderSize int64 // deprecated
+	LayersSize        int64
+	Images            []*ImageSummary
+	Containers        []*Container
+	Volumes           []*Volume
+	BuildCache        []*BuildCache
+	BuilderSize       int64                    // deprecated
+	Layers            []*LayerUsage            `json:",omitempty"`
+	ImagesReclaimable []*ImageReclaimableSpace `json:",omitempty"`
+}
+
+// DiskUsageOptions holds parameters for the disk usage request.
+type DiskUsageOptions struct {
+	Layers bool
+}
+
+// LayerUsage contains the disk usage of an image layer, and the images and
+// containers referencing it.
+type LayerUsage struct {
+	ChainID    string
+	Parent     string `json:",omitempty"`
+	Size       int64
+	Images     []string
+	Containers []string
+}
+
+// ImageReclaimableSpace contains the size of the layers of an image, and the
+// disk space that removing the image would reclaim.
+type ImageReclaimableSpace struct {
+	ID          string
+	RepoTags    []string
+	Size        int64
+	Reclaimable int64
 }
 
 // ContainersPruneReport contains the response for Engine API:
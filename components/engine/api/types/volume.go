@@ -47,12 +47,17 @@ type Volume struct {
 	UsageData *VolumeUsageData `json:"UsageData,omitempty"`
 }
 
-// VolumeUsageData Usage details about the volume. This information is used by the
-// `GET /system/df` endpoint, and omitted in other endpoints.
+// VolumeUsageData Usage details about the volume.
 //
 // swagger:model VolumeUsageData
 type VolumeUsageData struct {
 
+	// Date/Time the volume was last referenced, mounted or unmounted by a
+	// container. This field is set to the date/time the volume was
+	// created if the volume was never used.
+	//
+	LastUsedAt string `json:"LastUsedAt,omitempty"`
+
 	// The number of containers referencing this volume. This field
 	// is set to `-1` if the reference-count is not available.
 	//
@@ -64,6 +69,10 @@ type VolumeUsageData struct {
 	// driver. For volumes created with other volume drivers, this field
 	// is set to `-1` ("not available")
 	//
+	// The `GET /system/df` endpoint computes the size of the volumes. Other
+	// endpoints return the size computed the last time it was requested,
+	// or `-1` if it was never computed.
+	//
 	// Required: true
 	Size int64 `json:"Size"`
 }
@@ -0,0 +1,21 @@
+package volume
+
+// ----------------------------------------------------------------------------
+// DO NOT EDIT THIS FILE
+// This file was generated by `swagger generate operation`
+//
+// See hack/generate-swagger-api.sh
+// ----------------------------------------------------------------------------
+
+// VolumeCloneBody Volume clone configuration
+// swagger:model VolumeCloneBody
+type VolumeCloneBody struct {
+
+	// User-defined key/value metadata. If not specified, the labels of the source volume are used.
+	// Required: true
+	Labels map[string]string `json:"Labels"`
+
+	// The new volume's name. If not specified, Docker generates a name.
+	// Required: true
+	Name string `json:"Name"`
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/opencontainers/image-spec/identity"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/time/rate"
 )
 
@@ -126,6 +127,10 @@ func (is *imageSource) resolveLocal(refStr string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	// the local image is used as a base image of the build
+	if err := is.ImageStore.SetLastUsed(img.ID()); err != nil {
+		logrus.WithError(err).WithField("image", img.ID().String()).Warn("Error recording last use of image")
+	}
 	return img.RawJSON(), nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/docker/docker/api/types"
@@ -15,6 +16,7 @@ import (
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/go-connections/nat"
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
 	"github.com/moby/buildkit/frontend/dockerfile/shell"
 	"gotest.tools/assert"
 	is "gotest.tools/assert/cmp"
@@ -297,6 +299,32 @@ func TestHealthcheckCmd(t *testing.T) {
 	assert.Check(t, is.DeepEqual(expectedTest, sb.state.runConfig.Healthcheck.Test))
 }
 
+func TestHealthcheckProbes(t *testing.T) {
+	for line, expected := range map[string][]string{
+		"HEALTHCHECK HTTP http://localhost:8080/health": {"HTTP", "http://localhost:8080/health"},
+		`HEALTHCHECK tcp ["localhost:5432"]`:            {"TCP", "localhost:5432"},
+		`HEALTHCHECK CMD ["HTTP", "x"]`:                 {"CMD", "HTTP", "x"},
+	} {
+		node, err := parser.Parse(strings.NewReader(line))
+		assert.NilError(t, err)
+		cmd, err := instructions.ParseCommand(node.AST.Children[0])
+		assert.NilError(t, err, line)
+
+		b := newBuilderWithMockBackend()
+		sb := newDispatchRequest(b, '`', nil, NewBuildArgs(make(map[string]*string)), newStagesBuildResults())
+		assert.NilError(t, dispatch(sb, cmd))
+		assert.Assert(t, sb.state.runConfig.Healthcheck != nil)
+		assert.Check(t, is.DeepEqual(expected, []string(sb.state.runConfig.Healthcheck.Test)), line)
+	}
+
+	for _, line := range []string{"HEALTHCHECK HTTP", "HEALTHCHECK HTTP http://a/ http://b/", `HEALTHCHECK TCP ["a:1", "b:2"]`} {
+		node, err := parser.Parse(strings.NewReader(line))
+		assert.NilError(t, err)
+		_, err = instructions.ParseCommand(node.AST.Children[0])
+		assert.Check(t, is.ErrorContains(err, "requires exactly one argument"), line)
+	}
+}
+
 func TestEntrypoint(t *testing.T) {
 	b := newBuilderWithMockBackend()
 	sb := newDispatchRequest(b, '`', nil, NewBuildArgs(make(map[string]*string)), newStagesBuildResults())
@@ -0,0 +1,25 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+	"io"
+	"net/url"
+
+	"github.com/docker/docker/api/types"
+)
+
+// CheckpointExport retrieves an archive of the given checkpoint and of the
+// filesystem changes of the container, and returns it as an io.ReadCloser.
+// It's up to the caller to close the stream.
+func (cli *Client) CheckpointExport(ctx context.Context, containerID string, options types.CheckpointExportOptions) (io.ReadCloser, error) {
+	query := url.Values{}
+	if options.CheckpointDir != "" {
+		query.Set("dir", options.CheckpointDir)
+	}
+
+	resp, err := cli.get(ctx, "/containers/"+containerID+"/checkpoints/"+options.CheckpointID+"/export", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.body, nil
+}
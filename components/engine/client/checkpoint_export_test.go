@@ -0,0 +1,64 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestCheckpointExportError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.CheckpointExport(context.Background(), "container_id", types.CheckpointExportOptions{
+		CheckpointID: "checkpoint_id",
+	})
+
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestCheckpointExport(t *testing.T) {
+	expectedURL := "/containers/container_id/checkpoints/checkpoint_id/export"
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "GET" {
+				return nil, fmt.Errorf("expected GET method, got %s", req.Method)
+			}
+			if dir := req.URL.Query().Get("dir"); dir != "/tmp/checkpoints" {
+				return nil, fmt.Errorf("dir not set in URL query properly. Expected '/tmp/checkpoints', got %s", dir)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("response"))),
+			}, nil
+		}),
+	}
+
+	body, err := client.CheckpointExport(context.Background(), "container_id", types.CheckpointExportOptions{
+		CheckpointID:  "checkpoint_id",
+		CheckpointDir: "/tmp/checkpoints",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "response" {
+		t.Fatalf("expected response to contain 'response', got %s", string(content))
+	}
+}
@@ -0,0 +1,23 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+	"io"
+	"net/url"
+
+	"github.com/docker/docker/api/types"
+)
+
+// CheckpointImport imports a checkpoint archive, created by CheckpointExport,
+// in the given container under the given checkpoint name.
+func (cli *Client) CheckpointImport(ctx context.Context, containerID string, options types.CheckpointImportOptions, input io.Reader) error {
+	query := url.Values{}
+	if options.CheckpointDir != "" {
+		query.Set("dir", options.CheckpointDir)
+	}
+
+	headers := map[string][]string{"Content-Type": {"application/x-tar"}}
+	resp, err := cli.postRaw(ctx, "/containers/"+containerID+"/checkpoints/"+options.CheckpointID+"/import", query, input, headers)
+	ensureReaderClosed(resp)
+	return err
+}
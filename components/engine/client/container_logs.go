@@ -72,6 +72,14 @@ func (cli *Client) ContainerLogs(ctx context.Context, container string, options
 	}
 	query.Set("tail", options.Tail)
 
+	if options.Grep != "" {
+		query.Set("grep", options.Grep)
+	}
+
+	if options.Exclude != "" {
+		query.Set("exclude", options.Exclude)
+	}
+
 	resp, err := cli.get(ctx, "/containers/"+container+"/logs", query, nil)
 	if err != nil {
 		return nil, wrapResponseError(err, resp, "container", container)
@@ -3,19 +3,51 @@ package client // import "github.com/docker/docker/client"
 import (
 	"context"
 	"net/url"
+	"time"
 
 	"github.com/docker/docker/api/types"
+	timetypes "github.com/docker/docker/api/types/time"
+	"github.com/pkg/errors"
 )
 
 // ContainerStats returns near realtime stats for a given container.
 // It's up to the caller to close the io.ReadCloser returned.
 func (cli *Client) ContainerStats(ctx context.Context, containerID string, stream bool) (types.ContainerStats, error) {
+	return cli.ContainerStatsWithOptions(ctx, containerID, types.ContainerStatsOptions{Stream: stream})
+}
+
+// ContainerStatsWithOptions returns near realtime stats for a given
+// container, or the stats of its history selected by the options.
+// It's up to the caller to close the io.ReadCloser returned.
+func (cli *Client) ContainerStatsWithOptions(ctx context.Context, containerID string, options types.ContainerStatsOptions) (types.ContainerStats, error) {
 	query := url.Values{}
 	query.Set("stream", "0")
-	if stream {
+	if options.Stream {
 		query.Set("stream", "1")
 	}
 
+	if options.Since != "" || options.Until != "" {
+		if err := cli.NewVersionError("1.40", "stats history"); err != nil {
+			return types.ContainerStats{}, err
+		}
+	}
+
+	if options.Since != "" {
+		ts, err := timetypes.GetTimestamp(options.Since, time.Now())
+		if err != nil {
+			return types.ContainerStats{}, errors.Wrap(err, `invalid value for "since"`)
+		}
+		query.Set("since", ts)
+	}
+
+	if options.Until != "" {
+		ts, err := timetypes.GetTimestamp(options.Until, time.Now())
+		if err != nil {
+			return types.ContainerStats{}, errors.Wrap(err, `invalid value for "until"`)
+		}
+		query.Set("until", ts)
+	}
+
 	resp, err := cli.get(ctx, "/containers/"+containerID+"/stats", query, nil)
 	if err != nil {
 		return types.ContainerStats{}, err
@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/docker/docker/api/types"
 )
 
 func TestContainerStatsError(t *testing.T) {
@@ -67,3 +69,44 @@ func TestContainerStats(t *testing.T) {
 		}
 	}
 }
+
+func TestContainerStatsWithOptionsHistory(t *testing.T) {
+	expectedURL := "/containers/container_id/stats"
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(r.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			query := r.URL.Query()
+			if since := query.Get("since"); since != "1546300800.000000000" {
+				return nil, fmt.Errorf("since not set in URL query properly. Expected '1546300800.000000000', got %s", since)
+			}
+			if until := query.Get("until"); until == "" {
+				return nil, fmt.Errorf("until not set in URL query")
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("response"))),
+			}, nil
+		}),
+	}
+	resp, err := client.ContainerStatsWithOptions(context.Background(), "container_id", types.ContainerStatsOptions{
+		Since: "2019-01-01T00:00:00Z",
+		Until: "5m",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	_, err = client.ContainerStatsWithOptions(context.Background(), "container_id", types.ContainerStatsOptions{Since: "yesterday"})
+	if err == nil || !strings.Contains(err.Error(), `invalid value for "since"`) {
+		t.Fatalf("expected an invalid since error, got %v", err)
+	}
+
+	client.version = "1.39"
+	_, err = client.ContainerStatsWithOptions(context.Background(), "container_id", types.ContainerStatsOptions{Since: "10m"})
+	if err == nil || !strings.Contains(err.Error(), "stats history") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
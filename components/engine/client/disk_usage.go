@@ -4,15 +4,30 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/docker/docker/api/types"
 )
 
 // DiskUsage requests the current data usage from the daemon
 func (cli *Client) DiskUsage(ctx context.Context) (types.DiskUsage, error) {
+	return cli.DiskUsageWithOptions(ctx, types.DiskUsageOptions{})
+}
+
+// DiskUsageWithOptions requests the current data usage from the daemon, with
+// the details selected by the options.
+func (cli *Client) DiskUsageWithOptions(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error) {
 	var du types.DiskUsage
 
-	serverResp, err := cli.get(ctx, "/system/df", nil, nil)
+	query := url.Values{}
+	if options.Layers {
+		if err := cli.NewVersionError("1.40", "layers disk usage"); err != nil {
+			return du, err
+		}
+		query.Set("layers", "1")
+	}
+
+	serverResp, err := cli.get(ctx, "/system/df", query, nil)
 	if err != nil {
 		return du, err
 	}
@@ -53,3 +53,49 @@ func TestDiskUsage(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestDiskUsageWithLayers(t *testing.T) {
+	expectedURL := "/system/df"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if layers := req.URL.Query().Get("layers"); layers != "1" {
+				return nil, fmt.Errorf("layers not set in URL query properly. Expected '1', got %s", layers)
+			}
+
+			du := types.DiskUsage{
+				Layers: []*types.LayerUsage{{ChainID: "sha256:abc", Size: 10}},
+			}
+
+			b, err := json.Marshal(du)
+			if err != nil {
+				return nil, err
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+	du, err := client.DiskUsageWithOptions(context.Background(), types.DiskUsageOptions{Layers: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(du.Layers) != 1 || du.Layers[0].ChainID != "sha256:abc" {
+		t.Fatalf("expected layers to be returned, got %v", du.Layers)
+	}
+}
+
+func TestDiskUsageWithLayersVersionError(t *testing.T) {
+	client := &Client{
+		version: "1.39",
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.DiskUsageWithOptions(context.Background(), types.DiskUsageOptions{Layers: true})
+	if err == nil || !strings.Contains(err.Error(), "layers disk usage") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -97,5 +98,13 @@ func buildEventsQueryParams(cliVersion string, options types.EventsOptions) (url
 		query.Set("filters", filterJSON)
 	}
 
+	if options.Limit > 0 {
+		query.Set("limit", strconv.Itoa(options.Limit))
+	}
+
+	if options.After != "" {
+		query.Set("after", options.After)
+	}
+
 	return query, nil
 }
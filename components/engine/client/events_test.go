@@ -111,6 +111,32 @@ func TestEvents(t *testing.T) {
 				"3": true,
 			},
 		},
+		{
+			options: types.EventsOptions{
+				Limit: 2,
+				After: "1461943101000000000-42",
+			},
+			expectedQueryParams: map[string]string{
+				"limit": "2",
+				"after": "1461943101000000000-42",
+			},
+			events: []events.Message{
+				{
+					Type:   "container",
+					ID:     "1",
+					Action: "create",
+				},
+				{
+					Type:   "container",
+					ID:     "2",
+					Action: "die",
+				},
+			},
+			expectedEvents: map[string]bool{
+				"1": true,
+				"2": true,
+			},
+		},
 	}
 
 	for _, eventsCase := range eventsCases {
@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/docker/distribution/reference"
@@ -33,6 +34,12 @@ func (cli *Client) ImagePull(ctx context.Context, refStr string, options types.I
 	if options.Platform != "" {
 		query.Set("platform", strings.ToLower(options.Platform))
 	}
+	if options.LimitRate > 0 {
+		if err := cli.NewVersionError("1.40", "limit-rate"); err != nil {
+			return nil, err
+		}
+		query.Set("limit-rate", strconv.FormatInt(options.LimitRate, 10))
+	}
 
 	resp, err := cli.tryImageCreate(ctx, query, options.RegistryAuth)
 	if resp.statusCode == http.StatusUnauthorized && options.PrivilegeFunc != nil {
@@ -196,3 +196,28 @@ func TestImagePullWithoutErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestImagePullLimitRate(t *testing.T) {
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if limitRate := req.URL.Query().Get("limit-rate"); limitRate != "1048576" {
+				return nil, fmt.Errorf("limit-rate not set in URL query properly. Expected '1048576', got %s", limitRate)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("hello world"))),
+			}, nil
+		}),
+	}
+	resp, err := client.ImagePull(context.Background(), "myimage", types.ImagePullOptions{LimitRate: 1024 * 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Close()
+
+	client.version = "1.39"
+	_, err = client.ImagePull(context.Background(), "myimage", types.ImagePullOptions{LimitRate: 1024 * 1024})
+	if err == nil || !strings.Contains(err.Error(), "limit-rate") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
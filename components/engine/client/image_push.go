@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
@@ -34,6 +35,12 @@ func (cli *Client) ImagePush(ctx context.Context, image string, options types.Im
 
 	query := url.Values{}
 	query.Set("tag", tag)
+	if options.LimitRate > 0 {
+		if err := cli.NewVersionError("1.40", "limit-rate"); err != nil {
+			return nil, err
+		}
+		query.Set("limit-rate", strconv.FormatInt(options.LimitRate, 10))
+	}
 
 	resp, err := cli.tryImagePush(ctx, name, query, options.RegistryAuth)
 	if resp.statusCode == http.StatusUnauthorized && options.PrivilegeFunc != nil {
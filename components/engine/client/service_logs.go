@@ -44,6 +44,14 @@ func (cli *Client) ServiceLogs(ctx context.Context, serviceID string, options ty
 	}
 	query.Set("tail", options.Tail)
 
+	if options.Grep != "" {
+		query.Set("grep", options.Grep)
+	}
+
+	if options.Exclude != "" {
+		query.Set("exclude", options.Exclude)
+	}
+
 	resp, err := cli.get(ctx, "/services/"+serviceID+"/logs", query, nil)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,21 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	volumetypes "github.com/docker/docker/api/types/volume"
+)
+
+// VolumeClone creates a volume holding a copy of the data of the given volume.
+func (cli *Client) VolumeClone(ctx context.Context, volumeID string, options volumetypes.VolumeCloneBody) (types.Volume, error) {
+	var volume types.Volume
+	resp, err := cli.post(ctx, "/volumes/"+volumeID+"/clone", nil, options, nil)
+	if err != nil {
+		return volume, wrapResponseError(err, resp, "volume", volumeID)
+	}
+	err = json.NewDecoder(resp.body).Decode(&volume)
+	ensureReaderClosed(resp)
+	return volume, err
+}
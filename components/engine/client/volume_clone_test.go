@@ -0,0 +1,84 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	volumetypes "github.com/docker/docker/api/types/volume"
+)
+
+func TestVolumeCloneError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.VolumeClone(context.Background(), "volume_id", volumetypes.VolumeCloneBody{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestVolumeCloneNotFound(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusNotFound, "Server error")),
+	}
+
+	_, err := client.VolumeClone(context.Background(), "unknown", volumetypes.VolumeCloneBody{})
+	if err == nil || !IsErrNotFound(err) {
+		t.Fatalf("expected a NotFoundError error, got %v", err)
+	}
+}
+
+func TestVolumeClone(t *testing.T) {
+	expectedURL := "/volumes/volume_id/clone"
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			var body volumetypes.VolumeCloneBody
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			if body.Name != "myvolume" {
+				return nil, fmt.Errorf("expected name 'myvolume', got %s", body.Name)
+			}
+
+			content, err := json.Marshal(types.Volume{
+				Name:       body.Name,
+				Driver:     "local",
+				Mountpoint: "mountpoint",
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}),
+	}
+
+	volume, err := client.VolumeClone(context.Background(), "volume_id", volumetypes.VolumeCloneBody{
+		Name: "myvolume",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volume.Name != "myvolume" {
+		t.Fatalf("expected volume.Name to be 'myvolume', got %s", volume.Name)
+	}
+	if volume.Driver != "local" {
+		t.Fatalf("expected volume.Driver to be 'local', got %s", volume.Driver)
+	}
+}
@@ -0,0 +1,16 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+	"io"
+)
+
+// VolumeExport retrieves a tar archive of the data of the given volume, and
+// returns it as an io.ReadCloser. It's up to the caller to close the stream.
+func (cli *Client) VolumeExport(ctx context.Context, volumeID string) (io.ReadCloser, error) {
+	resp, err := cli.get(ctx, "/volumes/"+volumeID+"/export", nil, nil)
+	if err != nil {
+		return nil, wrapResponseError(err, resp, "volume", volumeID)
+	}
+	return resp.body, nil
+}
@@ -0,0 +1,15 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+	"io"
+)
+
+// VolumeImport extracts a tar archive, such as an archive created by
+// VolumeExport, in the given volume.
+func (cli *Client) VolumeImport(ctx context.Context, volumeID string, input io.Reader) error {
+	headers := map[string][]string{"Content-Type": {"application/x-tar"}}
+	resp, err := cli.postRaw(ctx, "/volumes/"+volumeID+"/import", nil, input, headers)
+	ensureReaderClosed(resp)
+	return wrapResponseError(err, resp, "volume", volumeID)
+}
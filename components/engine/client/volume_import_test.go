@@ -0,0 +1,57 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestVolumeImportError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	err := client.VolumeImport(context.Background(), "volume_id", strings.NewReader(""))
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestVolumeImport(t *testing.T) {
+	expectedURL := "/volumes/volume_id/import"
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			contentType := req.Header.Get("Content-Type")
+			if contentType != "application/x-tar" {
+				return nil, fmt.Errorf("Content-type not set in request header properly. Expected 'application/x-tar', got %s", contentType)
+			}
+			content, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			if string(content) != "volume archive" {
+				return nil, fmt.Errorf("expected the volume archive in the request body, got %s", string(content))
+			}
+			return &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+			}, nil
+		}),
+	}
+
+	err := client.VolumeImport(context.Background(), "volume_id", strings.NewReader("volume archive"))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
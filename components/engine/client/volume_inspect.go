@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"io/ioutil"
+	"net/url"
 
 	"github.com/docker/docker/api/types"
 )
@@ -17,12 +18,25 @@ func (cli *Client) VolumeInspect(ctx context.Context, volumeID string) (types.Vo
 
 // VolumeInspectWithRaw returns the information about a specific volume in the docker host and its raw representation
 func (cli *Client) VolumeInspectWithRaw(ctx context.Context, volumeID string) (types.Volume, []byte, error) {
+	return cli.volumeInspect(ctx, volumeID, nil)
+}
+
+// VolumeInspectWithSize returns the information about a specific volume in the docker host,
+// with the size of the volume computed by the daemon.
+func (cli *Client) VolumeInspectWithSize(ctx context.Context, volumeID string) (types.Volume, error) {
+	query := url.Values{}
+	query.Set("size", "1")
+	volume, _, err := cli.volumeInspect(ctx, volumeID, query)
+	return volume, err
+}
+
+func (cli *Client) volumeInspect(ctx context.Context, volumeID string, query url.Values) (types.Volume, []byte, error) {
 	if volumeID == "" {
 		return types.Volume{}, nil, objectNotFoundError{object: "volume", id: volumeID}
 	}
 
 	var volume types.Volume
-	resp, err := cli.get(ctx, "/volumes/"+volumeID, nil, nil)
+	resp, err := cli.get(ctx, "/volumes/"+volumeID, query, nil)
 	if err != nil {
 		return volume, nil, wrapResponseError(err, resp, "volume", volumeID)
 	}
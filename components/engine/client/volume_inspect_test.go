@@ -77,3 +77,35 @@ func TestVolumeInspect(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Check(t, is.DeepEqual(expected, volume))
 }
+
+func TestVolumeInspectWithSize(t *testing.T) {
+	expectedURL := "/volumes/volume_id"
+	expected := types.Volume{
+		Name:      "name",
+		Driver:    "driver",
+		UsageData: &types.VolumeUsageData{Size: 42, RefCount: 1},
+	}
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if size := req.URL.Query().Get("size"); size != "1" {
+				return nil, fmt.Errorf("size not set in URL query properly. Expected '1', got %s", size)
+			}
+			content, err := json.Marshal(expected)
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}),
+	}
+
+	volume, err := client.VolumeInspectWithSize(context.Background(), "volume_id")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(expected, volume))
+}
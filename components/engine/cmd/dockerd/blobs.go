@@ -0,0 +1,57 @@
+package main
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	"github.com/docker/docker/api/server/httputils"
+	distributionrouter "github.com/docker/docker/api/server/router/distribution"
+	"github.com/docker/docker/daemon/config"
+	"github.com/docker/go-connections/tlsconfig"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// startBlobShareServer serves the layers pulled by the daemon to peer
+// daemons on addr. Only the read-only blob endpoint is served, over TLS, to
+// the peers presenting a client certificate signed by the CA of the daemon.
+func startBlobShareServer(addr string, tlsOptions config.CommonTLSOptions, backend distributionrouter.Backend) error {
+	tlsConfig, err := tlsconfig.Server(tlsconfig.Options{
+		CAFile:             tlsOptions.CAFile,
+		CertFile:           tlsOptions.CertFile,
+		KeyFile:            tlsOptions.KeyFile,
+		ClientAuth:         tls.RequireAndVerifyClientCert,
+		ExclusiveRootPools: true,
+	})
+	if err != nil {
+		return err
+	}
+	if err := allocateDaemonPort(addr); err != nil {
+		return err
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	l = tls.NewListener(l, tlsConfig)
+	m := mux.NewRouter()
+	for _, r := range distributionrouter.NewBlobRouter(backend).Routes() {
+		handler := r.Handler()
+		f := func(w http.ResponseWriter, req *http.Request) {
+			if err := handler(req.Context(), w, req, mux.Vars(req)); err != nil {
+				logrus.WithError(err).Debugf("Handler for %s %s returned error", req.Method, req.URL.Path)
+				httputils.MakeErrorHandler(err)(w, req)
+			}
+		}
+		m.Path("/v{version:[0-9.]+}" + r.Path()).Methods(r.Method()).HandlerFunc(f)
+		m.Path(r.Path()).Methods(r.Method()).HandlerFunc(f)
+	}
+	go func() {
+		logrus.Infof("Blob sharing API listen on %s", l.Addr())
+		if err := http.Serve(l, m); err != nil {
+			logrus.Errorf("serve blob sharing api: %s", err)
+		}
+	}()
+	return nil
+}
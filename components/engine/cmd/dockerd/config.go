@@ -61,6 +61,8 @@ func installCommonConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	flags.StringVar(&conf.CorsHeaders, "api-cors-header", "", "Set CORS headers in the Engine API")
 	flags.IntVar(&maxConcurrentDownloads, "max-concurrent-downloads", config.DefaultMaxConcurrentDownloads, "Set the max concurrent downloads for each pull")
 	flags.IntVar(&maxConcurrentUploads, "max-concurrent-uploads", config.DefaultMaxConcurrentUploads, "Set the max concurrent uploads for each push")
+	flags.Var(&conf.MaxDownloadBandwidth, "max-download-bandwidth", "Set the max download bandwidth of all the pulls, in bytes per second")
+	flags.Var(&conf.MaxUploadBandwidth, "max-upload-bandwidth", "Set the max upload bandwidth of all the pushes, in bytes per second")
 	flags.IntVar(&conf.ShutdownTimeout, "shutdown-timeout", defaultShutdownTimeout, "Set the default shutdown timeout")
 	flags.IntVar(&conf.NetworkDiagnosticPort, "network-diagnostic-port", 0, "TCP port number of the network diagnostic server")
 	flags.MarkHidden("network-diagnostic-port")
@@ -68,6 +70,9 @@ func installCommonConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	flags.StringVar(&conf.SwarmDefaultAdvertiseAddr, "swarm-default-advertise-addr", "", "Set default address or interface for swarm advertised address")
 	flags.BoolVar(&conf.Experimental, "experimental", false, "Enable experimental features")
 	flags.StringVar(&conf.MetricsAddress, "metrics-addr", "", "Set default address and port to serve the metrics api on")
+	flags.Var(opts.NewNamedListOptsRef("blob-peers", &conf.BlobPeers, config.ValidateBlobPeer), "blob-peer", "Peer daemon to fetch layers from before the registry")
+	flags.StringVar(&conf.BlobCacheDir, "blob-cache-dir", "", "Directory of layers shared with other daemons")
+	flags.StringVar(&conf.BlobShareAddress, "blob-share-addr", "", "Set the address and port to serve layers to peer daemons on")
 
 	flags.Var(opts.NewNamedListOptsRef("node-generic-resources", &conf.NodeGenericResources, opts.ValidateSingleGenericResource), "node-generic-resource", "Advertise user-defined resource")
 
@@ -200,6 +200,12 @@ func (cli *DaemonCli) start(opts *daemonOptions) (err error) {
 		}
 	}
 
+	if cli.Config.BlobShareAddress != "" {
+		if err := startBlobShareServer(cli.Config.BlobShareAddress, cli.Config.CommonTLSOptions, d.ImageService()); err != nil {
+			return err
+		}
+	}
+
 	c, err := createAndStartCluster(cli, d)
 	if err != nil {
 		logrus.Fatalf("Error starting cluster component: %v", err)
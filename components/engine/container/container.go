@@ -358,12 +358,88 @@ func (container *Container) CheckpointDir() string {
 // StartLogger starts a new logger driver for the container.
 func (container *Container) StartLogger() (logger.Logger, error) {
 	cfg := container.HostConfig.LogConfig
-	initDriver, err := logger.GetLogDriver(cfg.Type)
+	limit, burst, err := logger.ParseRateLimit(cfg.Config)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get logging factory")
+		return nil, err
+	}
+
+	var l logger.Logger
+	if drivers := logger.SplitDrivers(cfg.Type); len(drivers) > 1 {
+		l, err = container.startTeeLogger(drivers, cfg)
+	} else {
+		l, err = container.startBufferedLogDriver(cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the rate limit before buffering, so that the messages over the
+	// limit do not take space in the buffer.
+	if limit > 0 {
+		l = logger.NewRateLimitedLogger(l, limit, burst)
+	}
+	return l, nil
+}
+
+// startBufferedLogDriver starts the configured log driver, wrapped in a
+// ring buffer in non-blocking mode.
+func (container *Container) startBufferedLogDriver(cfg containertypes.LogConfig) (logger.Logger, error) {
+	l, info, err := container.startLogDriver(cfg.Type, cfg.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	if containertypes.LogMode(cfg.Config["mode"]) == containertypes.LogModeNonBlock {
+		bufferSize := int64(-1)
+		if s, exists := cfg.Config["max-buffer-size"]; exists {
+			bufferSize, err = units.RAMInBytes(s)
+			if err != nil {
+				return nil, err
+			}
+		}
+		l = logger.NewRingLogger(l, info, bufferSize)
+	}
+	return l, nil
+}
+
+// startTeeLogger starts all the given log drivers, each with its own
+// options, and returns a logger sending the logs to all of them.
+func (container *Container) startTeeLogger(drivers []string, cfg containertypes.LogConfig) (logger.Logger, error) {
+	bufferSize := int64(-1)
+	if s, exists := cfg.Config["max-buffer-size"]; exists {
+		var err error
+		bufferSize, err = units.RAMInBytes(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		loggers []logger.Logger
+		info    logger.Info
+	)
+	for _, d := range drivers {
+		l, i, err := container.startLogDriver(d, logger.DriverOpts(d, drivers, cfg.Config))
+		if err != nil {
+			for _, l := range loggers {
+				l.Close()
+			}
+			return nil, errors.Wrapf(err, "failed to start log driver %s", d)
+		}
+		loggers = append(loggers, l)
+		info = i
+	}
+	return logger.NewTeeLogger(loggers, info, bufferSize), nil
+}
+
+// startLogDriver starts the given log driver with the given options.
+func (container *Container) startLogDriver(driver string, config map[string]string) (logger.Logger, logger.Info, error) {
+	initDriver, err := logger.GetLogDriver(driver)
+	if err != nil {
+		return nil, logger.Info{}, errors.Wrap(err, "failed to get logging factory")
 	}
 	info := logger.Info{
-		Config:              cfg.Config,
+		Config:              config,
 		ContainerID:         container.ID,
 		ContainerName:       container.Name,
 		ContainerEntrypoint: container.Path,
@@ -378,11 +454,11 @@ func (container *Container) StartLogger() (logger.Logger, error) {
 
 	// Set logging file for "json-logger"
 	// TODO(@cpuguy83): Setup here based on log driver is a little weird.
-	switch cfg.Type {
+	switch driver {
 	case jsonfilelog.Name:
 		info.LogPath, err = container.GetRootResourcePath(fmt.Sprintf("%s-json.log", container.ID))
 		if err != nil {
-			return nil, err
+			return nil, info, err
 		}
 
 		container.LogPath = info.LogPath
@@ -392,30 +468,19 @@ func (container *Container) StartLogger() (logger.Logger, error) {
 		// that the log file implementation would become a stable API that cannot change.
 		logDir, err := container.GetRootResourcePath("local-logs")
 		if err != nil {
-			return nil, err
+			return nil, info, err
 		}
 		if err := os.MkdirAll(logDir, 0700); err != nil {
-			return nil, errdefs.System(errors.Wrap(err, "error creating local logs dir"))
+			return nil, info, errdefs.System(errors.Wrap(err, "error creating local logs dir"))
 		}
 		info.LogPath = filepath.Join(logDir, "container.log")
 	}
 
 	l, err := initDriver(info)
 	if err != nil {
-		return nil, err
+		return nil, info, err
 	}
-
-	if containertypes.LogMode(cfg.Config["mode"]) == containertypes.LogModeNonBlock {
-		bufferSize := int64(-1)
-		if s, exists := cfg.Config["max-buffer-size"]; exists {
-			bufferSize, err = units.RAMInBytes(s)
-			if err != nil {
-				return nil, err
-			}
-		}
-		l = logger.NewRingLogger(l, info, bufferSize)
-	}
-	return l, nil
+	return l, info, nil
 }
 
 // GetProcessLabel returns the process label for the container.
@@ -617,6 +682,13 @@ func (container *Container) startLogging() error {
 	}
 
 	copier := logger.NewCopier(map[string]io.Reader{"stdout": container.StdoutPipe(), "stderr": container.StderrPipe()}, l)
+	if format, ok := container.HostConfig.LogConfig.Config["parse"]; ok {
+		parse, err := logger.GetFieldParser(format)
+		if err != nil {
+			return err
+		}
+		copier.SetFieldParser(parse)
+	}
 	container.LogCopier = copier
 	copier.Run()
 	container.LogDriver = l
@@ -0,0 +1,258 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/chrootarchive"
+	"github.com/pkg/errors"
+)
+
+// A checkpoint archive is a tar archive holding, in this order, a metadata
+// file, the files of the checkpoint, and the changes made to the filesystem
+// of the container, in the format of an image layer.
+const (
+	checkpointArchiveVersion = 1
+	checkpointMetadataName   = "checkpoint.json"
+	checkpointFilesPrefix    = "checkpoint/"
+	checkpointRootfsPrefix   = "rootfs/"
+)
+
+// checkpointMetadata describes the checkpoint stored in a checkpoint archive.
+type checkpointMetadata struct {
+	Version    int
+	Checkpoint string
+	Container  string
+	Image      string
+	Created    time.Time
+}
+
+// CheckpointExport writes an archive of the specified checkpoint, and of the
+// filesystem changes of the container, to out. The container must be stopped,
+// so that its filesystem matches the checkpoint.
+func (daemon *Daemon) CheckpointExport(name string, config types.CheckpointExportOptions, out io.Writer) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	if container.IsRunning() {
+		return errdefs.Conflict(fmt.Errorf("cannot export checkpoint of container %s: container is running", name))
+	}
+
+	checkpointDir, err := getCheckpointDir(config.CheckpointDir, config.CheckpointID, name, container.ID, container.CheckpointDir(), false)
+	if err != nil {
+		return errdefs.NotFound(err)
+	}
+
+	files, err := archive.TarWithOptions(checkpointDir, &archive.TarOptions{Compression: archive.Uncompressed})
+	if err != nil {
+		return err
+	}
+	defer files.Close()
+
+	diff, err := container.RWLayer.TarStream()
+	if err != nil {
+		return errors.Wrapf(err, "cannot export filesystem changes of container %s", name)
+	}
+	defer diff.Close()
+
+	metadata, err := json.Marshal(checkpointMetadata{
+		Version:    checkpointArchiveVersion,
+		Checkpoint: config.CheckpointID,
+		Container:  container.ID,
+		Image:      container.ImageID.String(),
+		Created:    time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(out)
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     checkpointMetadataName,
+		Mode:     0644,
+		Size:     int64(len(metadata)),
+		ModTime:  time.Now(),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(metadata); err != nil {
+		return err
+	}
+	if err := copyTarWithPrefix(tw, files, checkpointFilesPrefix); err != nil {
+		return errors.Wrap(err, "error archiving checkpoint")
+	}
+	if err := copyTarWithPrefix(tw, diff, checkpointRootfsPrefix); err != nil {
+		return errors.Wrap(err, "error archiving filesystem changes")
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	daemon.LogContainerEvent(container, "checkpoint-export")
+	return nil
+}
+
+// CheckpointImport imports a checkpoint archive created by CheckpointExport
+// in the specified container, which must be stopped and created from the
+// same image as the container the checkpoint was created from. The
+// filesystem changes in the archive are applied to the container, which can
+// then be started from the checkpoint.
+func (daemon *Daemon) CheckpointImport(name string, config types.CheckpointImportOptions, in io.Reader) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	if container.IsRunning() {
+		return errdefs.Conflict(fmt.Errorf("cannot import checkpoint in container %s: container is running", name))
+	}
+
+	if !validCheckpointNamePattern.MatchString(config.CheckpointID) {
+		return errdefs.InvalidParameter(fmt.Errorf("Invalid checkpoint ID (%s), only %s are allowed", config.CheckpointID, validCheckpointNameChars))
+	}
+
+	tr := tar.NewReader(in)
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != checkpointMetadataName {
+		return errdefs.InvalidParameter(errors.New("invalid checkpoint archive: missing metadata"))
+	}
+	var metadata checkpointMetadata
+	if err := json.NewDecoder(tr).Decode(&metadata); err != nil {
+		return errdefs.InvalidParameter(errors.Wrap(err, "invalid checkpoint archive: invalid metadata"))
+	}
+	if metadata.Version != checkpointArchiveVersion {
+		return errdefs.InvalidParameter(fmt.Errorf("unsupported checkpoint archive version: %d", metadata.Version))
+	}
+	if metadata.Image != container.ImageID.String() {
+		return errdefs.InvalidParameter(fmt.Errorf("checkpoint was created from a container of image %s, but container %s was created from image %s", metadata.Image, name, container.ImageID))
+	}
+
+	checkpointDir, err := getCheckpointDir(config.CheckpointDir, config.CheckpointID, name, container.ID, container.CheckpointDir(), true)
+	if err != nil {
+		return errdefs.Conflict(fmt.Errorf("cannot import checkpoint in container %s: %s", name, err))
+	}
+
+	if hdr, err = tr.Next(); err != nil && err != io.EOF {
+		os.RemoveAll(checkpointDir)
+		return errdefs.InvalidParameter(errors.Wrap(err, "invalid checkpoint archive"))
+	}
+	hdr, err = untarSection(tr, hdr, checkpointFilesPrefix, func(r io.Reader) error {
+		return chrootarchive.Untar(r, checkpointDir, &archive.TarOptions{})
+	})
+	if err != nil {
+		os.RemoveAll(checkpointDir)
+		return errors.Wrap(err, "error importing checkpoint")
+	}
+
+	if err := daemon.Mount(container); err != nil {
+		os.RemoveAll(checkpointDir)
+		return err
+	}
+	defer daemon.Unmount(container)
+
+	hdr, err = untarSection(tr, hdr, checkpointRootfsPrefix, func(r io.Reader) error {
+		_, err := chrootarchive.ApplyUncompressedLayer(container.BaseFS.Path(), r, &archive.TarOptions{
+			UIDMaps: daemon.idMapping.UIDs(),
+			GIDMaps: daemon.idMapping.GIDs(),
+		})
+		return err
+	})
+	if err == nil && hdr != nil {
+		err = errdefs.InvalidParameter(fmt.Errorf("invalid checkpoint archive: unexpected file %s", hdr.Name))
+	}
+	if err != nil {
+		os.RemoveAll(checkpointDir)
+		return errors.Wrapf(err, "error importing filesystem changes in container %s", name)
+	}
+
+	daemon.LogContainerEvent(container, "checkpoint-import")
+	return nil
+}
+
+// copyTarWithPrefix copies the entries of the tar archive read from r to tw,
+// adding prefix to their names.
+func copyTarWithPrefix(tw *tar.Writer, r io.Reader, prefix string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		hdr.Name = prefix + hdr.Name
+		if hdr.Typeflag == tar.TypeLink {
+			hdr.Linkname = prefix + hdr.Linkname
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// untarSection passes the entries of tr whose name starts with prefix, the
+// first of which is hdr, to unpack as a tar archive, with the prefix removed
+// from their names. It returns the header of the first entry that does not
+// start with prefix, or nil at the end of the archive.
+func untarSection(tr *tar.Reader, hdr *tar.Header, prefix string, unpack func(io.Reader) error) (*tar.Header, error) {
+	pr, pw := io.Pipe()
+	unpackErr := make(chan error, 1)
+	go func() {
+		err := unpack(pr)
+		if err == nil {
+			// drain the end of the archive that unpack did not need
+			_, err = io.Copy(ioutil.Discard, pr)
+		}
+		pr.CloseWithError(err)
+		unpackErr <- err
+	}()
+
+	tw := tar.NewWriter(pw)
+	var err error
+	for hdr != nil && strings.HasPrefix(hdr.Name, prefix) {
+		h := *hdr
+		h.Name = strings.TrimPrefix(hdr.Name, prefix)
+		if h.Typeflag == tar.TypeLink {
+			h.Linkname = strings.TrimPrefix(hdr.Linkname, prefix)
+		}
+		if h.Name != "" {
+			if err = tw.WriteHeader(&h); err != nil {
+				break
+			}
+			if _, err = io.Copy(tw, tr); err != nil {
+				break
+			}
+		}
+		if hdr, err = tr.Next(); err == io.EOF {
+			hdr, err = nil, nil
+		} else if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = tw.Close()
+	}
+	pw.CloseWithError(err)
+
+	// an error of unpack is the cause of an error writing to the pipe
+	if uErr := <-unpackErr; uErr != nil {
+		err = uErr
+	}
+	return hdr, err
+}
@@ -0,0 +1,77 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func makeTar(t *testing.T, files map[string]string, names ...string) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, name := range names {
+		content := files[name]
+		assert.NilError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		assert.NilError(t, err)
+	}
+	assert.NilError(t, tw.Close())
+	return buf
+}
+
+func readTar(r io.Reader) (map[string]string, error) {
+	files := make(map[string]string)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		b, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[hdr.Name] = string(b)
+	}
+}
+
+func TestCheckpointArchiveSections(t *testing.T) {
+	checkpoint := map[string]string{"pages-1.img": "pages", "inventory.img": "inventory"}
+	rootfs := map[string]string{"etc/hostname": "host", "tmp/.wh.removed": ""}
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	assert.NilError(t, copyTarWithPrefix(tw, makeTar(t, checkpoint, "inventory.img", "pages-1.img"), checkpointFilesPrefix))
+	assert.NilError(t, copyTarWithPrefix(tw, makeTar(t, rootfs, "etc/hostname", "tmp/.wh.removed"), checkpointRootfsPrefix))
+	assert.NilError(t, tw.Close())
+
+	tr := tar.NewReader(buf)
+	hdr, err := tr.Next()
+	assert.NilError(t, err)
+
+	var files map[string]string
+	hdr, err = untarSection(tr, hdr, checkpointFilesPrefix, func(r io.Reader) (err error) {
+		files, err = readTar(r)
+		return err
+	})
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(checkpoint, files))
+	assert.Assert(t, hdr != nil)
+	assert.Check(t, is.Equal("rootfs/etc/hostname", hdr.Name))
+
+	hdr, err = untarSection(tr, hdr, checkpointRootfsPrefix, func(r io.Reader) (err error) {
+		files, err = readTar(r)
+		return err
+	})
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(rootfs, files))
+	assert.Check(t, hdr == nil)
+}
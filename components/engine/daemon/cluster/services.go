@@ -17,6 +17,7 @@ import (
 	types "github.com/docker/docker/api/types/swarm"
 	timetypes "github.com/docker/docker/api/types/time"
 	"github.com/docker/docker/daemon/cluster/convert"
+	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/errdefs"
 	runconfigopts "github.com/docker/docker/runconfig/opts"
 	swarmapi "github.com/docker/swarmkit/api"
@@ -433,6 +434,24 @@ func (c *Cluster) ServiceLogs(ctx context.Context, selector *backend.LogSelector
 		}
 	}
 
+	include, exclude, err := logger.CompileLineFilters(config.Grep, config.Exclude)
+	if err != nil {
+		return nil, errdefs.InvalidParameter(err)
+	}
+	filter := logger.ReadConfig{Include: include, Exclude: exclude}
+
+	// With filters, the tail of each task is made of its last matching
+	// lines. The nodes only know how to tail unfiltered logs, so the logs
+	// are read from the start, and tailed here.
+	var tailLines int
+	if tail < -1 && filter.HasFilters() {
+		if config.Follow {
+			return nil, errdefs.InvalidParameter(errors.New("tail cannot be combined with grep or exclude when following service logs"))
+		}
+		tailLines = int(-tail - 1)
+		tail = 0
+	}
+
 	stream, err := state.logsClient.SubscribeLogs(ctx, &swarmapi.SubscribeLogsRequest{
 		Selector: swarmSelector,
 		Options: &swarmapi.LogSubscriptionOptions{
@@ -449,6 +468,23 @@ func (c *Cluster) ServiceLogs(ctx context.Context, selector *backend.LogSelector
 	messageChan := make(chan *backend.LogMessage, 1)
 	go func() {
 		defer close(messageChan)
+
+		var (
+			tasks []string
+			tails = make(map[string][]*backend.LogMessage)
+		)
+		defer func() {
+			for _, task := range tasks {
+				for _, m := range tails[task] {
+					select {
+					case <-ctx.Done():
+						return
+					case messageChan <- m:
+					}
+				}
+			}
+		}()
+
 		for {
 			// Check the context before doing anything.
 			select {
@@ -470,6 +506,9 @@ func (c *Cluster) ServiceLogs(ctx context.Context, selector *backend.LogSelector
 			}
 
 			for _, msg := range subscribeMsg.Messages {
+				if !filter.MatchLine(msg.Data) {
+					continue
+				}
 				// make a new message
 				m := new(backend.LogMessage)
 				m.Attrs = make([]backend.LogAttr, 0, len(msg.Attrs)+3)
@@ -508,6 +547,18 @@ func (c *Cluster) ServiceLogs(ctx context.Context, selector *backend.LogSelector
 				}
 				m.Line = msg.Data
 
+				if tailLines > 0 {
+					task := msg.Context.TaskID
+					if _, ok := tails[task]; !ok {
+						tasks = append(tasks, task)
+					}
+					tails[task] = append(tails[task], m)
+					if len(tails[task]) > tailLines {
+						tails[task] = tails[task][1:]
+					}
+					continue
+				}
+
 				// there could be a case where the reader stops accepting
 				// messages and the context is canceled. we need to check that
 				// here, or otherwise we risk blocking forever on the message
@@ -0,0 +1,51 @@
+package config // import "github.com/docker/docker/daemon/config"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// ValidateBlobPeer validates the URL of a peer daemon that layers are
+// fetched from, and returns it without trailing slash.
+func ValidateBlobPeer(val string) (string, error) {
+	uri, err := url.Parse(val)
+	if err != nil {
+		return "", fmt.Errorf("invalid blob peer: %q is not a valid URI", val)
+	}
+	if uri.Scheme != "https" {
+		return "", fmt.Errorf("invalid blob peer: unsupported scheme %q in %q", uri.Scheme, uri)
+	}
+	if uri.Host == "" {
+		return "", fmt.Errorf("invalid blob peer: missing host in %q", uri)
+	}
+	if (uri.Path != "" && uri.Path != "/") || uri.RawQuery != "" || uri.Fragment != "" {
+		return "", fmt.Errorf("invalid blob peer: path, query, or fragment at end of the URI %q", uri)
+	}
+	if uri.User != nil {
+		// strip password from output
+		uri.User = url.UserPassword(uri.User.Username(), "xxxxx")
+		return "", fmt.Errorf("invalid blob peer: username/password not allowed in URI %q", uri)
+	}
+	return strings.TrimSuffix(val, "/"), nil
+}
+
+// validateBlobSharing validates the peers and the cache directory that
+// layers are fetched from. Layers are only shared between daemons that
+// verify each other's TLS certificate.
+func validateBlobSharing(config *Config) error {
+	if (config.BlobShareAddress != "" || len(config.BlobPeers) > 0) && !config.TLSVerify {
+		return errors.New("sharing layers with peer daemons requires --tlsverify")
+	}
+	for _, peer := range config.BlobPeers {
+		if _, err := ValidateBlobPeer(peer); err != nil {
+			return err
+		}
+	}
+	if config.BlobCacheDir != "" && !filepath.IsAbs(config.BlobCacheDir) {
+		return fmt.Errorf("invalid blob cache directory %q: must be an absolute path", config.BlobCacheDir)
+	}
+	return nil
+}
@@ -56,6 +56,9 @@ var flatOptions = map[string]bool{
 	"default-ulimits":    true,
 	"features":           true,
 	"builder":            true,
+	"events":             true,
+	"images":             true,
+	"stats":              true,
 }
 
 // skipValidateOptions contains configuration keys
@@ -64,6 +67,9 @@ var flatOptions = map[string]bool{
 var skipValidateOptions = map[string]bool{
 	"features": true,
 	"builder":  true,
+	"events":   true,
+	"images":   true,
+	"stats":    true,
 }
 
 // skipDuplicates contains configuration keys that
@@ -167,6 +173,14 @@ type CommonConfig struct {
 	// may take place at a time for each push.
 	MaxConcurrentUploads *int `json:"max-concurrent-uploads,omitempty"`
 
+	// MaxDownloadBandwidth is the maximum number of bytes per second
+	// downloaded by all the pulls. Downloads are not limited if it is zero.
+	MaxDownloadBandwidth opts.MemBytes `json:"max-download-bandwidth,omitempty"`
+
+	// MaxUploadBandwidth is the maximum number of bytes per second
+	// uploaded by all the pushes. Uploads are not limited if it is zero.
+	MaxUploadBandwidth opts.MemBytes `json:"max-upload-bandwidth,omitempty"`
+
 	// ShutdownTimeout is the timeout value (in seconds) the daemon will wait for the container
 	// to stop when daemon is being shutdown
 	ShutdownTimeout int `json:"shutdown-timeout,omitempty"`
@@ -199,6 +213,19 @@ type CommonConfig struct {
 
 	MetricsAddress string `json:"metrics-addr"`
 
+	// BlobPeers are the URLs of peer daemons that layers are fetched from
+	// before the registry.
+	BlobPeers []string `json:"blob-peers,omitempty"`
+
+	// BlobCacheDir is a directory of layer blobs, possibly shared with other
+	// daemons, that layers are fetched from before the peers and the
+	// registry. The layers pulled from a registry are added to it.
+	BlobCacheDir string `json:"blob-cache-dir,omitempty"`
+
+	// BlobShareAddress is the address and port the layers are served to
+	// peer daemons on.
+	BlobShareAddress string `json:"blob-share-addr,omitempty"`
+
 	LogConfig
 	BridgeConfig // bridgeConfig holds bridge network specific configuration.
 	NetworkConfig
@@ -230,6 +257,12 @@ type CommonConfig struct {
 
 	Builder BuilderConfig `json:"builder,omitempty"`
 
+	Events EventsConfig `json:"events,omitempty"`
+
+	Images ImagesConfig `json:"images,omitempty"`
+
+	Stats StatsConfig `json:"stats,omitempty"`
+
 	ContainerdNamespace       string `json:"containerd-namespace,omitempty"`
 	ContainerdPluginNamespace string `json:"containerd-plugin-namespace,omitempty"`
 }
@@ -562,6 +595,14 @@ func Validate(config *Config) error {
 	if config.MaxConcurrentUploads != nil && *config.MaxConcurrentUploads < 0 {
 		return fmt.Errorf("invalid max concurrent uploads: %d", *config.MaxConcurrentUploads)
 	}
+	// validate MaxDownloadBandwidth
+	if config.MaxDownloadBandwidth < 0 {
+		return fmt.Errorf("invalid max download bandwidth: %d", config.MaxDownloadBandwidth)
+	}
+	// validate MaxUploadBandwidth
+	if config.MaxUploadBandwidth < 0 {
+		return fmt.Errorf("invalid max upload bandwidth: %d", config.MaxUploadBandwidth)
+	}
 
 	// validate that "default" runtime is not reset
 	if runtimes := config.GetAllRuntimes(); len(runtimes) > 0 {
@@ -574,6 +615,22 @@ func Validate(config *Config) error {
 		return err
 	}
 
+	if _, _, err := config.Events.Journal.Limits(); err != nil {
+		return err
+	}
+
+	if err := config.Images.GC.Validate(); err != nil {
+		return err
+	}
+
+	if _, _, err := config.Stats.History.Durations(); err != nil {
+		return err
+	}
+
+	if err := validateBlobSharing(config); err != nil {
+		return err
+	}
+
 	if defaultRuntime := config.GetDefaultRuntimeName(); defaultRuntime != "" && defaultRuntime != StockRuntimeName {
 		runtimes := config.GetAllRuntimes()
 		if _, ok := runtimes[defaultRuntime]; !ok {
@@ -1,6 +1,8 @@
 package config // import "github.com/docker/docker/daemon/config"
 
 import (
+	"fmt"
+
 	"github.com/docker/docker/api/types"
 )
 
@@ -53,5 +55,8 @@ func (conf *Config) IsSwarmCompatible() error {
 
 // ValidatePlatformConfig checks if any platform-specific configuration settings are invalid.
 func (conf *Config) ValidatePlatformConfig() error {
+	if conf.Images.GC.HighWatermark > 0 {
+		return fmt.Errorf("image gc disk usage watermarks are not supported on Windows")
+	}
 	return nil
 }
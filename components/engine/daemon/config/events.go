@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/docker/go-units"
+)
+
+// EventsJournalConfig contains the configuration of the on-disk event journal
+type EventsJournalConfig struct {
+	Enabled bool   `json:",omitempty"`
+	MaxSize string `json:",omitempty"`
+	MaxAge  string `json:",omitempty"`
+}
+
+// Limits returns the retention limits of the journal. Zero values mean
+// that the corresponding limit is not set.
+func (c EventsJournalConfig) Limits() (maxSize int64, maxAge time.Duration, err error) {
+	if c.MaxSize != "" {
+		maxSize, err = units.RAMInBytes(c.MaxSize)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid events journal max size %q: %v", c.MaxSize, err)
+		}
+		if maxSize < 0 {
+			return 0, 0, fmt.Errorf("invalid events journal max size %q: cannot be negative", c.MaxSize)
+		}
+	}
+	if c.MaxAge != "" {
+		maxAge, err = time.ParseDuration(c.MaxAge)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid events journal max age %q: %v", c.MaxAge, err)
+		}
+		if maxAge < 0 {
+			return 0, 0, fmt.Errorf("invalid events journal max age %q: cannot be negative", c.MaxAge)
+		}
+	}
+	return maxSize, maxAge, nil
+}
+
+// EventsConfig contains config for the daemon's event history
+type EventsConfig struct {
+	Journal EventsJournalConfig `json:",omitempty"`
+}
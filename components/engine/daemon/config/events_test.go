@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/fs"
+)
+
+func TestEventsJournal(t *testing.T) {
+	tempFile := fs.NewFile(t, "config", fs.WithContent(`{
+  "events": {
+    "journal": {
+      "enabled": true,
+      "maxSize": "64MB",
+      "maxAge": "168h"
+    }
+  }
+}`))
+	defer tempFile.Remove()
+	configFile := tempFile.Path()
+
+	cfg, err := MergeDaemonConfigurations(&Config{}, nil, configFile)
+	assert.NilError(t, err)
+	assert.Assert(t, cfg.Events.Journal.Enabled)
+
+	maxSize, maxAge, err := cfg.Events.Journal.Limits()
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(int64(64*1024*1024), maxSize))
+	assert.Check(t, is.Equal(168*time.Hour, maxAge))
+}
+
+func TestEventsJournalInvalidLimits(t *testing.T) {
+	tempFile := fs.NewFile(t, "config", fs.WithContent(`{
+  "events": {
+    "journal": {
+      "enabled": true,
+      "maxAge": "a week"
+    }
+  }
+}`))
+	defer tempFile.Remove()
+
+	_, err := MergeDaemonConfigurations(&Config{}, nil, tempFile.Path())
+	assert.ErrorContains(t, err, "invalid events journal max age")
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultImageGCInterval is the default interval between two runs of the
+// image garbage collector.
+const DefaultImageGCInterval = 10 * time.Minute
+
+// ImageGCConfig contains the configuration of the image garbage collector.
+// Images that are not used by any container are removed when they were not
+// used for MaxAge, when their repository has more than KeepTags more recent
+// tags, or, starting with the least recently used, while the disk usage of
+// the daemon root is over LowWatermark percent after it reached
+// HighWatermark percent. LowWatermark defaults to HighWatermark.
+type ImageGCConfig struct {
+	Enabled       bool   `json:",omitempty"`
+	Interval      string `json:",omitempty"`
+	HighWatermark int    `json:",omitempty"`
+	LowWatermark  int    `json:",omitempty"`
+	MaxAge        string `json:",omitempty"`
+	KeepTags      int    `json:",omitempty"`
+}
+
+// Durations returns the interval between two runs of the garbage collector,
+// and the maximum age of unused images. A zero maximum age means that images
+// are not removed because of their age.
+func (c ImageGCConfig) Durations() (interval, maxAge time.Duration, err error) {
+	interval = DefaultImageGCInterval
+	if c.Interval != "" {
+		interval, err = time.ParseDuration(c.Interval)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid image gc interval %q: %v", c.Interval, err)
+		}
+		if interval <= 0 {
+			return 0, 0, fmt.Errorf("invalid image gc interval %q: must be positive", c.Interval)
+		}
+	}
+	if c.MaxAge != "" {
+		maxAge, err = time.ParseDuration(c.MaxAge)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid image gc max age %q: %v", c.MaxAge, err)
+		}
+		if maxAge < 0 {
+			return 0, 0, fmt.Errorf("invalid image gc max age %q: cannot be negative", c.MaxAge)
+		}
+	}
+	return interval, maxAge, nil
+}
+
+// Validate validates the configuration of the image garbage collector.
+func (c ImageGCConfig) Validate() error {
+	_, maxAge, err := c.Durations()
+	if err != nil {
+		return err
+	}
+	if c.HighWatermark < 0 || c.HighWatermark > 100 {
+		return fmt.Errorf("invalid image gc high watermark %d: must be a percentage between 0 and 100", c.HighWatermark)
+	}
+	if c.LowWatermark < 0 || c.LowWatermark > c.HighWatermark {
+		return fmt.Errorf("invalid image gc low watermark %d: must be a percentage between 0 and the high watermark", c.LowWatermark)
+	}
+	if c.KeepTags < 0 {
+		return fmt.Errorf("invalid image gc keep tags %d: cannot be negative", c.KeepTags)
+	}
+	if c.Enabled && maxAge == 0 && c.HighWatermark == 0 && c.KeepTags == 0 {
+		return fmt.Errorf("image gc is enabled, but none of maxAge, highWatermark and keepTags is set")
+	}
+	return nil
+}
+
+// ImagesConfig contains config for the daemon's image management
+type ImagesConfig struct {
+	GC ImageGCConfig `json:",omitempty"`
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/fs"
+)
+
+func TestImageGC(t *testing.T) {
+	tempFile := fs.NewFile(t, "config", fs.WithContent(`{
+  "images": {
+    "gc": {
+      "enabled": true,
+      "highWatermark": 85,
+      "lowWatermark": 70,
+      "maxAge": "720h",
+      "keepTags": 3
+    }
+  }
+}`))
+	defer tempFile.Remove()
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	cfg, err := MergeDaemonConfigurations(&Config{}, flags, tempFile.Path())
+	assert.NilError(t, err)
+	gc := cfg.Images.GC
+	assert.Assert(t, gc.Enabled)
+	assert.Check(t, is.Equal(85, gc.HighWatermark))
+	assert.Check(t, is.Equal(70, gc.LowWatermark))
+	assert.Check(t, is.Equal(3, gc.KeepTags))
+
+	interval, maxAge, err := gc.Durations()
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(DefaultImageGCInterval, interval))
+	assert.Check(t, is.Equal(720*time.Hour, maxAge))
+}
+
+func TestImageGCInvalid(t *testing.T) {
+	testCases := []struct {
+		config      ImageGCConfig
+		expectedErr string
+	}{
+		{
+			config:      ImageGCConfig{Enabled: true},
+			expectedErr: "none of maxAge, highWatermark and keepTags is set",
+		},
+		{
+			config:      ImageGCConfig{Enabled: true, MaxAge: "a month"},
+			expectedErr: "invalid image gc max age",
+		},
+		{
+			config:      ImageGCConfig{Enabled: true, KeepTags: 1, Interval: "0s"},
+			expectedErr: "invalid image gc interval",
+		},
+		{
+			config:      ImageGCConfig{Enabled: true, HighWatermark: 101},
+			expectedErr: "invalid image gc high watermark",
+		},
+		{
+			config:      ImageGCConfig{Enabled: true, HighWatermark: 70, LowWatermark: 85},
+			expectedErr: "invalid image gc low watermark",
+		},
+		{
+			config:      ImageGCConfig{Enabled: true, KeepTags: -1},
+			expectedErr: "invalid image gc keep tags",
+		},
+	}
+	for _, tc := range testCases {
+		assert.Check(t, is.ErrorContains(tc.config.Validate(), tc.expectedErr), "%+v", tc.config)
+	}
+}
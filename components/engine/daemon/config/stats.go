@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultStatsHistoryInterval is the default interval between two
+	// samples of the stats history of a container.
+	DefaultStatsHistoryInterval = 10 * time.Second
+	// DefaultStatsHistoryRetention is the default duration for which the
+	// samples of the stats history are kept.
+	DefaultStatsHistoryRetention = time.Hour
+)
+
+// StatsHistoryConfig contains the configuration of the history of the
+// resource usage stats of the containers kept in memory by the daemon.
+type StatsHistoryConfig struct {
+	Enabled   bool   `json:",omitempty"`
+	Interval  string `json:",omitempty"`
+	Retention string `json:",omitempty"`
+}
+
+// Durations returns the interval between two samples of the history, and the
+// duration for which they are kept.
+func (c StatsHistoryConfig) Durations() (interval, retention time.Duration, err error) {
+	interval, retention = DefaultStatsHistoryInterval, DefaultStatsHistoryRetention
+	if c.Interval != "" {
+		interval, err = time.ParseDuration(c.Interval)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid stats history interval %q: %v", c.Interval, err)
+		}
+		if interval < time.Second {
+			return 0, 0, fmt.Errorf("invalid stats history interval %q: must be at least 1s", c.Interval)
+		}
+	}
+	if c.Retention != "" {
+		retention, err = time.ParseDuration(c.Retention)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid stats history retention %q: %v", c.Retention, err)
+		}
+	}
+	if retention < interval {
+		return 0, 0, fmt.Errorf("invalid stats history retention %q: must be at least the interval (%s)", c.Retention, interval)
+	}
+	return interval, retention, nil
+}
+
+// StatsConfig contains the configuration of the daemon's container stats
+type StatsConfig struct {
+	History StatsHistoryConfig `json:",omitempty"`
+}
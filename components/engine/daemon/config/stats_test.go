@@ -0,0 +1,72 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/fs"
+)
+
+func TestStatsHistory(t *testing.T) {
+	tempFile := fs.NewFile(t, "config", fs.WithContent(`{
+  "stats": {
+    "history": {
+      "enabled": true,
+      "interval": "30s",
+      "retention": "6h"
+    }
+  }
+}`))
+	defer tempFile.Remove()
+
+	cfg, err := MergeDaemonConfigurations(&Config{}, nil, tempFile.Path())
+	assert.NilError(t, err)
+	assert.Assert(t, cfg.Stats.History.Enabled)
+
+	interval, retention, err := cfg.Stats.History.Durations()
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(30*time.Second, interval))
+	assert.Check(t, is.Equal(6*time.Hour, retention))
+}
+
+func TestStatsHistoryDefaults(t *testing.T) {
+	interval, retention, err := StatsHistoryConfig{Enabled: true}.Durations()
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(DefaultStatsHistoryInterval, interval))
+	assert.Check(t, is.Equal(DefaultStatsHistoryRetention, retention))
+}
+
+func TestStatsHistoryInvalidDurations(t *testing.T) {
+	testCases := []struct {
+		config      StatsHistoryConfig
+		expectedErr string
+	}{
+		{
+			config:      StatsHistoryConfig{Interval: "often"},
+			expectedErr: `invalid stats history interval "often"`,
+		},
+		{
+			config:      StatsHistoryConfig{Interval: "100ms"},
+			expectedErr: `invalid stats history interval "100ms": must be at least 1s`,
+		},
+		{
+			config:      StatsHistoryConfig{Retention: "-1h"},
+			expectedErr: `invalid stats history retention "-1h": must be at least the interval (10s)`,
+		},
+		{
+			config:      StatsHistoryConfig{Interval: "1m", Retention: "30s"},
+			expectedErr: `invalid stats history retention "30s": must be at least the interval (1m0s)`,
+		},
+	}
+	for _, tc := range testCases {
+		_, _, err := tc.config.Durations()
+		assert.Check(t, is.ErrorContains(err, tc.expectedErr))
+	}
+
+	tempFile := fs.NewFile(t, "config", fs.WithContent(`{"stats": {"history": {"retention": "a day"}}}`))
+	defer tempFile.Remove()
+	_, err := MergeDaemonConfigurations(&Config{}, nil, tempFile.Path())
+	assert.ErrorContains(t, err, "invalid stats history retention")
+}
@@ -2,6 +2,8 @@ package daemon // import "github.com/docker/docker/daemon"
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
@@ -19,6 +21,7 @@ import (
 	"github.com/docker/docker/pkg/signal"
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/docker/pkg/truncindex"
+	"github.com/docker/docker/restartmanager"
 	"github.com/docker/docker/runconfig"
 	volumemounts "github.com/docker/docker/volume/mounts"
 	"github.com/docker/go-connections/nat"
@@ -284,6 +287,10 @@ func (daemon *Daemon) verifyContainerSettings(platform string, hostConfig *conta
 			if config.Healthcheck.StartPeriod != 0 && config.Healthcheck.StartPeriod < containertypes.MinimumDuration {
 				return nil, errors.Errorf("StartPeriod in Healthcheck cannot be less than %s", containertypes.MinimumDuration)
 			}
+
+			if err := validateHealthcheckTest(config.Healthcheck.Test); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -339,6 +346,37 @@ func (daemon *Daemon) verifyContainerSettings(platform string, hostConfig *conta
 		return nil, errors.Errorf("invalid restart policy '%s'", p.Name)
 	}
 
+	if p.InitialDelay < 0 || p.MaxDelay < 0 {
+		return nil, errors.Errorf("restart delays cannot be negative")
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay == 0 {
+		maxDelay = restartmanager.DefaultMaxDelay
+	}
+	if p.InitialDelay > maxDelay {
+		return nil, errors.Errorf("initial restart delay (%s) cannot be greater than maximum restart delay (%s)", p.InitialDelay, maxDelay)
+	}
+	if p.Multiplier != 0 && p.Multiplier < 1 {
+		return nil, errors.Errorf("restart delay multiplier cannot be less than 1")
+	}
+	if p.FailureWindow < 0 {
+		return nil, errors.Errorf("restart failure window cannot be negative")
+	}
+	if p.FailureWindow > 0 && !p.IsOnFailure() {
+		return nil, errors.Errorf("restart failure window can only be used with restart policy 'on-failure'")
+	}
+
+	for _, d := range hostConfig.DependsOn {
+		if d.Container == "" {
+			return nil, errors.Errorf("dependency must specify a container")
+		}
+		switch d.Condition {
+		case containertypes.DependencyConditionUnset, containertypes.DependencyConditionStarted, containertypes.DependencyConditionHealthy:
+		default:
+			return nil, errors.Errorf("invalid condition '%s' for dependency on %s", d.Condition, d.Container)
+		}
+	}
+
 	if !hostConfig.Isolation.IsValid() {
 		return nil, errors.Errorf("invalid isolation '%s' on %s", hostConfig.Isolation, runtime.GOOS)
 	}
@@ -356,3 +394,35 @@ func (daemon *Daemon) verifyContainerSettings(platform string, hostConfig *conta
 	}
 	return warnings, err
 }
+
+// validateHealthcheckTest checks the arguments of the probe types that are
+// run by the daemon itself rather than inside the container.
+func validateHealthcheckTest(test []string) error {
+	if len(test) == 0 {
+		return nil
+	}
+	if (test[0] == "HTTP" || test[0] == "TCP") && !healthProbesSupported {
+		return errors.Errorf("%s healthchecks are not supported on this platform", test[0])
+	}
+	switch test[0] {
+	case "HTTP":
+		if len(test) != 2 {
+			return errors.Errorf("HTTP healthcheck requires exactly one URL")
+		}
+		u, err := url.Parse(test[1])
+		if err != nil {
+			return errors.Wrap(err, "invalid URL in HTTP healthcheck")
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Errorf("invalid URL in HTTP healthcheck %q: must be an absolute http or https URL", test[1])
+		}
+	case "TCP":
+		if len(test) != 2 {
+			return errors.Errorf("TCP healthcheck requires exactly one address")
+		}
+		if _, _, err := net.SplitHostPort(test[1]); err != nil {
+			return errors.Wrap(err, "invalid address in TCP healthcheck")
+		}
+	}
+	return nil
+}
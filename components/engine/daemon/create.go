@@ -131,6 +131,10 @@ func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool) (
 		}
 	}()
 
+	if err := daemon.checkDependencyCycle(container, params.HostConfig); err != nil {
+		return nil, err
+	}
+
 	if err := daemon.setSecurityOptions(container, params.HostConfig); err != nil {
 		return nil, err
 	}
@@ -191,6 +195,9 @@ func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool) (
 		return nil, err
 	}
 	stateCtr.set(container.ID, "stopped")
+	if imgID != "" {
+		daemon.imageService.MarkImageUsed(imgID)
+	}
 	daemon.LogContainerEvent(container, "create")
 	return container, nil
 }
@@ -7,6 +7,7 @@ package daemon // import "github.com/docker/docker/daemon"
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
@@ -46,6 +47,7 @@ import (
 	// register graph drivers
 	_ "github.com/docker/docker/daemon/graphdriver/register"
 	"github.com/docker/docker/daemon/stats"
+	"github.com/docker/docker/distribution"
 	dmetadata "github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/dockerversion"
 	"github.com/docker/docker/image"
@@ -64,6 +66,7 @@ import (
 	"github.com/docker/docker/registry"
 	"github.com/docker/docker/runconfig"
 	volumesservice "github.com/docker/docker/volume/service"
+	"github.com/docker/go-connections/tlsconfig"
 	"github.com/docker/libnetwork"
 	"github.com/docker/libnetwork/cluster"
 	nwconfig "github.com/docker/libnetwork/config"
@@ -120,6 +123,7 @@ type Daemon struct {
 
 	diskUsageRunning int32
 	pruneRunning     int32
+	stopImageGC      context.CancelFunc
 	hosts            map[string]bool // hosts stores the addresses the daemon is listening on
 	startupDone      chan struct{}
 
@@ -352,6 +356,8 @@ func (daemon *Daemon) restore() error {
 						logrus.Errorf("Failed to update stopped container %s state: %v", c.ID, err)
 					}
 					c.Unlock()
+				} else {
+					daemon.statsCollector.Track(c)
 				}
 
 				// we call Mount and then Unmount to get BaseFs of the container
@@ -432,25 +438,71 @@ func (daemon *Daemon) restore() error {
 		}
 	}
 
+	// Containers are started after the containers they depend on. If the
+	// dependencies of a container form a cycle or cannot be resolved, fall
+	// back to waiting for them for a limited time only.
+	unordered := make(map[*container.Container]bool)
+	deferred := make(map[*container.Container]chan struct{})
+	for c := range restartContainers {
+		if _, err := daemon.startupOrder([]*container.Container{c}); err != nil {
+			logrus.WithError(err).WithField("container", c.ID).Error("Failed to order the startup of container")
+			unordered[c] = true
+		}
+		deferred[c] = make(chan struct{})
+	}
+
 	group := sync.WaitGroup{}
 	for c, notifier := range restartContainers {
 		group.Add(1)
 
 		go func(c *container.Container, chNotify chan struct{}) {
-			defer group.Done()
+			// Waiting for a dependency to become healthy can take as long
+			// as its healthcheck interval, so the containers doing it, and
+			// the ones depending on them, are started in the background
+			// instead of delaying the daemon startup.
+			var once sync.Once
+			background := func() {
+				once.Do(func() {
+					close(deferred[c])
+					group.Done()
+				})
+			}
+			defer once.Do(group.Done)
 
 			logrus.Debugf("Starting container %s", c.ID)
 
-			// ignore errors here as this is a best effort to wait for children to be
+			// ignore errors here as this is a best effort to wait for dependencies to be
 			//   running before we try to start the container
-			children := daemon.children(c)
-			timeout := time.After(5 * time.Second)
-			for _, child := range children {
-				if notifier, exists := restartContainers[child]; exists {
+			deps, err := daemon.startupDependencies(c)
+			if err != nil {
+				logrus.WithError(err).WithField("container", c.ID).Warn("Failed to get container dependencies")
+			}
+			var timeout <-chan time.Time
+			if unordered[c] {
+				timeout = time.After(5 * time.Second)
+			}
+			for _, d := range deps {
+				if notifier, exists := restartContainers[d.container]; exists {
 					select {
 					case <-notifier:
 					case <-timeout:
+					case <-deferred[d.container]:
+						background()
+						select {
+						case <-notifier:
+						case <-timeout:
+						}
+					}
+				}
+				if d.explicit {
+					if d.condition == containertypes.DependencyConditionHealthy {
+						background()
+					}
+					ctx, cancel := context.WithTimeout(context.Background(), dependencyHealthyTimeout)
+					if err := waitForDependency(ctx, d); err != nil {
+						logrus.WithError(err).WithField("container", c.ID).Warn("Dependency not ready, starting container anyway")
 					}
+					cancel()
 				}
 			}
 
@@ -888,7 +940,7 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 		return nil, err
 	}
 
-	d.volumes, err = volumesservice.NewVolumeService(config.Root, d.PluginStore, rootIDs, d)
+	d.volumes, err = volumesservice.NewVolumeService(config.Root, d.PluginStore, idMapping, d)
 	if err != nil {
 		return nil, err
 	}
@@ -925,6 +977,35 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 		return nil, err
 	}
 
+	partialDownloads, err := distribution.NewPartialDownloads(filepath.Join(imageRoot, "partial"))
+	if err != nil {
+		return nil, err
+	}
+	if err := partialDownloads.Prune(distribution.DefaultPartialDownloadMaxAge); err != nil {
+		logrus.WithError(err).Warn("Failed to remove stale partial downloads")
+	}
+
+	var blobMirrors *distribution.BlobMirrors
+	if len(config.BlobPeers) > 0 || config.BlobCacheDir != "" {
+		var tlsConfig *tls.Config
+		if len(config.BlobPeers) > 0 {
+			// peers verify the certificate of the daemon as a client
+			tlsConfig, err = tlsconfig.Client(tlsconfig.Options{
+				CAFile:             config.CommonTLSOptions.CAFile,
+				CertFile:           config.CommonTLSOptions.CertFile,
+				KeyFile:            config.CommonTLSOptions.KeyFile,
+				ExclusiveRootPools: true,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		blobMirrors, err = distribution.NewBlobMirrors(config.BlobPeers, config.BlobCacheDir, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// No content-addressability migration on Windows as it never supported pre-CA
 	if runtime.GOOS != "windows" {
 		migrationStart := time.Now()
@@ -955,9 +1036,32 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 	}
 	d.execCommands = exec.NewStore()
 	d.idIndex = truncindex.NewTruncIndex([]string{})
-	d.statsCollector = d.newStatsCollector(1 * time.Second)
+	var statsHistory *stats.HistoryOptions
+	if config.Stats.History.Enabled {
+		interval, retention, err := config.Stats.History.Durations()
+		if err != nil {
+			return nil, err
+		}
+		statsHistory = &stats.HistoryOptions{Interval: interval, Retention: retention}
+	}
+	d.statsCollector = d.newStatsCollector(1*time.Second, statsHistory)
 
-	d.EventsService = events.New()
+	if config.Events.Journal.Enabled {
+		maxSize, maxAge, err := config.Events.Journal.Limits()
+		if err != nil {
+			return nil, err
+		}
+		journal, err := events.NewJournal(filepath.Join(config.Root, "events", "journal.db"), events.JournalOptions{
+			MaxSize: maxSize,
+			MaxAge:  maxAge,
+		})
+		if err != nil {
+			return nil, err
+		}
+		d.EventsService = events.NewWithJournal(journal)
+	} else {
+		d.EventsService = events.New()
+	}
 	d.root = config.Root
 	d.idMapping = idMapping
 	d.seccompEnabled = sysInfo.Seccomp
@@ -969,6 +1073,7 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 	// used above to run migration. They could be initialized in ImageService
 	// if migration is called from daemon/images. layerStore might move as well.
 	d.imageService = images.NewImageService(images.ImageServiceConfig{
+		BlobMirrors:               blobMirrors,
 		ContainerStore:            d.containers,
 		DistributionMetadataStore: distributionMetadataStore,
 		EventsService:             d.EventsService,
@@ -976,6 +1081,9 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 		LayerStores:               layerStores,
 		MaxConcurrentDownloads:    *config.MaxConcurrentDownloads,
 		MaxConcurrentUploads:      *config.MaxConcurrentUploads,
+		MaxDownloadBandwidth:      config.MaxDownloadBandwidth.Value(),
+		MaxUploadBandwidth:        config.MaxUploadBandwidth.Value(),
+		PartialDownloads:          partialDownloads,
 		ReferenceStore:            rs,
 		RegistryService:           registryService,
 		TrustKey:                  trustKey,
@@ -993,6 +1101,8 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 	}
 	close(d.startupDone)
 
+	d.startImageGC(config.Images.GC)
+
 	// FIXME: this method never returns an error
 	info, _ := d.SystemInfo()
 
@@ -1120,6 +1230,10 @@ func (daemon *Daemon) Shutdown() error {
 		}
 	}
 
+	if daemon.stopImageGC != nil {
+		daemon.stopImageGC()
+	}
+
 	if daemon.imageService != nil {
 		daemon.imageService.Cleanup()
 	}
@@ -1144,6 +1258,12 @@ func (daemon *Daemon) Shutdown() error {
 		daemon.containerdCli.Close()
 	}
 
+	if daemon.EventsService != nil {
+		if err := daemon.EventsService.Close(); err != nil {
+			logrus.Errorf("Error closing event journal: %v", err)
+		}
+	}
+
 	return daemon.cleanupMounts()
 }
 
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/container"
@@ -118,7 +119,7 @@ func initDaemonWithVolumeStore(tmp string) (*Daemon, error) {
 		repository: tmp,
 		root:       tmp,
 	}
-	daemon.volumes, err = volumesservice.NewVolumeService(tmp, nil, idtools.Identity{UID: 0, GID: 0}, daemon)
+	daemon.volumes, err = volumesservice.NewVolumeService(tmp, nil, &idtools.IdentityMapping{}, daemon)
 	if err != nil {
 		return nil, err
 	}
@@ -309,6 +310,21 @@ func TestValidateContainerIsolation(t *testing.T) {
 	assert.Check(t, is.Error(err, "invalid isolation 'invalid' on "+runtime.GOOS))
 }
 
+func TestValidateRestartDelays(t *testing.T) {
+	d := Daemon{}
+
+	hostConfig := &containertypes.HostConfig{RestartPolicy: containertypes.RestartPolicy{Name: "always", InitialDelay: 2 * time.Minute}}
+	_, err := d.verifyContainerSettings(runtime.GOOS, hostConfig, nil, false)
+	assert.Check(t, is.Error(err, "initial restart delay (2m0s) cannot be greater than maximum restart delay (1m0s)"))
+
+	// the initial delay is valid with a longer maximum delay, and the next
+	// setting is validated
+	hostConfig.RestartPolicy.MaxDelay = 5 * time.Minute
+	hostConfig.RestartPolicy.Multiplier = 0.5
+	_, err = d.verifyContainerSettings(runtime.GOOS, hostConfig, nil, false)
+	assert.Check(t, is.Error(err, "restart delay multiplier cannot be less than 1"))
+}
+
 func TestFindNetworkErrorType(t *testing.T) {
 	d := Daemon{}
 	_, err := d.FindNetwork("fakeNet")
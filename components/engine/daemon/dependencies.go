@@ -0,0 +1,309 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+)
+
+const (
+	// dependencyHealthyTimeout is how long to wait for a dependency with
+	// the "healthy" condition to become healthy.
+	dependencyHealthyTimeout = 5 * time.Minute
+
+	// dependencyPollInterval is the interval at which the health status of
+	// a dependency is checked while waiting for it.
+	dependencyPollInterval = 100 * time.Millisecond
+)
+
+// startupDependency is an edge of the startup graph: a container that has
+// to be started before the container depending on it.
+type startupDependency struct {
+	container *container.Container
+	condition containertypes.DependencyCondition
+	// explicit is set for the dependencies configured with DependsOn, as
+	// opposed to the ones implied by links and network modes.
+	explicit bool
+}
+
+// startupDependencies returns the containers that have to be started
+// before c: its linked containers, the container whose network namespace
+// it joins, and its explicit dependencies.
+func (daemon *Daemon) startupDependencies(c *container.Container) ([]startupDependency, error) {
+	var deps []startupDependency
+	for _, child := range daemon.children(c) {
+		deps = append(deps, startupDependency{container: child})
+	}
+	if c.HostConfig.NetworkMode.IsContainer() {
+		nc, err := daemon.GetContainer(c.HostConfig.NetworkMode.ConnectedContainer())
+		if err != nil {
+			return nil, err
+		}
+		deps = append(deps, startupDependency{container: nc})
+	}
+	for _, d := range c.HostConfig.DependsOn {
+		dc, err := daemon.GetContainer(d.Container)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get dependency %s", d.Container)
+		}
+		deps = append(deps, startupDependency{container: dc, condition: d.Condition, explicit: true})
+	}
+	return deps, nil
+}
+
+// startupOrder returns the given containers and all the containers they
+// depend on, sorted so that every container comes after its dependencies.
+// An error is returned if the dependencies form a cycle.
+func (daemon *Daemon) startupOrder(containers []*container.Container) ([]*container.Container, error) {
+	byName := make(map[string]*container.Container)
+	name := func(c *container.Container) string {
+		n := strings.TrimPrefix(c.Name, "/")
+		byName[n] = c
+		return n
+	}
+
+	var roots []string
+	for _, c := range containers {
+		roots = append(roots, name(c))
+	}
+	order, err := sortDependencies(roots, func(n string) ([]string, error) {
+		deps, err := daemon.startupDependencies(byName[n])
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, d := range deps {
+			names = append(names, name(d.container))
+		}
+		return names, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]*container.Container, 0, len(order))
+	for _, n := range order {
+		sorted = append(sorted, byName[n])
+	}
+	return sorted, nil
+}
+
+// checkDependencyCycle returns an error if the container being created, with
+// the given host config, would be part of a dependency cycle. Dependencies
+// are referenced by name, so existing containers may already depend on it.
+func (daemon *Daemon) checkDependencyCycle(c *container.Container, hostConfig *containertypes.HostConfig) error {
+	if len(hostConfig.DependsOn) == 0 {
+		return nil
+	}
+	name := strings.TrimPrefix(c.Name, "/")
+
+	// resolve returns the name of the referenced container, or an empty
+	// string if it does not exist; missing dependencies are reported when
+	// the container is started.
+	resolve := func(ref string) string {
+		if strings.TrimPrefix(ref, "/") == name || ref == c.ID {
+			return name
+		}
+		dc, err := daemon.GetContainer(ref)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimPrefix(dc.Name, "/")
+	}
+
+	_, err := sortDependencies([]string{name}, func(n string) ([]string, error) {
+		var refs []string
+		if n == name {
+			for _, d := range hostConfig.DependsOn {
+				refs = append(refs, d.Container)
+			}
+		} else {
+			dc, err := daemon.GetContainer(n)
+			if err != nil {
+				return nil, nil
+			}
+			for _, child := range daemon.children(dc) {
+				refs = append(refs, child.ID)
+			}
+			if dc.HostConfig.NetworkMode.IsContainer() {
+				refs = append(refs, dc.HostConfig.NetworkMode.ConnectedContainer())
+			}
+			for _, d := range dc.HostConfig.DependsOn {
+				refs = append(refs, d.Container)
+			}
+		}
+
+		var deps []string
+		for _, ref := range refs {
+			if dn := resolve(ref); dn != "" {
+				deps = append(deps, dn)
+			}
+		}
+		return deps, nil
+	})
+	return err
+}
+
+// sortDependencies does a depth-first traversal of the dependency graph
+// starting at roots and returns the visited nodes, each one after its
+// dependencies.
+func sortDependencies(roots []string, dependencies func(string) ([]string, error)) ([]string, error) {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	var (
+		order []string
+		path  []string
+		state = make(map[string]int)
+		visit func(string) error
+	)
+	visit = func(n string) error {
+		switch state[n] {
+		case visited:
+			return nil
+		case visiting:
+			for i := range path {
+				if path[i] == n {
+					cycle := append(append([]string{}, path[i:]...), n)
+					return errdefs.InvalidParameter(errors.Errorf("dependency cycle detected: %s", strings.Join(cycle, " -> ")))
+				}
+			}
+		}
+		state[n] = visiting
+		path = append(path, n)
+
+		deps, err := dependencies(n)
+		if err != nil {
+			return err
+		}
+		for _, d := range deps {
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[n] = visited
+		order = append(order, n)
+		return nil
+	}
+
+	for _, r := range roots {
+		if err := visit(r); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// startDependencies starts the explicit dependencies of c that are not
+// running, in dependency order, and then waits for the dependencies of c
+// to meet their condition, until ctx is done.
+func (daemon *Daemon) startDependencies(ctx context.Context, c *container.Container) error {
+	if len(c.HostConfig.DependsOn) == 0 {
+		return nil
+	}
+	order, err := daemon.startupOrder([]*container.Container{c})
+	if err != nil {
+		return err
+	}
+
+	// Only the containers that c explicitly depends on, directly or not,
+	// are started. Linked containers and network namespace owners are
+	// expected to be running, as before.
+	required := map[string]bool{c.ID: true}
+	for i := len(order) - 1; i >= 0; i-- {
+		if !required[order[i].ID] {
+			continue
+		}
+		deps, err := daemon.startupDependencies(order[i])
+		if err != nil {
+			return err
+		}
+		for _, d := range deps {
+			if d.explicit {
+				required[d.container.ID] = true
+			}
+		}
+	}
+
+	for _, dc := range order {
+		if dc == c || !required[dc.ID] || dc.IsRunning() {
+			continue
+		}
+		if err := daemon.waitForDependencies(ctx, dc); err != nil {
+			return err
+		}
+		if err := daemon.containerStart(dc, "", "", true); err != nil {
+			return errors.Wrapf(err, "failed to start dependency %s", strings.TrimPrefix(dc.Name, "/"))
+		}
+	}
+	return daemon.waitForDependencies(ctx, c)
+}
+
+// waitForDependencies waits for the explicit dependencies of c to meet
+// their condition, until ctx is done.
+func (daemon *Daemon) waitForDependencies(ctx context.Context, c *container.Container) error {
+	deps, err := daemon.startupDependencies(c)
+	if err != nil {
+		return err
+	}
+	for _, d := range deps {
+		if !d.explicit {
+			continue
+		}
+		waitCtx, cancel := context.WithTimeout(ctx, dependencyHealthyTimeout)
+		err := waitForDependency(waitCtx, d)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitForDependency blocks until the dependency meets its condition. It
+// returns an error if the dependency is not running, or if it does not
+// become healthy before ctx is done.
+func waitForDependency(ctx context.Context, d startupDependency) error {
+	name := strings.TrimPrefix(d.container.Name, "/")
+	if !d.container.IsRunning() {
+		return errdefs.Conflict(errors.Errorf("dependency %s is not running", name))
+	}
+	if d.condition != containertypes.DependencyConditionHealthy {
+		return nil
+	}
+
+	ticker := time.NewTicker(dependencyPollInterval)
+	defer ticker.Stop()
+	for {
+		d.container.Lock()
+		running, health := d.container.Running, d.container.Health
+		d.container.Unlock()
+
+		switch {
+		case health == nil:
+			return errdefs.InvalidParameter(errors.Errorf("dependency %s has no healthcheck", name))
+		case !running:
+			return errdefs.Conflict(errors.Errorf("dependency %s exited before becoming healthy", name))
+		case health.Status() == types.Healthy:
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return errdefs.Unavailable(errors.Errorf("timed out waiting for dependency %s to become healthy", name))
+			}
+			return errors.Wrapf(ctx.Err(), "stopped waiting for dependency %s to become healthy", name)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/truncindex"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func graph(edges map[string][]string) func(string) ([]string, error) {
+	return func(n string) ([]string, error) {
+		return edges[n], nil
+	}
+}
+
+func TestSortDependencies(t *testing.T) {
+	edges := map[string][]string{
+		"web":    {"app"},
+		"app":    {"db", "cache"},
+		"cache":  {"db"},
+		"worker": {"db"},
+	}
+
+	order, err := sortDependencies([]string{"web", "worker"}, graph(edges))
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"db", "cache", "app", "web", "worker"}, order))
+
+	order, err = sortDependencies([]string{"cache"}, graph(edges))
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"db", "cache"}, order))
+}
+
+func TestSortDependenciesCycle(t *testing.T) {
+	edges := map[string][]string{
+		"web": {"app"},
+		"app": {"db"},
+		"db":  {"app"},
+	}
+
+	_, err := sortDependencies([]string{"web"}, graph(edges))
+	assert.Check(t, errdefs.IsInvalidParameter(err))
+	assert.Check(t, is.Error(err, "dependency cycle detected: app -> db -> app"))
+
+	_, err = sortDependencies([]string{"self"}, graph(map[string][]string{"self": {"self"}}))
+	assert.Check(t, is.Error(err, "dependency cycle detected: self -> self"))
+}
+
+func TestCheckDependencyCycle(t *testing.T) {
+	containersReplica, err := container.NewViewDB()
+	assert.NilError(t, err)
+	daemon := &Daemon{
+		containers:        container.NewMemoryStore(),
+		containersReplica: containersReplica,
+		idIndex:           truncindex.NewTruncIndex([]string{}),
+		linkIndex:         newLinkIndex(),
+	}
+	add := func(id, name string, dependsOn ...string) *container.Container {
+		c := &container.Container{ID: id, Name: "/" + name, HostConfig: &containertypes.HostConfig{}}
+		for _, d := range dependsOn {
+			c.HostConfig.DependsOn = append(c.HostConfig.DependsOn, containertypes.Dependency{Container: d})
+		}
+		daemon.containers.Add(c.ID, c)
+		daemon.idIndex.Add(c.ID)
+		_, err := daemon.reserveName(c.ID, c.Name)
+		assert.NilError(t, err)
+		return c
+	}
+	// "app" was created with a dependency on "web", which does not exist yet
+	add("1111", "db")
+	add("2222", "app", "db", "web")
+
+	newContainer := func(name string) *container.Container {
+		return &container.Container{ID: name + "-id", Name: "/" + name}
+	}
+	dependsOn := func(names ...string) *containertypes.HostConfig {
+		hostConfig := &containertypes.HostConfig{}
+		for _, n := range names {
+			hostConfig.DependsOn = append(hostConfig.DependsOn, containertypes.Dependency{Container: n})
+		}
+		return hostConfig
+	}
+
+	assert.Check(t, daemon.checkDependencyCycle(newContainer("web"), &containertypes.HostConfig{}))
+	assert.Check(t, daemon.checkDependencyCycle(newContainer("web"), dependsOn("db", "missing")))
+	assert.Check(t, daemon.checkDependencyCycle(newContainer("worker"), dependsOn("app")))
+
+	err = daemon.checkDependencyCycle(newContainer("web"), dependsOn("app"))
+	assert.Check(t, errdefs.IsInvalidParameter(err))
+	assert.Check(t, is.Error(err, "dependency cycle detected: web -> app -> web"))
+
+	err = daemon.checkDependencyCycle(newContainer("self"), dependsOn("/self"))
+	assert.Check(t, is.Error(err, "dependency cycle detected: self -> self"))
+}
+
+func TestWaitForDependencyCancelled(t *testing.T) {
+	c := &container.Container{ID: "1111", Name: "/db", State: container.NewState()}
+	c.State.SetRunning(1234, true)
+	c.State.Health = &container.Health{}
+	c.State.Health.SetStatus(types.Starting)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := waitForDependency(ctx, startupDependency{container: c, condition: containertypes.DependencyConditionHealthy})
+	assert.Check(t, is.ErrorContains(err, "stopped waiting for dependency db to become healthy"))
+	assert.Check(t, errors.Cause(err) == context.Canceled)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	err = waitForDependency(ctx, startupDependency{container: c, condition: containertypes.DependencyConditionHealthy})
+	assert.Check(t, errdefs.IsUnavailable(err))
+}
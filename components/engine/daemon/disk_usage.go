@@ -48,3 +48,9 @@ func (daemon *Daemon) SystemDiskUsage(ctx context.Context) (*types.DiskUsage, er
 		Images:     allImages,
 	}, nil
 }
+
+// LayersDiskUsage returns the disk usage of each image layer, and the disk
+// space that removing each image would reclaim.
+func (daemon *Daemon) LayersDiskUsage(ctx context.Context) ([]*types.LayerUsage, []*types.ImageReclaimableSpace, error) {
+	return daemon.imageService.LayersUsage(ctx)
+}
@@ -104,6 +104,15 @@ func (daemon *Daemon) SubscribeToEvents(since, until time.Time, filter filters.A
 	return daemon.EventsService.SubscribeTopic(since, until, ef)
 }
 
+// EventsHistory returns at most limit past events that were emitted until
+// the given time and match the filter, oldest first: the first events after
+// the event with the after cursor or emitted since the given time, or else
+// the last events.
+func (daemon *Daemon) EventsHistory(since, until time.Time, after string, filter filters.Args, limit int) ([]events.Message, error) {
+	ef := daemonevents.NewFilter(filter)
+	return daemon.EventsService.History(since, until, after, ef, limit)
+}
+
 // UnsubscribeFromEvents stops the event subscription for a client by closing the
 // channel where the daemon sends events to.
 func (daemon *Daemon) UnsubscribeFromEvents(listener chan interface{}) {
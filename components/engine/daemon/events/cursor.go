@@ -0,0 +1,47 @@
+package events // import "github.com/docker/docker/daemon/events"
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/docker/docker/errdefs"
+)
+
+// position is the position of an event in the history. Events are ordered
+// by time, and events with the same time by the order in which they were
+// published.
+type position struct {
+	timeNano int64
+	seq      uint64
+}
+
+var (
+	firstPosition = position{}
+	lastPosition  = position{timeNano: math.MaxInt64, seq: math.MaxUint64}
+)
+
+// parseCursor returns the position of the event with the given cursor.
+func parseCursor(cursor string) (position, error) {
+	var p position
+	if n, err := fmt.Sscanf(cursor, "%d-%d", &p.timeNano, &p.seq); err != nil || n != 2 || cursor != p.String() {
+		return position{}, errdefs.InvalidParameter(fmt.Errorf("invalid event cursor %q", cursor))
+	}
+	return p, nil
+}
+
+// String returns the cursor of the event at position p.
+func (p position) String() string {
+	return fmt.Sprintf("%d-%d", p.timeNano, p.seq)
+}
+
+func (p position) less(o position) bool {
+	return p.timeNano < o.timeNano || (p.timeNano == o.timeNano && p.seq < o.seq)
+}
+
+// next returns the first position after p.
+func (p position) next() position {
+	if p.seq == math.MaxUint64 {
+		return position{timeNano: p.timeNano + 1}
+	}
+	return position{timeNano: p.timeNano, seq: p.seq + 1}
+}
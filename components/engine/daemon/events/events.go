@@ -1,11 +1,13 @@
 package events // import "github.com/docker/docker/daemon/events"
 
 import (
+	"sort"
 	"sync"
 	"time"
 
 	eventtypes "github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/pkg/pubsub"
+	"github.com/sirupsen/logrus"
 )
 
 const (
@@ -17,7 +19,12 @@ const (
 type Events struct {
 	mu     sync.Mutex
 	events []eventtypes.Message
-	pub    *pubsub.Publisher
+	// unwritten are the events discarded from the buffer that the journal
+	// has not written to disk yet, oldest first.
+	unwritten []eventtypes.Message
+	seq       uint64 // sequence number of the last published event
+	pub       *pubsub.Publisher
+	journal   *Journal
 }
 
 // New returns new *Events instance
@@ -28,6 +35,15 @@ func New() *Events {
 	}
 }
 
+// NewWithJournal returns new *Events instance that records events in the
+// given journal and replays past events from it.
+func NewWithJournal(j *Journal) *Events {
+	e := New()
+	e.journal = j
+	e.seq = j.Sequence()
+	return e
+}
+
 // Subscribe adds new listener to events, returns slice of 256 stored
 // last events, a channel in which you can expect new events (in form
 // of interface{}, so you need type assertion), and a function to call
@@ -46,20 +62,29 @@ func (e *Events) Subscribe() ([]eventtypes.Message, chan interface{}, func()) {
 	return current, l, cancel
 }
 
-// SubscribeTopic adds new listener to events, returns slice of 256 stored
-// last events, a channel in which you can expect new events (in form
+// SubscribeTopic adds new listener to events, returns slice of stored
+// past events (the last 256 events, or the events in the journal if one
+// is configured), a channel in which you can expect new events (in form
 // of interface{}, so you need type assertion).
 func (e *Events) SubscribeTopic(since, until time.Time, ef *Filter) ([]eventtypes.Message, chan interface{}) {
 	eventSubscribers.Inc()
-	e.mu.Lock()
+	topic := topicFilter(ef)
+	from, to := timeRange(since, until)
+	load := !since.IsZero() || !until.IsZero()
 
-	var topic func(m interface{}) bool
-	if ef != nil && ef.filter.Len() > 0 {
-		topic = func(m interface{}) bool { return ef.Include(m.(eventtypes.Message)) }
+	// The journal is read after subscribing, outside of the lock, to not
+	// block the publication of events while the history is loaded. It only
+	// returns the events published before the buffered ones, so no event
+	// is missed or received twice.
+	e.mu.Lock()
+	var (
+		buffered []eventtypes.Message
+		boundary uint64
+	)
+	if load {
+		buffered, boundary = e.pendingEvents(from, to, topic)
 	}
 
-	buffered := e.loadBufferedEvents(since, until, topic)
-
 	var ch chan interface{}
 	if topic != nil {
 		ch = e.pub.SubscribeTopic(topic)
@@ -67,9 +92,51 @@ func (e *Events) SubscribeTopic(since, until time.Time, ef *Filter) ([]eventtype
 		// Subscribe to all events if there are no filters
 		ch = e.pub.Subscribe()
 	}
+	e.mu.Unlock()
+
+	if !load {
+		return nil, ch
+	}
+	history, err := e.readHistory(from, to, topic, 0, false, buffered, boundary)
+	if err != nil {
+		logrus.WithError(err).Error("failed to read events from event journal")
+	}
+	return history, ch
+}
+
+// History returns at most limit stored events that were emitted until the
+// given time and match the filter, oldest first. A limit of 0 returns all
+// matching events.
+//
+// If after is the cursor of an event, the first events after it are
+// returned. Otherwise, if since is set, the first events emitted since then
+// are returned, and if neither is set, the last events are returned.
+func (e *Events) History(since, until time.Time, after string, ef *Filter, limit int) ([]eventtypes.Message, error) {
+	from, to := timeRange(since, until)
+	if after != "" {
+		p, err := parseCursor(after)
+		if err != nil {
+			return nil, err
+		}
+		if from.less(p.next()) {
+			from = p.next()
+		}
+	}
+	last := since.IsZero() && after == ""
+	topic := topicFilter(ef)
 
+	e.mu.Lock()
+	buffered, boundary := e.pendingEvents(from, to, topic)
 	e.mu.Unlock()
-	return buffered, ch
+	return e.readHistory(from, to, topic, limit, last, buffered, boundary)
+}
+
+// Close stops recording events in the journal, if any.
+func (e *Events) Close() error {
+	if e.journal == nil {
+		return nil
+	}
+	return e.journal.Close()
 }
 
 // Evict evicts listener from pubsub
@@ -110,14 +177,29 @@ func (e *Events) PublishMessage(jm eventtypes.Message) {
 	eventsCounter.Inc()
 
 	e.mu.Lock()
+	e.seq++
+	seq := e.seq
+	jm.Cursor = position{timeNano: jm.TimeNano, seq: seq}.String()
 	if len(e.events) == cap(e.events) {
-		// discard oldest event
+		// discard oldest event, keeping it until it is in the journal
+		if e.journal != nil {
+			e.trimUnwritten()
+			e.unwritten = append(e.unwritten, e.events[0])
+		}
 		copy(e.events, e.events[1:])
 		e.events[len(e.events)-1] = jm
 	} else {
 		e.events = append(e.events, jm)
 	}
 	e.mu.Unlock()
+	if e.journal != nil {
+		// Append blocks while the journal is behind on writing events to
+		// disk, which must not block the readers of the history too. The
+		// journal orders events by their sequence number, so appending them
+		// out of order is fine, and the history keeps the events that are
+		// not written yet in memory.
+		e.journal.Append(jm, seq)
+	}
 	e.pub.Publish(jm)
 }
 
@@ -126,34 +208,132 @@ func (e *Events) SubscribersCount() int {
 	return e.pub.Len()
 }
 
+// pendingEvents returns the events kept in memory that were emitted
+// between the from and to positions (both inclusive), filtered with the
+// topic function if it's not nil, and the sequence number of the oldest
+// event kept in memory. All the events published before that one are in
+// the journal. It must be called with e.mu held.
+func (e *Events) pendingEvents(from, to position, topic func(interface{}) bool) ([]eventtypes.Message, uint64) {
+	if e.journal != nil {
+		e.trimUnwritten()
+	}
+
+	boundary := e.seq + 1
+	if len(e.unwritten) > 0 {
+		boundary = eventPosition(e.unwritten[0]).seq
+	} else if len(e.events) > 0 {
+		boundary = eventPosition(e.events[0]).seq
+	}
+
+	var pending []eventtypes.Message
+	for _, ev := range e.unwritten {
+		if p := eventPosition(ev); !p.less(from) && !to.less(p) && (topic == nil || topic(ev)) {
+			pending = append(pending, ev)
+		}
+	}
+	return append(pending, e.bufferedEvents(from, to, topic)...), boundary
+}
+
+// trimUnwritten discards the unwritten events that the journal has written
+// to disk since. It must be called with e.mu held.
+func (e *Events) trimUnwritten() {
+	written := e.journal.Written()
+	i := 0
+	for i < len(e.unwritten) && eventPosition(e.unwritten[i]).seq <= written {
+		i++
+	}
+	e.unwritten = append(e.unwritten[:0], e.unwritten[i:]...)
+}
+
+// readHistory returns the events between the from and to positions (both
+// inclusive) that are in memory, given by pendingEvents, or in the journal,
+// filtered with the topic function if it's not nil. At most limit events
+// are returned, unless limit is 0: the first ones, or the last ones if
+// last is true.
+func (e *Events) readHistory(from, to position, topic func(interface{}) bool, limit int, last bool, pending []eventtypes.Message, boundary uint64) ([]eventtypes.Message, error) {
+	if e.journal == nil || (last && limit > 0 && len(pending) >= limit) {
+		return truncateHistory(pending, limit, last), nil
+	}
+
+	// The events kept in memory may not have been written to disk yet, so
+	// the journal is only used for the ones published before them.
+	journalTopic := func(m interface{}) bool {
+		return eventPosition(m.(eventtypes.Message)).seq < boundary && (topic == nil || topic(m))
+	}
+	history, err := e.journal.Read(from, to, journalTopic, limit, last)
+	history = append(history, pending...)
+	sort.SliceStable(history, func(i, j int) bool {
+		return eventPosition(history[i]).less(eventPosition(history[j]))
+	})
+	return truncateHistory(history, limit, last), err
+}
+
+func truncateHistory(history []eventtypes.Message, limit int, last bool) []eventtypes.Message {
+	if limit == 0 || len(history) <= limit {
+		return history
+	}
+	if last {
+		return history[len(history)-limit:]
+	}
+	return history[:limit]
+}
+
+// timeRange returns the positions of the first event emitted since the given
+// time, and of the last one emitted until the given time. Zero times do not
+// bound the range.
+func timeRange(since, until time.Time) (from, to position) {
+	from, to = firstPosition, lastPosition
+	if !since.IsZero() {
+		from = position{timeNano: since.UnixNano()}
+	}
+	if !until.IsZero() {
+		to = position{timeNano: until.UnixNano(), seq: lastPosition.seq}
+	}
+	return from, to
+}
+
+// eventPosition returns the position of a published event in the history.
+func eventPosition(m eventtypes.Message) position {
+	p, err := parseCursor(m.Cursor)
+	if err != nil {
+		return position{timeNano: m.TimeNano}
+	}
+	return p
+}
+
+func topicFilter(ef *Filter) func(interface{}) bool {
+	if ef == nil || ef.filter.Len() == 0 {
+		return nil
+	}
+	return func(m interface{}) bool { return ef.Include(m.(eventtypes.Message)) }
+}
+
 // loadBufferedEvents iterates over the cached events in the buffer
 // and returns those that were emitted between two specific dates.
 // It uses `time.Unix(seconds, nanoseconds)` to generate valid dates with those arguments.
 // It filters those buffered messages with a topic function if it's not nil, otherwise it adds all messages.
 func (e *Events) loadBufferedEvents(since, until time.Time, topic func(interface{}) bool) []eventtypes.Message {
-	var buffered []eventtypes.Message
 	if since.IsZero() && until.IsZero() {
-		return buffered
-	}
-
-	var sinceNanoUnix int64
-	if !since.IsZero() {
-		sinceNanoUnix = since.UnixNano()
-	}
-
-	var untilNanoUnix int64
-	if !until.IsZero() {
-		untilNanoUnix = until.UnixNano()
+		return nil
 	}
+	from, to := timeRange(since, until)
+	return e.bufferedEvents(from, to, topic)
+}
 
+// bufferedEvents returns the cached events in the buffer between the from
+// and to positions (both inclusive), filtered with the topic function if
+// it's not nil.
+func (e *Events) bufferedEvents(from, to position, topic func(interface{}) bool) []eventtypes.Message {
+	var buffered []eventtypes.Message
 	for i := len(e.events) - 1; i >= 0; i-- {
 		ev := e.events[i]
+		p := eventPosition(ev)
 
-		if ev.TimeNano < sinceNanoUnix {
+		if p.less(from) {
 			break
 		}
 
-		if untilNanoUnix > 0 && ev.TimeNano > untilNanoUnix {
+		if to.less(p) {
 			continue
 		}
 
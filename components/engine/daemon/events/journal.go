@@ -0,0 +1,340 @@
+package events // import "github.com/docker/docker/daemon/events"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	eventtypes "github.com/docker/docker/api/types/events"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// journalQueueSize is the number of events that can be waiting to be
+	// written to disk before Append starts to block.
+	journalQueueSize = 1024
+
+	// journalPruneInterval is the interval at which the retention policy
+	// is applied, in addition to after every write that exceeds the size
+	// limit.
+	journalPruneInterval = time.Minute
+)
+
+var journalBucketName = []byte("events")
+
+// journalEntry is an event waiting to be written to the journal.
+type journalEntry struct {
+	msg eventtypes.Message
+	seq uint64
+}
+
+// JournalOptions holds the retention policy of a Journal. Zero values
+// disable the corresponding limit.
+type JournalOptions struct {
+	// MaxSize is the approximate number of bytes of encoded events to keep.
+	MaxSize int64
+	// MaxAge is the maximum age of the events to keep.
+	MaxAge time.Duration
+}
+
+// Journal is a persistent history of events backed by a bolt database.
+// Unlike the in-memory buffer of Events, it survives daemon restarts and
+// is only bounded by its retention policy.
+type Journal struct {
+	db   *bolt.DB
+	opts JournalOptions
+
+	mu   sync.Mutex
+	size int64  // approximate size of the stored events, in bytes
+	seq  uint64 // sequence number of the last event, when opened
+
+	// written is the sequence number up to which all the appended events
+	// have been written, or dropped if writing them failed. The events
+	// written ahead of it, as they can be appended out of order, are in
+	// writtenAhead.
+	writtenMu    sync.Mutex
+	written      uint64
+	writtenAhead map[uint64]bool
+
+	queue     chan journalEntry
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewJournal opens (or creates) the event journal at path and starts
+// writing events to it in the background.
+func NewJournal(path string, opts JournalOptions) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening event journal")
+	}
+
+	j := &Journal{
+		db:           db,
+		opts:         opts,
+		writtenAhead: make(map[uint64]bool),
+		queue:        make(chan journalEntry, journalQueueSize),
+		done:         make(chan struct{}),
+		stopped:      make(chan struct{}),
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(journalBucketName)
+		if err != nil {
+			return err
+		}
+		j.seq = b.Sequence()
+		j.written = j.seq
+		return b.ForEach(func(k, v []byte) error {
+			j.size += int64(len(k) + len(v))
+			return nil
+		})
+	}); err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "error initializing event journal")
+	}
+	if err := j.prune(time.Now()); err != nil {
+		logrus.WithError(err).Warn("failed to apply event journal retention policy")
+	}
+
+	go j.run()
+	return j, nil
+}
+
+// Append queues an event to be written to the journal. seq is the sequence
+// number of the event, which orders the events with the same time.
+func (j *Journal) Append(m eventtypes.Message, seq uint64) {
+	select {
+	case j.queue <- journalEntry{msg: m, seq: seq}:
+	case <-j.done:
+		j.markWritten([]journalEntry{{seq: seq}})
+	}
+}
+
+// Written returns the sequence number up to which all the events appended
+// to the journal have been written to disk.
+func (j *Journal) Written() uint64 {
+	j.writtenMu.Lock()
+	defer j.writtenMu.Unlock()
+	return j.written
+}
+
+// markWritten records that the events of batch are done with, whether
+// they were written or not.
+func (j *Journal) markWritten(batch []journalEntry) {
+	j.writtenMu.Lock()
+	defer j.writtenMu.Unlock()
+	for _, entry := range batch {
+		if entry.seq > j.written {
+			j.writtenAhead[entry.seq] = true
+		}
+	}
+	for j.writtenAhead[j.written+1] {
+		delete(j.writtenAhead, j.written+1)
+		j.written++
+	}
+}
+
+// Sequence returns the sequence number of the last event in the journal
+// when it was opened.
+func (j *Journal) Sequence() uint64 {
+	return j.seq
+}
+
+// Read returns the events stored between the from and to positions (both
+// inclusive), oldest first. Events for which topic returns false are
+// skipped. At most limit events are returned, unless limit is 0: the first
+// ones, or the last ones if last is true.
+func (j *Journal) Read(from, to position, topic func(interface{}) bool, limit int, last bool) ([]eventtypes.Message, error) {
+	var events []eventtypes.Message
+	start, end := journalKey(from), journalKey(to)
+	err := j.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(journalBucketName).Cursor()
+		var k, v []byte
+		next := c.Next
+		if last {
+			next = c.Prev
+			if k, v = c.Seek(end); k == nil {
+				k, v = c.Last()
+			} else if bytes.Compare(k, end) > 0 {
+				k, v = c.Prev()
+			}
+		} else {
+			k, v = c.Seek(start)
+		}
+		for ; k != nil && bytes.Compare(k, start) >= 0 && bytes.Compare(k, end) <= 0; k, v = next() {
+			var m eventtypes.Message
+			if err := json.Unmarshal(v, &m); err != nil {
+				logrus.WithError(err).Warn("skipping corrupted event in event journal")
+				continue
+			}
+			if topic != nil && !topic(m) {
+				continue
+			}
+			events = append(events, m)
+			if limit > 0 && len(events) == limit {
+				break
+			}
+		}
+		return nil
+	})
+	if last {
+		for l, r := 0, len(events)-1; l < r; l, r = l+1, r-1 {
+			events[l], events[r] = events[r], events[l]
+		}
+	}
+	return events, err
+}
+
+// Close flushes the pending events and closes the journal.
+func (j *Journal) Close() error {
+	j.closeOnce.Do(func() {
+		close(j.done)
+	})
+	<-j.stopped
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.db.Close()
+}
+
+// run writes queued events to disk in batches until the journal is closed.
+func (j *Journal) run() {
+	defer close(j.stopped)
+
+	ticker := time.NewTicker(journalPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case entry := <-j.queue:
+			j.writeLogged(j.drain([]journalEntry{entry}))
+		case <-ticker.C:
+			if err := j.prune(time.Now()); err != nil {
+				logrus.WithError(err).Warn("failed to apply event journal retention policy")
+			}
+		case <-j.done:
+			j.writeLogged(j.drain(nil))
+			return
+		}
+	}
+}
+
+// drain appends the events that are currently queued to batch.
+func (j *Journal) drain(batch []journalEntry) []journalEntry {
+	for len(batch) < journalQueueSize {
+		select {
+		case entry := <-j.queue:
+			batch = append(batch, entry)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func (j *Journal) writeLogged(batch []journalEntry) {
+	if err := j.write(batch); err != nil {
+		logrus.WithError(err).Errorf("failed to write %d events to event journal", len(batch))
+	}
+	j.markWritten(batch)
+}
+
+func (j *Journal) write(batch []journalEntry) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var written int64
+	err := j.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(journalBucketName)
+		for _, entry := range batch {
+			v, err := json.Marshal(entry.msg)
+			if err != nil {
+				return err
+			}
+			k := journalKey(position{timeNano: entry.msg.TimeNano, seq: entry.seq})
+			if err := b.Put(k, v); err != nil {
+				return err
+			}
+			written += int64(len(k) + len(v))
+			if entry.seq > b.Sequence() {
+				if err := b.SetSequence(entry.seq); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	j.size += written
+	if j.opts.MaxSize > 0 && j.size > j.opts.MaxSize {
+		return j.pruneLocked(time.Now())
+	}
+	return nil
+}
+
+// prune removes the events that fall outside the retention policy.
+func (j *Journal) prune(now time.Time) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.pruneLocked(now)
+}
+
+func (j *Journal) pruneLocked(now time.Time) error {
+	var cutoff int64
+	if j.opts.MaxAge > 0 {
+		cutoff = now.Add(-j.opts.MaxAge).UnixNano()
+	}
+	var removed int64
+	err := j.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(journalBucketName)
+		var expired [][]byte
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			tooOld := journalKeyTime(k) < cutoff
+			tooBig := j.opts.MaxSize > 0 && j.size-removed > j.opts.MaxSize
+			if !tooOld && !tooBig {
+				break
+			}
+			removed += int64(len(k) + len(v))
+			expired = append(expired, k)
+		}
+		for _, k := range expired {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	j.size -= removed
+	return nil
+}
+
+// journalKey returns the key of the event at position p, which sorts by
+// event time and then by sequence number.
+func journalKey(p position) []byte {
+	k := make([]byte, 16)
+	binary.BigEndian.PutUint64(k, uint64(p.timeNano))
+	binary.BigEndian.PutUint64(k[8:], p.seq)
+	return k
+}
+
+func journalKeyTime(k []byte) int64 {
+	return int64(binary.BigEndian.Uint64(k))
+}
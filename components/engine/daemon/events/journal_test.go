@@ -0,0 +1,243 @@
+package events // import "github.com/docker/docker/daemon/events"
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/poll"
+)
+
+func newTestJournal(t *testing.T, dir string, opts JournalOptions) *Journal {
+	j, err := NewJournal(filepath.Join(dir, "events", "journal.db"), opts)
+	assert.NilError(t, err)
+	return j
+}
+
+func testMessage(id string, ts time.Time) events.Message {
+	return events.Message{
+		Type:     events.ContainerEventType,
+		Action:   "start",
+		Actor:    events.Actor{ID: id},
+		Time:     ts.Unix(),
+		TimeNano: ts.UnixNano(),
+	}
+}
+
+func messageIDs(messages []events.Message) []string {
+	var ids []string
+	for _, m := range messages {
+		ids = append(ids, m.Actor.ID)
+	}
+	return ids
+}
+
+func TestJournalPersistsAcrossRestarts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events-journal")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	base := time.Now().Add(-time.Hour)
+	j := newTestJournal(t, dir, JournalOptions{})
+	for i, id := range []string{"a", "b", "c", "d"} {
+		j.Append(testMessage(id, base.Add(time.Duration(i)*time.Minute)), uint64(i+1))
+	}
+	assert.NilError(t, j.Close())
+
+	j = newTestJournal(t, dir, JournalOptions{})
+	defer j.Close()
+	assert.Check(t, is.Equal(uint64(4), j.Sequence()))
+
+	from, to := timeRange(base, time.Time{})
+	all, err := j.Read(from, to, nil, 0, false)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"a", "b", "c", "d"}, messageIDs(all)))
+
+	from, to = timeRange(base.Add(time.Minute), base.Add(2*time.Minute))
+	window, err := j.Read(from, to, nil, 0, false)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"b", "c"}, messageIDs(window)))
+
+	page, err := j.Read(firstPosition, lastPosition, nil, 3, false)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"a", "b", "c"}, messageIDs(page)))
+
+	page, err = j.Read(firstPosition, lastPosition, nil, 3, true)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"b", "c", "d"}, messageIDs(page)))
+
+	page, err = j.Read(firstPosition, to, nil, 2, true)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"b", "c"}, messageIDs(page)))
+}
+
+func TestJournalRetention(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events-journal")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	j := newTestJournal(t, dir, JournalOptions{})
+	j.Append(testMessage("old", now.Add(-48*time.Hour)), 1)
+	j.Append(testMessage("new", now.Add(-time.Hour)), 2)
+	assert.NilError(t, j.Close())
+
+	j = newTestJournal(t, dir, JournalOptions{MaxAge: 24 * time.Hour})
+	all, err := j.Read(firstPosition, lastPosition, nil, 0, false)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"new"}, messageIDs(all)))
+	assert.NilError(t, j.Close())
+
+	j = newTestJournal(t, dir, JournalOptions{MaxSize: 1})
+	defer j.Close()
+	all, err = j.Read(firstPosition, lastPosition, nil, 0, false)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(all, 0))
+}
+
+func TestHistoryFromJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events-journal")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	since := time.Now().Add(-time.Minute)
+
+	e := NewWithJournal(newTestJournal(t, dir, JournalOptions{}))
+	e.Log("create", events.ContainerEventType, events.Actor{ID: "a"})
+	e.Log("create", events.ImageEventType, events.Actor{ID: "b"})
+	e.Log("start", events.ContainerEventType, events.Actor{ID: "c"})
+	assert.NilError(t, e.Close())
+
+	// A new instance has no events in memory, so history has to come
+	// from the journal written by the previous one.
+	e = NewWithJournal(newTestJournal(t, dir, JournalOptions{}))
+	defer e.Close()
+	e.Log("stop", events.ContainerEventType, events.Actor{ID: "d"})
+
+	buffered, l := e.SubscribeTopic(since, time.Time{}, nil)
+	defer e.Evict(l)
+	assert.Check(t, is.DeepEqual([]string{"a", "b", "c", "d"}, messageIDs(buffered)))
+
+	f := filters.NewArgs()
+	f.Add("type", events.ContainerEventType)
+	history, err := e.History(since, time.Time{}, "", NewFilter(f), 2)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"a", "c"}, messageIDs(history)))
+
+	// without since, the last events are returned
+	history, err = e.History(time.Time{}, time.Time{}, "", nil, 3)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"b", "c", "d"}, messageIDs(history)))
+
+	history, err = e.History(time.Time{}, time.Time{}, "", nil, 1)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"d"}, messageIDs(history)))
+}
+
+func TestHistoryPaging(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events-journal")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	// All the events have the same time, so they can only be told apart by
+	// their cursor.
+	ts := time.Now().Add(-time.Minute)
+	e := NewWithJournal(newTestJournal(t, dir, JournalOptions{}))
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		e.PublishMessage(testMessage(id, ts))
+	}
+	assert.NilError(t, e.Close())
+
+	e = NewWithJournal(newTestJournal(t, dir, JournalOptions{}))
+	defer e.Close()
+	e.PublishMessage(testMessage("f", ts))
+
+	var (
+		pages [][]string
+		after string
+	)
+	for {
+		page, err := e.History(time.Time{}, time.Time{}, after, nil, 2)
+		assert.NilError(t, err)
+		if after == "" {
+			// the first page holds the last events
+			assert.Check(t, is.DeepEqual([]string{"e", "f"}, messageIDs(page)))
+			page, err = e.History(ts, time.Time{}, "", nil, 2)
+			assert.NilError(t, err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		pages = append(pages, messageIDs(page))
+		after = page[len(page)-1].Cursor
+	}
+	assert.Check(t, is.DeepEqual([][]string{{"a", "b"}, {"c", "d"}, {"e", "f"}}, pages))
+
+	_, err = e.History(time.Time{}, time.Time{}, "not-a-cursor", nil, 2)
+	assert.Check(t, is.ErrorContains(err, "invalid event cursor"))
+}
+
+func TestHistoryWhileJournalIsBehind(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events-journal")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	ts := time.Now().Add(-time.Minute)
+	j := newTestJournal(t, dir, JournalOptions{})
+	e := NewWithJournal(j)
+	defer e.Close()
+
+	// Holding the lock of the journal stops it from writing events, so the
+	// events discarded from the buffer are only in memory.
+	j.mu.Lock()
+	total := eventsLimit + 10
+	for i := 0; i < total; i++ {
+		e.PublishMessage(testMessage(fmt.Sprint(i), ts))
+	}
+	history, err := e.History(ts, time.Time{}, "", nil, 0)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(history, total))
+	assert.Check(t, is.Equal("0", history[0].Actor.ID))
+
+	buffered, l := e.SubscribeTopic(ts, time.Time{}, nil)
+	e.Evict(l)
+	assert.Check(t, is.Len(buffered, total))
+	j.mu.Unlock()
+
+	// Once written, the events are read from the journal.
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if j.Written() < uint64(total) {
+			return poll.Continue("journal has written %d events", j.Written())
+		}
+		return poll.Success()
+	})
+	history, err = e.History(ts, time.Time{}, "", nil, 0)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(history, total))
+	assert.Check(t, is.Len(e.unwritten, 0))
+
+	// The written events are also discarded when new events are published,
+	// without reading the history.
+	j.mu.Lock()
+	for i := 0; i < 10; i++ {
+		e.PublishMessage(testMessage(fmt.Sprint(total+i), ts))
+	}
+	j.mu.Unlock()
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if j.Written() < uint64(total+10) {
+			return poll.Continue("journal has written %d events", j.Written())
+		}
+		return poll.Success()
+	})
+	e.PublishMessage(testMessage(fmt.Sprint(total+10), ts))
+	e.mu.Lock()
+	assert.Check(t, is.Len(e.unwritten, 1))
+	e.mu.Unlock()
+}
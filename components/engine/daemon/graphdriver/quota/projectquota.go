@@ -153,25 +153,26 @@ func NewControl(basePath string) (*Control, error) {
 // SetQuota - assign a unique project id to directory and set the quota limits
 // for that project id
 func (q *Control) SetQuota(targetPath string, quota Quota) error {
-	q.RLock()
+	q.Lock()
 	projectID, ok := q.quotas[targetPath]
-	q.RUnlock()
 	if !ok {
-		q.Lock()
 		projectID = q.nextProjectID
+	}
 
-		//
-		// assign project id to new container directory
-		//
-		err := setProjectID(targetPath, projectID)
-		if err != nil {
-			q.Unlock()
-			return err
-		}
+	//
+	// assign project id to the directory, again if it was known: it may have
+	// been removed and created again (e.g. a volume of the same name)
+	//
+	err := setProjectID(targetPath, projectID)
+	if err != nil {
+		q.Unlock()
+		return err
+	}
+	if !ok {
 		q.quotas[targetPath] = projectID
 		q.nextProjectID++
-		q.Unlock()
 	}
+	q.Unlock()
 
 	//
 	// set the quota limit for the container's project id
@@ -207,18 +208,40 @@ func setProjectQuota(backingFsBlockDev string, projectID uint32, quota Quota) er
 
 // GetQuota - get the quota limits of a directory that was configured with SetQuota
 func (q *Control) GetQuota(targetPath string, quota *Quota) error {
+	d, err := q.getProjectQuota(targetPath)
+	if err != nil {
+		return err
+	}
+	quota.Size = uint64(d.d_blk_hardlimit) * 512
+
+	return nil
+}
+
+// GetUsage - get the number of bytes used by a directory that was configured
+// with SetQuota
+func (q *Control) GetUsage(targetPath string) (uint64, error) {
+	d, err := q.getProjectQuota(targetPath)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(d.d_bcount) * 512, nil
+}
+
+// getProjectQuota - get the quota of the project id of a directory that was
+// configured with SetQuota
+func (q *Control) getProjectQuota(targetPath string) (C.fs_disk_quota_t, error) {
+	var d C.fs_disk_quota_t
+
 	q.RLock()
 	projectID, ok := q.quotas[targetPath]
 	q.RUnlock()
 	if !ok {
-		return fmt.Errorf("quota not found for path : %s", targetPath)
+		return d, fmt.Errorf("quota not found for path : %s", targetPath)
 	}
 
 	//
-	// get the quota limit for the container's project id
+	// get the quota of the container's project id
 	//
-	var d C.fs_disk_quota_t
-
 	var cs = C.CString(q.backingFsBlockDev)
 	defer C.free(unsafe.Pointer(cs))
 
@@ -226,12 +249,10 @@ func (q *Control) GetQuota(targetPath string, quota *Quota) error {
 		uintptr(unsafe.Pointer(cs)), uintptr(C.__u32(projectID)),
 		uintptr(unsafe.Pointer(&d)), 0, 0)
 	if errno != 0 {
-		return fmt.Errorf("Failed to get quota limit for projid %d on %s: %v",
+		return d, fmt.Errorf("Failed to get quota limit for projid %d on %s: %v",
 			projectID, q.backingFsBlockDev, errno.Error())
 	}
-	quota.Size = uint64(d.d_blk_hardlimit) * 512
-
-	return nil
+	return d, nil
 }
 
 // getProjectID - get the project id of path on xfs
@@ -58,6 +58,7 @@ func TestBlockDev(t *testing.T) {
 	t.Run("testSmallerThanQuota", wrapMountTest(imageFileName, true, wrapQuotaTest(testSmallerThanQuota)))
 	t.Run("testBiggerThanQuota", wrapMountTest(imageFileName, true, wrapQuotaTest(testBiggerThanQuota)))
 	t.Run("testRetrieveQuota", wrapMountTest(imageFileName, true, wrapQuotaTest(testRetrieveQuota)))
+	t.Run("testRetrieveUsage", wrapMountTest(imageFileName, true, wrapQuotaTest(testRetrieveUsage)))
 }
 
 func wrapMountTest(imageFileName string, enableQuota bool, testFunc func(t *testing.T, mountPoint, backingFsDev string)) func(*testing.T) {
@@ -150,3 +151,13 @@ func testRetrieveQuota(t *testing.T, ctrl *Control, homeDir, testDir, testSubDir
 	assert.NilError(t, ctrl.GetQuota(testSubDir, &q))
 	assert.Check(t, is.Equal(uint64(testQuotaSize), q.Size))
 }
+
+func testRetrieveUsage(t *testing.T, ctrl *Control, homeDir, testDir, testSubDir string) {
+	// Validate that we can retrieve the usage of the directory
+	assert.NilError(t, ctrl.SetQuota(testSubDir, Quota{testQuotaSize}))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(testSubDir, "usage"), make([]byte, testQuotaSize/2), 0644))
+
+	usage, err := ctrl.GetUsage(testSubDir)
+	assert.NilError(t, err)
+	assert.Check(t, usage >= uint64(testQuotaSize/2))
+}
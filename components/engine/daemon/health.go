@@ -3,7 +3,10 @@ package daemon // import "github.com/docker/docker/daemon"
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
+	"net/http"
 	"runtime"
 	"strings"
 	"sync"
@@ -44,7 +47,8 @@ const (
 const (
 	// Exit status codes that can be returned by the probe command.
 
-	exitStatusHealthy = 0 // Container is healthy
+	exitStatusHealthy   = 0 // Container is healthy
+	exitStatusUnhealthy = 1 // Container is unhealthy
 )
 
 // probe implementations know how to run a particular type of probe.
@@ -114,6 +118,86 @@ func (p *cmdProbe) run(ctx context.Context, d *Daemon, cntr *container.Container
 	}, nil
 }
 
+// httpProbe implements the "HTTP" probe type.
+type httpProbe struct{}
+
+// Send a GET request to the healthcheck URL from within the container's
+// network namespace. Redirects are not followed: any 2xx or 3xx response
+// is considered healthy.
+func (p *httpProbe) run(ctx context.Context, d *Daemon, cntr *container.Container) (*types.HealthcheckResult, error) {
+	test := cntr.Config.Healthcheck.Test
+	if len(test) != 2 {
+		return nil, fmt.Errorf("HTTP healthcheck for container %s requires exactly one URL", cntr.ID)
+	}
+	req, err := http.NewRequest(http.MethodGet, test[1], nil)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext:       probeDialContext(cntr),
+			DisableKeepAlives: true,
+			// Endpoints inside the container commonly use self-signed
+			// certificates; the probe only cares about reachability.
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return &types.HealthcheckResult{
+			End:      time.Now(),
+			ExitCode: exitStatusUnhealthy,
+			Output:   err.Error(),
+		}, nil
+	}
+	defer resp.Body.Close()
+
+	output := &limitedBuffer{}
+	fmt.Fprintf(output, "%s %s\n", resp.Proto, resp.Status)
+	io.Copy(output, io.LimitReader(resp.Body, maxOutputLen))
+
+	exitCode := exitStatusUnhealthy
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusBadRequest {
+		exitCode = exitStatusHealthy
+	}
+	return &types.HealthcheckResult{
+		End:      time.Now(),
+		ExitCode: exitCode,
+		Output:   output.String(),
+	}, nil
+}
+
+// tcpProbe implements the "TCP" probe type.
+type tcpProbe struct{}
+
+// Open a TCP connection to the healthcheck address from within the
+// container's network namespace. The check succeeds if the connection
+// is established.
+func (p *tcpProbe) run(ctx context.Context, d *Daemon, cntr *container.Container) (*types.HealthcheckResult, error) {
+	test := cntr.Config.Healthcheck.Test
+	if len(test) != 2 {
+		return nil, fmt.Errorf("TCP healthcheck for container %s requires exactly one address", cntr.ID)
+	}
+	conn, err := probeDialContext(cntr)(ctx, "tcp", test[1])
+	if err != nil {
+		return &types.HealthcheckResult{
+			End:      time.Now(),
+			ExitCode: exitStatusUnhealthy,
+			Output:   err.Error(),
+		}, nil
+	}
+	out := fmt.Sprintf("Connected to %s", conn.RemoteAddr())
+	conn.Close()
+	return &types.HealthcheckResult{
+		End:      time.Now(),
+		ExitCode: exitStatusHealthy,
+		Output:   out,
+	}, nil
+}
+
 // Update the container's Status.Health struct based on the latest probe's result.
 func handleProbeResult(d *Daemon, c *container.Container, result *types.HealthcheckResult, done chan struct{}) {
 	c.Lock()
@@ -257,10 +341,14 @@ func getProbe(c *container.Container) probe {
 		return &cmdProbe{shell: false}
 	case "CMD-SHELL":
 		return &cmdProbe{shell: true}
+	case "HTTP":
+		return &httpProbe{}
+	case "TCP":
+		return &tcpProbe{}
 	case "NONE":
 		return nil
 	default:
-		logrus.Warnf("Unknown healthcheck type '%s' (expected 'CMD') in container %s", config.Test[0], c.ID)
+		logrus.Warnf("Unknown healthcheck type '%s' (expected 'CMD', 'HTTP' or 'TCP') in container %s", config.Test[0], c.ID)
 		return nil
 	}
 }
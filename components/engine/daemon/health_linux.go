@@ -0,0 +1,179 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/docker/docker/container"
+	"github.com/docker/libnetwork/resolvconf"
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netns"
+)
+
+// healthProbesSupported is whether the HTTP and TCP probes can be run on this
+// platform.
+const healthProbesSupported = true
+
+// probeDialContext returns a dial function that opens connections from
+// within the network namespace of the container's init process, so that
+// HTTP and TCP probes see the same network as the container itself
+// (including "host" and "container:<id>" network modes).
+//
+// Host names are resolved the way the container resolves them: using its
+// hosts file first, and then querying the name servers of its resolv.conf
+// from within its network namespace. The search domains of the container
+// are not applied.
+func probeDialContext(c *container.Container) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		ips, err := resolveProbeHost(ctx, c, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			var conn net.Conn
+			conn, err = dialInNetns(ctx, c, network, net.JoinHostPort(ip, port))
+			if err == nil {
+				return conn, nil
+			}
+		}
+		return nil, err
+	}
+}
+
+// resolveProbeHost returns the addresses of host, as seen from within the
+// container.
+func resolveProbeHost(ctx context.Context, c *container.Container, host string) ([]string, error) {
+	// An empty host dials the local system, as with net.Dial.
+	if host == "" || net.ParseIP(host) != nil {
+		return []string{host}, nil
+	}
+	if c.HostsPath != "" {
+		ips, err := lookupHostsFile(c.HostsPath, host)
+		if err != nil {
+			return nil, err
+		}
+		if len(ips) > 0 {
+			return ips, nil
+		}
+	}
+
+	var nameservers []string
+	if c.ResolvConfPath != "" {
+		content, err := ioutil.ReadFile(c.ResolvConfPath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		nameservers = resolvconf.GetNameservers(content, types.IP)
+	}
+	if len(nameservers) == 0 {
+		return nil, fmt.Errorf("cannot resolve %s: no name server configured in container %s", host, c.ID)
+	}
+	resolver := &net.Resolver{
+		PreferGo: true,
+		// The name servers of the host are replaced by the ones of the
+		// container, which are queried from within its network namespace.
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var (
+				conn net.Conn
+				err  error
+			)
+			for _, ns := range nameservers {
+				conn, err = dialInNetns(ctx, c, network, net.JoinHostPort(ns, "53"))
+				if err == nil {
+					return conn, nil
+				}
+			}
+			return nil, err
+		},
+	}
+	// Resolve the name as fully qualified, so that the search domains of
+	// the host are not applied.
+	return resolver.LookupHost(ctx, strings.TrimSuffix(host, ".")+".")
+}
+
+// lookupHostsFile returns the addresses of host in the hosts file at path.
+func lookupHostsFile(path, host string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var ips []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+			continue
+		}
+		for _, name := range fields[1:] {
+			if strings.EqualFold(name, host) {
+				ips = append(ips, fields[0])
+				break
+			}
+		}
+	}
+	return ips, s.Err()
+}
+
+// dialInNetns connects to addr, which must be an IP address and a port,
+// from within the network namespace of the container's init process.
+func dialInNetns(ctx context.Context, c *container.Container, network, addr string) (net.Conn, error) {
+	pid := c.State.GetPID()
+	if pid == 0 {
+		return nil, fmt.Errorf("container %s is not running", c.ID)
+	}
+
+	// The socket is created by the calling thread, so the thread must
+	// stay in the container's namespace until the dial has returned.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	origin, err := netns.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current network namespace: %v", err)
+	}
+	defer origin.Close()
+
+	target, err := netns.GetFromPid(pid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network namespace of container %s: %v", c.ID, err)
+	}
+	defer target.Close()
+
+	if err := netns.Set(target); err != nil {
+		return nil, fmt.Errorf("failed to enter network namespace of container %s: %v", c.ID, err)
+	}
+	defer func() {
+		if err := netns.Set(origin); err != nil {
+			logrus.Errorf("Failed to restore network namespace after health check of container %s: %v", c.ID, err)
+			// Never hand a thread in the wrong namespace back to the
+			// scheduler; keeping it locked makes the runtime discard it.
+			runtime.LockOSThread()
+		}
+	}()
+
+	// A negative FallbackDelay disables "happy eyeballs" dialing, which
+	// would otherwise create sockets from other goroutines (and threads).
+	// The address is an IP address, so the dialer does not resolve names.
+	d := net.Dialer{FallbackDelay: -1}
+	return d.DialContext(ctx, network, addr)
+}
@@ -0,0 +1,123 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+// newProbeContainer returns a container sharing the network namespace of
+// the test process, with a hosts file mapping "probe-target" to 127.0.0.1.
+func newProbeContainer(t *testing.T, test ...string) *container.Container {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NilError(t, err)
+	hostsPath := filepath.Join(dir, "hosts")
+	err = ioutil.WriteFile(hostsPath, []byte("127.0.0.1\tlocalhost\n# comment\n127.0.0.1\tprobe-target\n"), 0644)
+	assert.NilError(t, err)
+
+	c := &container.Container{
+		ID:        "container_id",
+		Root:      dir,
+		HostsPath: hostsPath,
+		Config: &containertypes.Config{
+			Healthcheck: &containertypes.HealthConfig{Test: test},
+		},
+		State: container.NewState(),
+	}
+	c.State.SetRunning(os.Getpid(), true)
+	return c
+}
+
+func TestHTTPProbe(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("root required")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("all good"))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/fail", http.StatusFound)
+	})
+	mux.HandleFunc("/fail", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "broken", http.StatusInternalServerError)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	assert.NilError(t, err)
+
+	testCases := []struct {
+		url      string
+		exitCode int
+		output   string
+	}{
+		{url: "http://127.0.0.1:" + port + "/ok", exitCode: exitStatusHealthy, output: "200 OK\nall good"},
+		{url: "http://probe-target:" + port + "/ok", exitCode: exitStatusHealthy, output: "200 OK"},
+		{url: "http://127.0.0.1:" + port + "/redirect", exitCode: exitStatusHealthy, output: "302 Found"},
+		{url: "http://127.0.0.1:" + port + "/fail", exitCode: exitStatusUnhealthy, output: "500 Internal Server Error\nbroken"},
+	}
+	for _, tc := range testCases {
+		c := newProbeContainer(t, "HTTP", tc.url)
+		defer os.RemoveAll(c.Root)
+
+		result, err := (&httpProbe{}).run(context.Background(), nil, c)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(tc.exitCode, result.ExitCode), tc.url)
+		assert.Check(t, is.Contains(result.Output, tc.output), tc.url)
+	}
+}
+
+func TestTCPProbe(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("root required")
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	_, port, err := net.SplitHostPort(l.Addr().String())
+	assert.NilError(t, err)
+
+	c := newProbeContainer(t, "TCP", "probe-target:"+port)
+	defer os.RemoveAll(c.Root)
+	result, err := (&tcpProbe{}).run(context.Background(), nil, c)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(exitStatusHealthy, result.ExitCode))
+	assert.Check(t, is.Equal("Connected to 127.0.0.1:"+port, result.Output))
+
+	// nothing listens on the port once the listener is closed
+	l.Close()
+	result, err = (&tcpProbe{}).run(context.Background(), nil, c)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(exitStatusUnhealthy, result.ExitCode))
+	assert.Check(t, strings.Contains(result.Output, "connection refused"), result.Output)
+}
+
+func TestResolveProbeHost(t *testing.T) {
+	c := newProbeContainer(t)
+	defer os.RemoveAll(c.Root)
+
+	ips, err := resolveProbeHost(context.Background(), c, "PROBE-TARGET")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"127.0.0.1"}, ips))
+
+	ips, err = resolveProbeHost(context.Background(), c, "10.0.0.1")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"10.0.0.1"}, ips))
+
+	// names missing from the hosts file are never resolved by the host
+	_, err = resolveProbeHost(context.Background(), c, "example.com")
+	assert.Check(t, is.ErrorContains(err, "no name server configured"))
+}
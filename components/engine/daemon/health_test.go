@@ -1,6 +1,7 @@
 package daemon // import "github.com/docker/docker/daemon"
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -152,3 +153,62 @@ func TestHealthStates(t *testing.T) {
 		t.Errorf("Expecting FailingStreak=0, but got %d\n", c.State.Health.FailingStreak)
 	}
 }
+
+func TestNativeHealthcheckProbes(t *testing.T) {
+	c := &container.Container{
+		ID:     "container_id",
+		Config: &containertypes.Config{},
+	}
+
+	c.Config.Healthcheck = &containertypes.HealthConfig{Test: []string{"HTTP", "http://localhost:8080/"}}
+	if _, ok := getProbe(c).(*httpProbe); !ok {
+		t.Errorf("Expecting an HTTP probe, got %#v", getProbe(c))
+	}
+
+	c.Config.Healthcheck = &containertypes.HealthConfig{Test: []string{"TCP", "localhost:5432"}}
+	if _, ok := getProbe(c).(*tcpProbe); !ok {
+		t.Errorf("Expecting a TCP probe, got %#v", getProbe(c))
+	}
+}
+
+func TestValidateHealthcheckTest(t *testing.T) {
+	valid := [][]string{
+		nil,
+		{"NONE"},
+		{"CMD", "true"},
+	}
+	probes := [][]string{
+		{"HTTP", "http://localhost:8080/health"},
+		{"HTTP", "https://127.0.0.1/"},
+		{"TCP", "localhost:5432"},
+		{"TCP", ":80"},
+	}
+	if healthProbesSupported {
+		valid = append(valid, probes...)
+	} else {
+		for _, test := range probes {
+			if err := validateHealthcheckTest(test); err == nil || !strings.Contains(err.Error(), "not supported") {
+				t.Errorf("Expecting %q to be unsupported, got %v", test, err)
+			}
+		}
+	}
+	for _, test := range valid {
+		if err := validateHealthcheckTest(test); err != nil {
+			t.Errorf("Expecting %q to be valid, got %v", test, err)
+		}
+	}
+
+	invalid := [][]string{
+		{"HTTP"},
+		{"HTTP", "localhost:8080/health"},
+		{"HTTP", "ftp://localhost/"},
+		{"HTTP", "http://localhost/", "extra"},
+		{"TCP"},
+		{"TCP", "localhost"},
+	}
+	for _, test := range invalid {
+		if err := validateHealthcheckTest(test); err == nil {
+			t.Errorf("Expecting %q to be invalid", test)
+		}
+	}
+}
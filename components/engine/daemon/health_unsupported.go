@@ -0,0 +1,23 @@
+// +build !linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/docker/docker/container"
+)
+
+// healthProbesSupported is whether the HTTP and TCP probes can be run on this
+// platform.
+const healthProbesSupported = false
+
+// probeDialContext returns a dial function for HTTP and TCP probes. Entering
+// the container's network namespace is only supported on Linux.
+func probeDialContext(c *container.Container) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, errors.New("HTTP and TCP health checks are not supported on this platform")
+	}
+}
@@ -0,0 +1,53 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"time"
+
+	"github.com/docker/docker/daemon/config"
+	"github.com/docker/docker/daemon/images"
+	"github.com/docker/docker/errdefs"
+	"github.com/sirupsen/logrus"
+)
+
+// startImageGC starts the image garbage collector, if it is enabled in the
+// configuration. It runs until the daemon is shut down.
+func (daemon *Daemon) startImageGC(cfg config.ImageGCConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	// the configuration was validated when it was loaded
+	interval, maxAge, _ := cfg.Durations()
+	policy := images.GCPolicy{
+		MaxAge:        maxAge,
+		KeepTags:      cfg.KeepTags,
+		HighWatermark: cfg.HighWatermark,
+		LowWatermark:  cfg.LowWatermark,
+		// images pulled since the previous run may be about to be used
+		MinAge: interval,
+		DiskUsage: func() (float64, error) {
+			return diskUsage(daemon.root)
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	daemon.stopImageGC = cancel
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if _, err := daemon.imageService.GarbageCollect(ctx, policy); err != nil && err != context.Canceled {
+				if errdefs.IsConflict(err) {
+					logrus.Debug("Skipping image garbage collection: a prune operation is running")
+					continue
+				}
+				logrus.WithError(err).Warn("Image garbage collection failed")
+			}
+		}
+	}()
+}
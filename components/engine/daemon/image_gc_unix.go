@@ -0,0 +1,22 @@
+// +build !windows
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// diskUsage returns the percentage of the disk space used on the filesystem
+// holding path, as reported by df.
+func diskUsage(path string) (float64, error) {
+	var buf unix.Statfs_t
+	if err := unix.Statfs(path, &buf); err != nil {
+		return 0, err
+	}
+	used := uint64(buf.Blocks) - uint64(buf.Bfree)
+	total := used + uint64(buf.Bavail)
+	if total == 0 {
+		return 0, nil
+	}
+	return float64(used) * 100 / float64(total), nil
+}
@@ -0,0 +1,11 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"errors"
+)
+
+// diskUsage returns the percentage of the disk space used on the filesystem
+// holding path.
+func diskUsage(path string) (float64, error) {
+	return 0, errors.New("disk usage watermarks are not supported on Windows")
+}
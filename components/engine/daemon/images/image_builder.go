@@ -190,6 +190,7 @@ func (i *ImageService) GetImageAndReleasableLayer(ctx context.Context, refOrID s
 			if !system.IsOSSupported(image.OperatingSystem()) {
 				return nil, nil, system.ErrNotSupportedOperatingSystem
 			}
+			i.MarkImageUsed(image.ID())
 			layer, err := newROLayerForImage(image, i.layerStores[image.OperatingSystem()])
 			return image, layer, err
 		}
@@ -202,6 +203,7 @@ func (i *ImageService) GetImageAndReleasableLayer(ctx context.Context, refOrID s
 	if !system.IsOSSupported(image.OperatingSystem()) {
 		return nil, nil, system.ErrNotSupportedOperatingSystem
 	}
+	i.MarkImageUsed(image.ID())
 	layer, err := newROLayerForImage(image, i.layerStores[image.OperatingSystem()])
 	return image, layer, err
 }
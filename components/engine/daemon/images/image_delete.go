@@ -61,6 +61,13 @@ const (
 // conflict will not be reported.
 //
 func (i *ImageService) ImageDelete(imageRef string, force, prune bool) ([]types.ImageDeleteResponseItem, error) {
+	return i.imageDelete(imageRef, force, prune, "")
+}
+
+// imageDelete deletes the image referenced by the given imageRef, like
+// ImageDelete. If reason is not empty, it is added as the reason attribute
+// of the untag and delete events.
+func (i *ImageService) imageDelete(imageRef string, force, prune bool, reason string) ([]types.ImageDeleteResponseItem, error) {
 	start := time.Now()
 	records := []types.ImageDeleteResponseItem{}
 
@@ -108,7 +115,7 @@ func (i *ImageService) ImageDelete(imageRef string, force, prune bool) ([]types.
 
 		untaggedRecord := types.ImageDeleteResponseItem{Untagged: reference.FamiliarString(parsedRef)}
 
-		i.LogImageEvent(imgID.String(), imgID.String(), "untag")
+		i.logImageDeleteEvent(imgID, "untag", reason)
 		records = append(records, untaggedRecord)
 
 		repoRefs = i.referenceStore.References(imgID.Digest())
@@ -171,13 +178,13 @@ func (i *ImageService) ImageDelete(imageRef string, force, prune bool) ([]types.
 
 				untaggedRecord := types.ImageDeleteResponseItem{Untagged: reference.FamiliarString(parsedRef)}
 
-				i.LogImageEvent(imgID.String(), imgID.String(), "untag")
+				i.logImageDeleteEvent(imgID, "untag", reason)
 				records = append(records, untaggedRecord)
 			}
 		}
 	}
 
-	if err := i.imageDeleteHelper(imgID, &records, force, prune, removedRepositoryRef); err != nil {
+	if err := i.imageDeleteHelper(imgID, &records, force, prune, removedRepositoryRef, reason); err != nil {
 		return nil, err
 	}
 
@@ -246,7 +253,7 @@ func (i *ImageService) removeImageRef(ref reference.Named) (reference.Named, err
 // on the first encountered error. Removed references are logged to this
 // daemon's event service. An "Untagged" types.ImageDeleteResponseItem is added to the
 // given list of records.
-func (i *ImageService) removeAllReferencesToImageID(imgID image.ID, records *[]types.ImageDeleteResponseItem) error {
+func (i *ImageService) removeAllReferencesToImageID(imgID image.ID, records *[]types.ImageDeleteResponseItem, reason string) error {
 	imageRefs := i.referenceStore.References(imgID.Digest())
 
 	for _, imageRef := range imageRefs {
@@ -257,13 +264,23 @@ func (i *ImageService) removeAllReferencesToImageID(imgID image.ID, records *[]t
 
 		untaggedRecord := types.ImageDeleteResponseItem{Untagged: reference.FamiliarString(parsedRef)}
 
-		i.LogImageEvent(imgID.String(), imgID.String(), "untag")
+		i.logImageDeleteEvent(imgID, "untag", reason)
 		*records = append(*records, untaggedRecord)
 	}
 
 	return nil
 }
 
+// logImageDeleteEvent logs an untag or delete event for the image, with the
+// reason of the deletion, if any.
+func (i *ImageService) logImageDeleteEvent(imgID image.ID, action, reason string) {
+	attributes := map[string]string{}
+	if reason != "" {
+		attributes["reason"] = reason
+	}
+	i.LogImageEventWithAttributes(imgID.String(), imgID.String(), action, attributes)
+}
+
 // ImageDeleteConflict holds a soft or hard conflict and an associated error.
 // Implements the error interface.
 type imageDeleteConflict struct {
@@ -297,7 +314,7 @@ func (idc *imageDeleteConflict) Conflict() {}
 // conflict is encountered, it will be returned immediately without deleting
 // the image. If quiet is true, any encountered conflicts will be ignored and
 // the function will return nil immediately without deleting the image.
-func (i *ImageService) imageDeleteHelper(imgID image.ID, records *[]types.ImageDeleteResponseItem, force, prune, quiet bool) error {
+func (i *ImageService) imageDeleteHelper(imgID image.ID, records *[]types.ImageDeleteResponseItem, force, prune, quiet bool, reason string) error {
 	// First, determine if this image has any conflicts. Ignore soft conflicts
 	// if force is true.
 	c := conflictHard
@@ -323,7 +340,7 @@ func (i *ImageService) imageDeleteHelper(imgID image.ID, records *[]types.ImageD
 	}
 
 	// Delete all repository tag/digest references to this image.
-	if err := i.removeAllReferencesToImageID(imgID, records); err != nil {
+	if err := i.removeAllReferencesToImageID(imgID, records, reason); err != nil {
 		return err
 	}
 
@@ -332,7 +349,7 @@ func (i *ImageService) imageDeleteHelper(imgID image.ID, records *[]types.ImageD
 		return err
 	}
 
-	i.LogImageEvent(imgID.String(), imgID.String(), "delete")
+	i.logImageDeleteEvent(imgID, "delete", reason)
 	*records = append(*records, types.ImageDeleteResponseItem{Deleted: imgID.String()})
 	for _, removedLayer := range removedLayers {
 		*records = append(*records, types.ImageDeleteResponseItem{Deleted: removedLayer.ChainID.String()})
@@ -347,7 +364,7 @@ func (i *ImageService) imageDeleteHelper(imgID image.ID, records *[]types.ImageD
 	// either running or stopped).
 	// Do not force prunings, but do so quietly (stopping on any encountered
 	// conflicts).
-	return i.imageDeleteHelper(parent, records, false, true, true)
+	return i.imageDeleteHelper(parent, records, false, true, true, reason)
 }
 
 // checkImageDeleteConflict determines whether there are any conflicts
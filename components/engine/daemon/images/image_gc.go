@@ -0,0 +1,247 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/image"
+	"github.com/docker/go-units"
+	"github.com/sirupsen/logrus"
+)
+
+// gcReason is the reason attribute of the events of the images removed by
+// the garbage collector.
+const gcReason = "gc"
+
+// GCPolicy is the policy of the image garbage collector. Only the images
+// that are not used by any container, and are not the parent of another
+// image, are removed.
+type GCPolicy struct {
+	// MaxAge is the time after which an image that was not used is removed.
+	// Images are not removed because of their age if MaxAge is zero.
+	MaxAge time.Duration
+	// KeepTags is the number of most recent tags kept in each repository.
+	// Tags are not removed because of their number if KeepTags is zero.
+	KeepTags int
+	// HighWatermark is the percentage of disk usage over which images are
+	// removed, from the least recently used, until the disk usage is below
+	// LowWatermark. Images are not removed because of the disk usage if
+	// HighWatermark is zero.
+	HighWatermark int
+	LowWatermark  int
+	// MinAge is the time during which an image that was pulled or used is
+	// not removed because of the disk usage, as it may be about to be used.
+	MinAge time.Duration
+	// DiskUsage returns the percentage of disk space used by the daemon.
+	DiskUsage func() (float64, error)
+}
+
+// gcCandidate is an image that can be removed by the garbage collector
+type gcCandidate struct {
+	id       image.ID
+	lastUsed time.Time
+}
+
+// GarbageCollect removes the images selected by the policy, and returns the
+// images that were removed and the space reclaimed. An image prune event is
+// logged with the space reclaimed if any image was removed.
+func (i *ImageService) GarbageCollect(ctx context.Context, policy GCPolicy) (*types.ImagesPruneReport, error) {
+	if !atomic.CompareAndSwapInt32(&i.pruneRunning, 0, 1) {
+		return nil, errPruneRunning
+	}
+	defer atomic.StoreInt32(&i.pruneRunning, 0)
+
+	allLayers := i.allLayers()
+	rep := &types.ImagesPruneReport{}
+	defer func() {
+		if len(rep.ImagesDeleted) == 0 {
+			return
+		}
+		rep.SpaceReclaimed = reclaimedSpace(allLayers, rep.ImagesDeleted)
+		logrus.WithField("reclaimed", units.HumanSize(float64(rep.SpaceReclaimed))).Infof("Image garbage collection removed %d images and layers", len(rep.ImagesDeleted))
+		i.eventsService.Log("prune", events.ImageEventType, events.Actor{
+			Attributes: map[string]string{
+				"reason":    gcReason,
+				"reclaimed": strconv.FormatUint(rep.SpaceReclaimed, 10),
+			},
+		})
+	}()
+
+	if policy.KeepTags > 0 {
+		if err := i.gcTags(ctx, policy.KeepTags, rep); err != nil {
+			return rep, err
+		}
+	}
+
+	if policy.MaxAge > 0 {
+		before := time.Now().Add(-policy.MaxAge)
+		for _, c := range i.gcCandidates() {
+			if !c.lastUsed.Before(before) {
+				break
+			}
+			if err := ctx.Err(); err != nil {
+				return rep, err
+			}
+			rep.ImagesDeleted = append(rep.ImagesDeleted, i.gcImage(c.id)...)
+		}
+	}
+
+	if policy.HighWatermark > 0 && policy.DiskUsage != nil {
+		if err := i.gcDiskUsage(ctx, policy, rep); err != nil {
+			return rep, err
+		}
+	}
+	return rep, nil
+}
+
+// gcTags removes the tags of each repository but the keep most recent ones.
+// The tags of images used by a container count towards the tags that are
+// kept, but are never removed.
+func (i *ImageService) gcTags(ctx context.Context, keep int, rep *types.ImagesPruneReport) error {
+	type tag struct {
+		ref       reference.NamedTagged
+		id        image.ID
+		lastUsed  time.Time
+		candidate bool
+	}
+
+	candidates := make(map[image.ID]bool)
+	for _, c := range i.gcCandidates() {
+		candidates[c.id] = true
+	}
+	repos := make(map[string][]tag)
+	for id, img := range i.imageStore.Map() {
+		for _, ref := range i.referenceStore.References(id.Digest()) {
+			tagged, ok := ref.(reference.NamedTagged)
+			if !ok {
+				continue
+			}
+			repos[ref.Name()] = append(repos[ref.Name()], tag{
+				ref:       tagged,
+				id:        id,
+				lastUsed:  i.lastUsed(id, img),
+				candidate: candidates[id],
+			})
+		}
+	}
+
+	for _, tags := range repos {
+		if len(tags) <= keep {
+			continue
+		}
+		sort.Slice(tags, func(a, b int) bool {
+			return tags[a].lastUsed.After(tags[b].lastUsed)
+		})
+		for _, t := range tags[keep:] {
+			if !t.candidate {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			imgDel, err := i.imageDelete(t.ref.String(), false, true, gcReason)
+			if imageDeleteFailed(t.ref.String(), err) {
+				continue
+			}
+			rep.ImagesDeleted = append(rep.ImagesDeleted, imgDel...)
+		}
+	}
+	return nil
+}
+
+// gcDiskUsage removes images, from the least recently used, while the disk
+// usage is over the low watermark, if it is over the high watermark. It
+// stops if removing an image does not lower the disk usage, as the disk is
+// then used by other data than images.
+func (i *ImageService) gcDiskUsage(ctx context.Context, policy GCPolicy, rep *types.ImagesPruneReport) error {
+	usage, err := policy.DiskUsage()
+	if err != nil {
+		return err
+	}
+	if usage < float64(policy.HighWatermark) {
+		return nil
+	}
+	low := policy.LowWatermark
+	if low == 0 {
+		low = policy.HighWatermark
+	}
+	logrus.Infof("Disk usage is %.1f%%, removing unused images until it is below %d%%", usage, low)
+	before := time.Now().Add(-policy.MinAge)
+	for _, c := range i.gcCandidates() {
+		if usage < float64(low) {
+			return nil
+		}
+		if !c.lastUsed.Before(before) {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		deleted := i.gcImage(c.id)
+		if len(deleted) == 0 {
+			continue
+		}
+		rep.ImagesDeleted = append(rep.ImagesDeleted, deleted...)
+		previous := usage
+		if usage, err = policy.DiskUsage(); err != nil {
+			return err
+		}
+		if usage >= previous {
+			logrus.Warnf("Disk usage is still %.1f%% after removing image %s, not removing other images", usage, c.id)
+			return nil
+		}
+	}
+	if usage >= float64(low) {
+		logrus.Warnf("Disk usage is still %.1f%% after removing the unused images", usage)
+	}
+	return nil
+}
+
+// gcCandidates returns the images that are not used by any container and
+// are not the parent of another image, from the least to the most recently
+// used.
+func (i *ImageService) gcCandidates() []gcCandidate {
+	used := make(map[image.ID]bool)
+	for _, c := range i.containers.List() {
+		used[c.ImageID] = true
+	}
+	var candidates []gcCandidate
+	for id, img := range i.imageStore.Map() {
+		if used[id] || len(i.imageStore.Children(id)) > 0 {
+			continue
+		}
+		candidates = append(candidates, gcCandidate{id: id, lastUsed: i.lastUsed(id, img)})
+	}
+	sort.Slice(candidates, func(a, b int) bool {
+		return candidates[a].lastUsed.Before(candidates[b].lastUsed)
+	})
+	return candidates
+}
+
+// gcImage removes all the references to the image, and the image.
+func (i *ImageService) gcImage(id image.ID) []types.ImageDeleteResponseItem {
+	refs := i.referenceStore.References(id.Digest())
+	if len(refs) == 0 {
+		hex := id.Digest().Hex()
+		imgDel, err := i.imageDelete(hex, false, true, gcReason)
+		if imageDeleteFailed(hex, err) {
+			return nil
+		}
+		return imgDel
+	}
+	var deleted []types.ImageDeleteResponseItem
+	for _, ref := range refs {
+		imgDel, err := i.imageDelete(ref.String(), false, true, gcReason)
+		if imageDeleteFailed(ref.String(), err) {
+			continue
+		}
+		deleted = append(deleted, imgDel...)
+	}
+	return deleted
+}
@@ -0,0 +1,217 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/container"
+	daemonevents "github.com/docker/docker/daemon/events"
+	"github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	dockerreference "github.com/docker/docker/reference"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+type fakeContainerStore struct {
+	containers []*container.Container
+}
+
+func (s *fakeContainerStore) First(filter container.StoreFilter) *container.Container {
+	for _, c := range s.containers {
+		if filter(c) {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *fakeContainerStore) List() []*container.Container {
+	return s.containers
+}
+
+func (s *fakeContainerStore) Get(id string) *container.Container {
+	return nil
+}
+
+type fakeLayer struct {
+	layer.Layer
+	chainID layer.ChainID
+	diffID  layer.DiffID
+	parent  *fakeLayer
+	size    int64
+	content []byte
+}
+
+func (l *fakeLayer) ChainID() layer.ChainID {
+	return l.chainID
+}
+
+func (l *fakeLayer) Parent() layer.Layer {
+	if l.parent == nil {
+		return nil
+	}
+	return l.parent
+}
+
+func (l *fakeLayer) DiffSize() (int64, error) {
+	return l.size, nil
+}
+
+func (l *fakeLayer) DiffID() layer.DiffID {
+	return l.diffID
+}
+
+func (l *fakeLayer) TarStream() (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(l.content)), nil
+}
+
+type fakeLayerStore struct {
+	layer.Store
+	layers map[layer.ChainID]layer.Layer
+}
+
+func (s *fakeLayerStore) Get(chainID layer.ChainID) (layer.Layer, error) {
+	l, ok := s.layers[chainID]
+	if !ok {
+		return nil, layer.ErrLayerDoesNotExist
+	}
+	return l, nil
+}
+
+func (s *fakeLayerStore) Release(l layer.Layer) ([]layer.Metadata, error) {
+	return nil, nil
+}
+
+func (s *fakeLayerStore) Map() map[layer.ChainID]layer.Layer {
+	return s.layers
+}
+
+func newTestImageService(t *testing.T) (*ImageService, *fakeContainerStore, func()) {
+	dir, err := ioutil.TempDir("", "images-gc-test")
+	assert.NilError(t, err)
+
+	fs, err := image.NewFSStoreBackend(filepath.Join(dir, "images"))
+	assert.NilError(t, err)
+	ls := &fakeLayerStore{layers: map[layer.ChainID]layer.Layer{}}
+	imageStore, err := image.NewImageStore(fs, map[string]image.LayerGetReleaser{runtime.GOOS: ls})
+	assert.NilError(t, err)
+	referenceStore, err := dockerreference.NewReferenceStore(filepath.Join(dir, "repositories.json"))
+	assert.NilError(t, err)
+	distributionMetadataStore, err := metadata.NewFSMetadataStore(filepath.Join(dir, "distribution"))
+	assert.NilError(t, err)
+
+	containers := &fakeContainerStore{}
+	i := NewImageService(ImageServiceConfig{
+		ContainerStore:            containers,
+		DistributionMetadataStore: distributionMetadataStore,
+		EventsService:             daemonevents.New(),
+		ImageStore:                imageStore,
+		LayerStores:               map[string]layer.Store{runtime.GOOS: ls},
+		ReferenceStore:            referenceStore,
+	})
+	return i, containers, func() { os.RemoveAll(dir) }
+}
+
+func createTestImage(t *testing.T, i *ImageService, comment string, created time.Time, tags ...string) image.ID {
+	config, err := json.Marshal(map[string]interface{}{
+		"comment": comment,
+		"created": created,
+		"rootfs":  map[string]string{"type": "layers"},
+	})
+	assert.NilError(t, err)
+	id, err := i.imageStore.Create(config)
+	assert.NilError(t, err)
+	for _, tag := range tags {
+		ref, err := reference.ParseNormalizedNamed(tag)
+		assert.NilError(t, err)
+		assert.NilError(t, i.referenceStore.AddTag(ref, id.Digest(), false))
+	}
+	return id
+}
+
+func TestGarbageCollect(t *testing.T) {
+	i, containers, cleanup := newTestImageService(t)
+	defer cleanup()
+
+	now := time.Now()
+	oldest := createTestImage(t, i, "oldest", now.Add(-3*time.Hour), "repo:oldest")
+	older := createTestImage(t, i, "older", now.Add(-2*time.Hour), "repo:older")
+	newest := createTestImage(t, i, "newest", now.Add(-time.Hour), "repo:newest")
+	used := createTestImage(t, i, "used", now.Add(-48*time.Hour), "used:latest")
+	untagged := createTestImage(t, i, "untagged", now.Add(-48*time.Hour))
+	c := container.NewBaseContainer("container", "")
+	c.ImageID = used
+	containers.containers = []*container.Container{c}
+
+	exists := func(id image.ID) bool {
+		_, err := i.imageStore.Get(id)
+		return err == nil
+	}
+
+	rep, err := i.GarbageCollect(context.Background(), GCPolicy{KeepTags: 2, MaxAge: 24 * time.Hour})
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(rep.ImagesDeleted, 3))
+	assert.Check(t, !exists(oldest))
+	assert.Check(t, !exists(untagged))
+	assert.Check(t, exists(older))
+	assert.Check(t, exists(newest))
+	assert.Check(t, exists(used))
+
+	var usage []float64
+	diskUsage := func() (float64, error) {
+		u := usage[0]
+		if len(usage) > 1 {
+			usage = usage[1:]
+		}
+		return u, nil
+	}
+
+	// images used since the grace period are not removed
+	usage = []float64{90, 70, 40}
+	_, err = i.GarbageCollect(context.Background(), GCPolicy{HighWatermark: 80, LowWatermark: 50, MinAge: 90 * time.Minute, DiskUsage: diskUsage})
+	assert.NilError(t, err)
+	assert.Check(t, !exists(older))
+	assert.Check(t, exists(newest))
+
+	// nor are images removed once removing them does not lower the disk usage
+	recent := createTestImage(t, i, "recent", now.Add(-30*time.Minute), "repo:recent")
+	usage = []float64{90, 90, 40}
+	_, err = i.GarbageCollect(context.Background(), GCPolicy{HighWatermark: 80, LowWatermark: 50, DiskUsage: diskUsage})
+	assert.NilError(t, err)
+	assert.Check(t, !exists(newest))
+	assert.Check(t, exists(recent))
+
+	usage = []float64{90, 70, 40}
+	_, err = i.GarbageCollect(context.Background(), GCPolicy{HighWatermark: 80, LowWatermark: 50, DiskUsage: diskUsage})
+	assert.NilError(t, err)
+	assert.Check(t, !exists(recent))
+	assert.Check(t, exists(used))
+
+	messages, _, cancel := i.eventsService.Subscribe()
+	defer cancel()
+	var deleted, pruned int
+	for _, m := range messages {
+		assert.Check(t, is.Equal(events.ImageEventType, m.Type))
+		assert.Check(t, is.Equal(gcReason, m.Actor.Attributes["reason"]), "%s event", m.Action)
+		switch m.Action {
+		case "delete":
+			deleted++
+		case "prune":
+			pruned++
+		}
+	}
+	assert.Check(t, is.Equal(5, deleted))
+	assert.Check(t, is.Equal(4, pruned))
+}
@@ -19,10 +19,11 @@ import (
 )
 
 var imagesAcceptedFilters = map[string]bool{
-	"dangling": true,
-	"label":    true,
-	"label!":   true,
-	"until":    true,
+	"dangling":   true,
+	"label":      true,
+	"label!":     true,
+	"until":      true,
+	"unused-for": true,
 }
 
 // errPruneRunning is returned when a prune request is received while
@@ -58,6 +59,11 @@ func (i *ImageService) ImagesPrune(ctx context.Context, pruneFilters filters.Arg
 		return nil, err
 	}
 
+	unusedBefore, err := getUnusedForFromPruneFilters(pruneFilters)
+	if err != nil {
+		return nil, err
+	}
+
 	var allImages map[image.ID]*image.Image
 	if danglingOnly {
 		allImages = i.imageStore.Heads()
@@ -66,12 +72,7 @@ func (i *ImageService) ImagesPrune(ctx context.Context, pruneFilters filters.Arg
 	}
 
 	// Filter intermediary images and get their unique size
-	allLayers := make(map[layer.ChainID]layer.Layer)
-	for _, ls := range i.layerStores {
-		for k, v := range ls.Map() {
-			allLayers[k] = v
-		}
-	}
+	allLayers := i.allLayers()
 	topImages := map[image.ID]*image.Image{}
 	for id, img := range allImages {
 		select {
@@ -85,6 +86,9 @@ func (i *ImageService) ImagesPrune(ctx context.Context, pruneFilters filters.Arg
 			if !until.IsZero() && img.Created.After(until) {
 				continue
 			}
+			if !unusedBefore.IsZero() && !i.lastUsed(id, img).Before(unusedBefore) {
+				continue
+			}
 			if img.Config != nil && !matchLabels(pruneFilters, img.Config.Labels) {
 				continue
 			}
@@ -142,7 +146,30 @@ deleteImagesLoop:
 	}
 
 	// Compute how much space was freed
-	for _, d := range rep.ImagesDeleted {
+	rep.SpaceReclaimed = reclaimedSpace(allLayers, rep.ImagesDeleted)
+
+	if canceled {
+		logrus.Debugf("ImagesPrune operation cancelled: %#v", *rep)
+	}
+
+	return rep, nil
+}
+
+// allLayers returns the layers of all the layer stores.
+func (i *ImageService) allLayers() map[layer.ChainID]layer.Layer {
+	allLayers := make(map[layer.ChainID]layer.Layer)
+	for _, ls := range i.layerStores {
+		for k, v := range ls.Map() {
+			allLayers[k] = v
+		}
+	}
+	return allLayers
+}
+
+// reclaimedSpace returns the size of the deleted layers, out of allLayers.
+func reclaimedSpace(allLayers map[layer.ChainID]layer.Layer, deleted []types.ImageDeleteResponseItem) uint64 {
+	var reclaimed uint64
+	for _, d := range deleted {
 		if d.Deleted != "" {
 			chid := layer.ChainID(d.Deleted)
 			if l, ok := allLayers[chid]; ok {
@@ -151,16 +178,11 @@ deleteImagesLoop:
 					logrus.Warnf("failed to get layer %s size: %v", chid, err)
 					continue
 				}
-				rep.SpaceReclaimed += uint64(diffSize)
+				reclaimed += uint64(diffSize)
 			}
 		}
 	}
-
-	if canceled {
-		logrus.Debugf("ImagesPrune operation cancelled: %#v", *rep)
-	}
-
-	return rep, nil
+	return reclaimed
 }
 
 func imageDeleteFailed(ref string, err error) bool {
@@ -76,9 +76,11 @@ func (i *ImageService) pullImageWithReference(ctx context.Context, ref reference
 			ImageStore:       distribution.NewImageConfigStoreFromStore(i.imageStore),
 			ReferenceStore:   i.referenceStore,
 		},
-		DownloadManager: i.downloadManager,
-		Schema2Types:    distribution.ImageTypes,
-		Platform:        platform,
+		DownloadManager:  i.downloadManager,
+		Schema2Types:     distribution.ImageTypes,
+		Platform:         platform,
+		PartialDownloads: i.partialDownloads,
+		BlobMirrors:      i.blobMirrors,
 	}
 
 	err := distribution.Pull(ctx, ref, imagePullConfig)
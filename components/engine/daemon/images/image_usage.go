@@ -0,0 +1,49 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/image"
+	"github.com/sirupsen/logrus"
+)
+
+// MarkImageUsed records the current time as the last time the image was used
+// to create or start a container, or as the base image of a build.
+func (i *ImageService) MarkImageUsed(id image.ID) {
+	if err := i.imageStore.SetLastUsed(id); err != nil {
+		logrus.WithError(err).WithField("image", id.String()).Warn("Error recording last use of image")
+	}
+}
+
+// lastUsed returns the last time the image was used, tagged or pulled, or
+// the time it was created if none of those is known.
+func (i *ImageService) lastUsed(id image.ID, img *image.Image) time.Time {
+	last := img.Created
+	if lastUpdated, err := i.imageStore.GetLastUpdated(id); err == nil && lastUpdated.After(last) {
+		last = lastUpdated
+	}
+	if lastUsed, err := i.imageStore.GetLastUsed(id); err == nil && lastUsed.After(last) {
+		last = lastUsed
+	}
+	return last
+}
+
+// getUnusedForFromPruneFilters returns the time before which images must have
+// been last used to match the "unused-for" filter, or the zero time if the
+// filter is not set.
+func getUnusedForFromPruneFilters(pruneFilters filters.Args) (time.Time, error) {
+	if !pruneFilters.Contains("unused-for") {
+		return time.Time{}, nil
+	}
+	unusedForFilters := pruneFilters.Get("unused-for")
+	if len(unusedForFilters) > 1 {
+		return time.Time{}, fmt.Errorf("more than one unused-for filter specified")
+	}
+	d, err := time.ParseDuration(unusedForFilters[0])
+	if err != nil || d < 0 {
+		return time.Time{}, invalidFilter{"unused-for", unusedForFilters[0]}
+	}
+	return time.Now().Add(-d), nil
+}
@@ -0,0 +1,43 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestImagesPruneUnusedFor(t *testing.T) {
+	i, _, cleanup := newTestImageService(t)
+	defer cleanup()
+
+	now := time.Now()
+	unused := createTestImage(t, i, "unused", now.Add(-48*time.Hour), "repo:unused")
+	used := createTestImage(t, i, "used", now.Add(-48*time.Hour), "repo:used")
+	recent := createTestImage(t, i, "recent", now.Add(-time.Hour), "repo:recent")
+	i.MarkImageUsed(used)
+
+	lastUsed, err := i.imageStore.GetLastUsed(used)
+	assert.NilError(t, err)
+	assert.Check(t, !lastUsed.Before(now))
+
+	_, err = i.ImagesPrune(context.Background(), filters.NewArgs(filters.Arg("unused-for", "invalid")))
+	assert.Check(t, is.ErrorContains(err, "unused-for"))
+
+	rep, err := i.ImagesPrune(context.Background(), filters.NewArgs(
+		filters.Arg("dangling", "false"),
+		filters.Arg("unused-for", "24h"),
+	))
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(rep.ImagesDeleted, 2))
+
+	_, err = i.imageStore.Get(unused)
+	assert.Check(t, err != nil)
+	_, err = i.imageStore.Get(used)
+	assert.Check(t, err)
+	_, err = i.imageStore.Get(recent)
+	assert.Check(t, err)
+}
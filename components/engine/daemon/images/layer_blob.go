@@ -0,0 +1,45 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"context"
+	"io"
+
+	"github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// GetLayerBlob returns the uncompressed content of the layer that was pulled
+// as the blob dgst, and the DiffID of the layer, which is the digest of the
+// content. It is used to serve the layers to peer daemons.
+func (i *ImageService) GetLayerBlob(ctx context.Context, dgst digest.Digest) (io.ReadCloser, digest.Digest, error) {
+	diffID, err := metadata.NewV2MetadataService(i.distributionMetadataStore).GetDiffID(dgst)
+	if err != nil {
+		return nil, "", errdefs.NotFound(errors.Errorf("no layer was pulled as blob %s", dgst))
+	}
+	for _, ls := range i.layerStores {
+		for chainID, l := range ls.Map() {
+			if l.DiffID() != diffID {
+				continue
+			}
+			l, err := ls.Get(chainID)
+			if err != nil {
+				continue
+			}
+			rc, err := l.TarStream()
+			if err != nil {
+				layer.ReleaseAndLog(ls, l)
+				return nil, "", err
+			}
+			return ioutils.NewReadCloserWrapper(rc, func() error {
+				err := rc.Close()
+				layer.ReleaseAndLog(ls, l)
+				return err
+			}), digest.Digest(diffID), nil
+		}
+	}
+	return nil, "", errdefs.NotFound(errors.Errorf("no layer was pulled as blob %s", dgst))
+}
@@ -0,0 +1,45 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"context"
+	"io/ioutil"
+	"runtime"
+	"testing"
+
+	"github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/layer"
+	"github.com/opencontainers/go-digest"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestGetLayerBlob(t *testing.T) {
+	i, _, cleanup := newTestImageService(t)
+	defer cleanup()
+
+	content := []byte("layer content")
+	diffID := layer.DiffID(digest.FromBytes(content))
+	l := &fakeLayer{chainID: layer.CreateChainID([]layer.DiffID{diffID}), diffID: diffID, content: content}
+	ls := i.layerStores[runtime.GOOS].(*fakeLayerStore)
+	ls.layers[l.chainID] = l
+
+	blob := digest.FromString("compressed layer content")
+	_, _, err := i.GetLayerBlob(context.Background(), blob)
+	assert.Check(t, errdefs.IsNotFound(err))
+
+	v2MetadataService := metadata.NewV2MetadataService(i.distributionMetadataStore)
+	assert.NilError(t, v2MetadataService.Add(diffID, metadata.V2Metadata{Digest: blob, SourceRepository: "docker.io/library/busybox"}))
+
+	rc, actualDiffID, err := i.GetLayerBlob(context.Background(), blob)
+	assert.NilError(t, err)
+	defer rc.Close()
+	assert.Check(t, is.Equal(digest.Digest(diffID), actualDiffID))
+	b, err := ioutil.ReadAll(rc)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(content), string(b)))
+
+	delete(ls.layers, l.chainID)
+	_, _, err = i.GetLayerBlob(context.Background(), blob)
+	assert.Check(t, errdefs.IsNotFound(err))
+}
@@ -0,0 +1,136 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"context"
+	"sort"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/sirupsen/logrus"
+)
+
+// LayersUsage returns the size of each layer, with the images and containers
+// referencing it, and the space that removing each image would reclaim. It
+// only uses the metadata of the layer stores, and does not walk the
+// filesystem.
+//
+// Only the images that are tagged, or are not the parent of another image,
+// are reported. The space reclaimed by removing an image is the size of the
+// layers that are not referenced by any other image nor by any container.
+func (i *ImageService) LayersUsage(ctx context.Context) ([]*types.LayerUsage, []*types.ImageReclaimableSpace, error) {
+	layers := make(map[layer.ChainID]*types.LayerUsage)
+	for _, ls := range i.layerStores {
+		for chainID, l := range ls.Map() {
+			size, err := l.DiffSize()
+			if err != nil {
+				logrus.Warnf("failed to get diff size for layer %v", chainID)
+			}
+			lu := &types.LayerUsage{
+				ChainID:    chainID.String(),
+				Size:       size,
+				Images:     []string{},
+				Containers: []string{},
+			}
+			if parent := l.Parent(); parent != nil {
+				lu.Parent = parent.ChainID().String()
+			}
+			layers[chainID] = lu
+		}
+	}
+
+	imageLayers := make(map[image.ID][]layer.ChainID)
+	var imagesReclaimable []*types.ImageReclaimableSpace
+	for id, img := range i.imageStore.Map() {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		default:
+		}
+		refs := i.referenceStore.References(id.Digest())
+		if len(refs) == 0 && len(i.imageStore.Children(id)) != 0 {
+			continue
+		}
+		chainIDs := imageChainIDs(img)
+		imageLayers[id] = chainIDs
+
+		repoTags := []string{}
+		for _, ref := range refs {
+			if _, ok := ref.(reference.NamedTagged); ok {
+				repoTags = append(repoTags, reference.FamiliarString(ref))
+			}
+		}
+		imagesReclaimable = append(imagesReclaimable, &types.ImageReclaimableSpace{
+			ID:       id.String(),
+			RepoTags: repoTags,
+		})
+		for _, chainID := range chainIDs {
+			if lu, ok := layers[chainID]; ok {
+				lu.Images = append(lu.Images, id.String())
+			}
+		}
+	}
+
+	for _, c := range i.containers.List() {
+		if c.ImageID == "" {
+			continue
+		}
+		chainIDs, ok := imageLayers[c.ImageID]
+		if !ok {
+			img, err := i.imageStore.Get(c.ImageID)
+			if err != nil {
+				continue
+			}
+			chainIDs = imageChainIDs(img)
+		}
+		for _, chainID := range chainIDs {
+			if lu, ok := layers[chainID]; ok {
+				lu.Containers = append(lu.Containers, c.ID)
+			}
+		}
+	}
+
+	for _, ir := range imagesReclaimable {
+		for _, chainID := range imageLayers[image.ID(ir.ID)] {
+			lu, ok := layers[chainID]
+			if !ok {
+				continue
+			}
+			ir.Size += lu.Size
+			if len(lu.Images) == 1 && len(lu.Containers) == 0 {
+				ir.Reclaimable += lu.Size
+			}
+		}
+	}
+
+	layersUsage := make([]*types.LayerUsage, 0, len(layers))
+	for _, lu := range layers {
+		sort.Strings(lu.Images)
+		sort.Strings(lu.Containers)
+		layersUsage = append(layersUsage, lu)
+	}
+	sort.Slice(layersUsage, func(a, b int) bool {
+		return layersUsage[a].ChainID < layersUsage[b].ChainID
+	})
+	sort.Slice(imagesReclaimable, func(a, b int) bool {
+		return imagesReclaimable[a].ID < imagesReclaimable[b].ID
+	})
+	return layersUsage, imagesReclaimable, nil
+}
+
+// imageChainIDs returns the chain IDs of the layers of the image, from the
+// base layer to the top layer.
+func imageChainIDs(img *image.Image) []layer.ChainID {
+	if img.RootFS == nil {
+		return nil
+	}
+	rootFS := *img.RootFS
+	rootFS.DiffIDs = nil
+	chainIDs := make([]layer.ChainID, 0, len(img.RootFS.DiffIDs))
+	for _, diffID := range img.RootFS.DiffIDs {
+		rootFS.Append(diffID)
+		chainIDs = append(chainIDs, rootFS.ChainID())
+	}
+	return chainIDs
+}
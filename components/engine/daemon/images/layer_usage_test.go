@@ -0,0 +1,98 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"context"
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/opencontainers/go-digest"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func createTestImageWithLayers(t *testing.T, i *ImageService, diffIDs []layer.DiffID, tag string) image.ID {
+	rootFS := image.NewRootFS()
+	for _, diffID := range diffIDs {
+		rootFS.Append(diffID)
+	}
+	config, err := json.Marshal(map[string]interface{}{
+		"comment": tag,
+		"rootfs":  rootFS,
+	})
+	assert.NilError(t, err)
+	id, err := i.imageStore.Create(config)
+	assert.NilError(t, err)
+	ref, err := reference.ParseNormalizedNamed(tag)
+	assert.NilError(t, err)
+	assert.NilError(t, i.referenceStore.AddTag(ref, id.Digest(), false))
+	return id
+}
+
+func TestLayersUsage(t *testing.T) {
+	i, containers, cleanup := newTestImageService(t)
+	defer cleanup()
+
+	d1 := layer.DiffID(digest.FromString("1"))
+	d2 := layer.DiffID(digest.FromString("2"))
+	d3 := layer.DiffID(digest.FromString("3"))
+	base := &fakeLayer{chainID: layer.CreateChainID([]layer.DiffID{d1}), size: 100}
+	l2 := &fakeLayer{chainID: layer.CreateChainID([]layer.DiffID{d1, d2}), parent: base, size: 20}
+	l3 := &fakeLayer{chainID: layer.CreateChainID([]layer.DiffID{d1, d3}), parent: base, size: 30}
+	ls := i.layerStores[runtime.GOOS].(*fakeLayerStore)
+	for _, l := range []*fakeLayer{base, l2, l3} {
+		ls.layers[l.chainID] = l
+	}
+
+	imgBase := createTestImageWithLayers(t, i, []layer.DiffID{d1}, "base:latest")
+	img2 := createTestImageWithLayers(t, i, []layer.DiffID{d1, d2}, "two:latest")
+	img3 := createTestImageWithLayers(t, i, []layer.DiffID{d1, d3}, "three:latest")
+	c := container.NewBaseContainer("container", "")
+	c.ImageID = img3
+	containers.containers = []*container.Container{c}
+
+	layers, images, err := i.LayersUsage(context.Background())
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(layers, 3))
+	usage := map[string]int{}
+	for idx, lu := range layers {
+		usage[lu.ChainID] = idx
+	}
+
+	lu := layers[usage[base.chainID.String()]]
+	assert.Check(t, is.Equal(int64(100), lu.Size))
+	assert.Check(t, is.Equal("", lu.Parent))
+	assert.Check(t, is.Len(lu.Images, 3))
+	assert.Check(t, is.DeepEqual([]string{"container"}, lu.Containers))
+
+	lu = layers[usage[l2.chainID.String()]]
+	assert.Check(t, is.Equal(base.chainID.String(), lu.Parent))
+	assert.Check(t, is.DeepEqual([]string{img2.String()}, lu.Images))
+	assert.Check(t, is.Len(lu.Containers, 0))
+
+	lu = layers[usage[l3.chainID.String()]]
+	assert.Check(t, is.DeepEqual([]string{img3.String()}, lu.Images))
+	assert.Check(t, is.DeepEqual([]string{"container"}, lu.Containers))
+
+	assert.Assert(t, is.Len(images, 3))
+	for _, ir := range images {
+		switch image.ID(ir.ID) {
+		case imgBase:
+			assert.Check(t, is.DeepEqual([]string{"base:latest"}, ir.RepoTags))
+			assert.Check(t, is.Equal(int64(100), ir.Size))
+			assert.Check(t, is.Equal(int64(0), ir.Reclaimable))
+		case img2:
+			assert.Check(t, is.Equal(int64(120), ir.Size))
+			assert.Check(t, is.Equal(int64(20), ir.Reclaimable))
+		case img3:
+			assert.Check(t, is.Equal(int64(130), ir.Size))
+			assert.Check(t, is.Equal(int64(0), ir.Reclaimable))
+		default:
+			t.Errorf("unexpected image %s", ir.ID)
+		}
+	}
+}
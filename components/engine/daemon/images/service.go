@@ -31,6 +31,7 @@ type containerStore interface {
 
 // ImageServiceConfig is the configuration used to create a new ImageService
 type ImageServiceConfig struct {
+	BlobMirrors               *distribution.BlobMirrors
 	ContainerStore            containerStore
 	DistributionMetadataStore metadata.Store
 	EventsService             *daemonevents.Events
@@ -38,6 +39,9 @@ type ImageServiceConfig struct {
 	LayerStores               map[string]layer.Store
 	MaxConcurrentDownloads    int
 	MaxConcurrentUploads      int
+	MaxDownloadBandwidth      int64
+	MaxUploadBandwidth        int64
+	PartialDownloads          *distribution.PartialDownloads
 	ReferenceStore            dockerreference.Store
 	RegistryService           registry.Service
 	TrustKey                  libtrust.PrivateKey
@@ -47,28 +51,34 @@ type ImageServiceConfig struct {
 func NewImageService(config ImageServiceConfig) *ImageService {
 	logrus.Debugf("Max Concurrent Downloads: %d", config.MaxConcurrentDownloads)
 	logrus.Debugf("Max Concurrent Uploads: %d", config.MaxConcurrentUploads)
-	return &ImageService{
+	i := &ImageService{
+		blobMirrors:               config.BlobMirrors,
 		containers:                config.ContainerStore,
 		distributionMetadataStore: config.DistributionMetadataStore,
 		downloadManager:           xfer.NewLayerDownloadManager(config.LayerStores, config.MaxConcurrentDownloads),
 		eventsService:             config.EventsService,
 		imageStore:                config.ImageStore,
 		layerStores:               config.LayerStores,
+		partialDownloads:          config.PartialDownloads,
 		referenceStore:            config.ReferenceStore,
 		registryService:           config.RegistryService,
 		trustKey:                  config.TrustKey,
 		uploadManager:             xfer.NewLayerUploadManager(config.MaxConcurrentUploads),
 	}
+	i.UpdateBandwidth(config.MaxDownloadBandwidth, config.MaxUploadBandwidth)
+	return i
 }
 
 // ImageService provides a backend for image management
 type ImageService struct {
+	blobMirrors               *distribution.BlobMirrors
 	containers                containerStore
 	distributionMetadataStore metadata.Store
 	downloadManager           *xfer.LayerDownloadManager
 	eventsService             *daemonevents.Events
 	imageStore                image.Store
 	layerStores               map[string]layer.Store // By operating system
+	partialDownloads          *distribution.PartialDownloads
 	pruneRunning              int32
 	referenceStore            dockerreference.Store
 	registryService           registry.Service
@@ -247,3 +257,16 @@ func (i *ImageService) UpdateConfig(maxDownloads, maxUploads *int) {
 		i.uploadManager.SetConcurrency(*maxUploads)
 	}
 }
+
+// UpdateBandwidth sets the max bandwidth of all the downloads and uploads,
+// in bytes per second. The bandwidth is not limited if it is zero.
+//
+// called from reload.go
+func (i *ImageService) UpdateBandwidth(maxDownload, maxUpload int64) {
+	if i.downloadManager != nil {
+		i.downloadManager.SetBandwidth(maxDownload)
+	}
+	if i.uploadManager != nil {
+		i.uploadManager.SetBandwidth(maxUpload)
+	}
+}
@@ -160,6 +160,11 @@ func (daemon *Daemon) getInspectData(container *container.Container) (*types.Con
 		FinishedAt: container.State.FinishedAt.Format(time.RFC3339Nano),
 		Health:     containerHealth,
 	}
+	if container.State.Restarting {
+		if next := container.RestartManager().NextRestart(); !next.IsZero() {
+			containerState.NextRestartAt = next.Format(time.RFC3339Nano)
+		}
+	}
 
 	contJSONBase := &types.ContainerJSONBase{
 		ID:           container.ID,
@@ -27,6 +27,7 @@ type Copier struct {
 	// srcs is map of name -> reader pairs, for example "stdout", "stderr"
 	srcs      map[string]io.Reader
 	dst       Logger
+	parse     FieldParser
 	copyJobs  sync.WaitGroup
 	closeOnce sync.Once
 	closed    chan struct{}
@@ -41,6 +42,12 @@ func NewCopier(srcs map[string]io.Reader, dst Logger) *Copier {
 	}
 }
 
+// SetFieldParser sets the parser used to extract structured fields from
+// the lines that are copied. It must be called before Run.
+func (c *Copier) SetFieldParser(p FieldParser) {
+	c.parse = p
+}
+
 // Run starts logs copying
 func (c *Copier) Run() {
 	for src, w := range c.srcs {
@@ -116,6 +123,9 @@ func (c *Copier) copySrc(name string, src io.Reader) {
 					}
 					if msg.PLogMetaData == nil {
 						msg.Timestamp = time.Now().UTC()
+						if c.parse != nil {
+							msg.Fields = c.parse(msg.Line)
+						}
 					} else {
 						msg.Timestamp = partialTS
 					}
@@ -119,6 +119,9 @@ func GetLogDriver(name string) (Creator, error) {
 var builtInLogOpts = map[string]bool{
 	"mode":            true,
 	"max-buffer-size": true,
+	"parse":           true,
+	"rate":            true,
+	"burst":           true,
 }
 
 // ValidateLogOpts checks the options for the given log driver. The
@@ -134,6 +137,20 @@ func ValidateLogOpts(name string, cfg map[string]string) error {
 		return fmt.Errorf("logger: logging mode not supported: %s", cfg["mode"])
 	}
 
+	if format, ok := cfg["parse"]; ok {
+		if _, err := GetFieldParser(format); err != nil {
+			return err
+		}
+	}
+
+	if _, _, err := ParseRateLimit(cfg); err != nil {
+		return err
+	}
+
+	if drivers := SplitDrivers(name); len(drivers) > 1 {
+		return validateTeeLogOpts(drivers, cfg)
+	}
+
 	if s, ok := cfg["max-buffer-size"]; ok {
 		if containertypes.LogMode(cfg["mode"]) != containertypes.LogModeNonBlock {
 			return fmt.Errorf("logger: max-buffer-size option is only supported with 'mode=%s'", containertypes.LogModeNonBlock)
@@ -160,3 +177,26 @@ func ValidateLogOpts(name string, cfg map[string]string) error {
 	}
 	return nil
 }
+
+// validateTeeLogOpts checks the options of a multi-driver log
+// configuration. Messages are always buffered per driver, so the blocking
+// mode is not supported.
+func validateTeeLogOpts(drivers []string, cfg map[string]string) error {
+	if err := validateDrivers(drivers); err != nil {
+		return err
+	}
+	if containertypes.LogMode(cfg["mode"]) == containertypes.LogModeBlocking {
+		return fmt.Errorf("logger: multiple log drivers only support 'mode=%s'", containertypes.LogModeNonBlock)
+	}
+	if s, ok := cfg["max-buffer-size"]; ok {
+		if _, err := units.RAMInBytes(s); err != nil {
+			return errors.Wrap(err, "error parsing option max-buffer-size")
+		}
+	}
+	for _, d := range drivers {
+		if err := ValidateLogOpts(d, DriverOpts(d, drivers, cfg)); err != nil {
+			return errors.Wrapf(err, "invalid options for log driver %s", d)
+		}
+	}
+	return nil
+}
@@ -163,6 +163,11 @@ func (f *fluentd) Log(msg *logger.Message) error {
 	for k, v := range f.extra {
 		data[k] = v
 	}
+	for k, v := range msg.Fields {
+		if _, exists := data[k]; !exists {
+			data[k] = v
+		}
+	}
 	if msg.PLogMetaData != nil {
 		data["partial_message"] = "true"
 	}
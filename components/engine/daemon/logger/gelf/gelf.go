@@ -25,6 +25,7 @@ type gelfLogger struct {
 	info     logger.Info
 	hostname string
 	rawExtra json.RawMessage
+	extra    map[string]interface{}
 }
 
 func init() {
@@ -105,6 +106,7 @@ func New(info logger.Info) (logger.Logger, error) {
 		info:     info,
 		hostname: hostname,
 		rawExtra: rawExtra,
+		extra:    extra,
 	}, nil
 }
 
@@ -179,6 +181,18 @@ func (s *gelfLogger) Log(msg *logger.Message) error {
 		Level:    int32(level),
 		RawExtra: s.rawExtra,
 	}
+	for k, v := range msg.Fields {
+		// Parsed fields are sent as additional fields, which can't
+		// override the fields set by the driver.
+		k = "_" + k
+		if _, exists := s.extra[k]; exists || k == "_id" {
+			continue
+		}
+		if m.Extra == nil {
+			m.Extra = make(map[string]interface{}, len(msg.Fields))
+		}
+		m.Extra[k] = v
+	}
 	logger.PutMessage(msg)
 
 	if err := s.writer.WriteMessage(&m); err != nil {
@@ -106,6 +106,12 @@ func (s *journald) Log(msg *logger.Message) error {
 	if msg.PLogMetaData != nil {
 		vars["CONTAINER_PARTIAL_MESSAGE"] = "true"
 	}
+	for k, v := range msg.Fields {
+		k = sanitizeKeyMod(k)
+		if _, exists := vars[k]; !exists && k != "" {
+			vars[k] = v
+		}
+	}
 
 	line := string(msg.Line)
 	source := msg.Source
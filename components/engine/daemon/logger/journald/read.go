@@ -231,6 +231,44 @@ drain:
 	return cursor, done, shown
 }
 
+// skipBackMatching moves backwards in the journal until config.Tail entries
+// that pass the filters of config have been passed, but not before since.
+func skipBackMatching(j *C.sd_journal, config logger.ReadConfig, sinceUnixMicro uint64) {
+	var (
+		msg               *C.char
+		length            C.size_t
+		stamp             C.uint64_t
+		priority, partial C.int
+	)
+	for matched := 0; matched < config.Tail; {
+		if C.sd_journal_previous(j) <= 0 {
+			return
+		}
+		if sinceUnixMicro != 0 && C.sd_journal_get_realtime_usec(j, &stamp) == 0 && uint64(stamp) < sinceUnixMicro {
+			C.sd_journal_seek_realtime_usec(j, C.uint64_t(sinceUnixMicro))
+			return
+		}
+		i := C.get_message(j, &msg, &length, &partial)
+		if i == -C.ENOENT || i == -C.EADDRNOTAVAIL {
+			continue
+		}
+		m := logger.Message{Line: C.GoBytes(unsafe.Pointer(msg), C.int(length))}
+		if partial == 0 {
+			m.Line = append(m.Line, "\n"...)
+		}
+		if C.get_priority(j, &priority) == 0 {
+			if priority == C.int(journal.PriErr) {
+				m.Source = "stderr"
+			} else if priority == C.int(journal.PriInfo) {
+				m.Source = "stdout"
+			}
+		}
+		if config.Matches(&m) {
+			matched++
+		}
+	}
+}
+
 func (s *journald) followJournal(logWatcher *logger.LogWatcher, j *C.sd_journal, cursor *C.char, untilUnixMicro uint64) *C.char {
 	s.mu.Lock()
 	s.readers[logWatcher] = struct{}{}
@@ -339,8 +377,11 @@ func (s *journald) readLogs(logWatcher *logger.LogWatcher, config logger.ReadCon
 			logWatcher.Err <- errors.New("error seeking to end of journal: " + CErr(rc))
 			return
 		}
-		// (Try to) skip backwards by the requested number of lines...
-		if C.sd_journal_previous_skip(j, C.uint64_t(config.Tail)) >= 0 {
+		if config.HasFilters() {
+			// The tail is made of the last entries that pass the filters.
+			skipBackMatching(j, config, sinceUnixMicro)
+		} else if C.sd_journal_previous_skip(j, C.uint64_t(config.Tail)) >= 0 {
+			// (Try to) skip backwards by the requested number of lines...
 			// ...but not before "since"
 			if sinceUnixMicro != 0 &&
 				C.sd_journal_get_realtime_usec(j, &stamp) == 0 &&
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/daemon/logger/jsonfilelog/jsonlog"
@@ -65,6 +66,28 @@ func New(info logger.Info) (logger.Logger, error) {
 		}
 	}
 
+	var rotateInterval time.Duration
+	if rotate, ok := info.Config["rotate"]; ok {
+		var err error
+		rotateInterval, err = loggerutils.ParseRotateInterval(rotate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var maxAge time.Duration
+	if maxAgeString, ok := info.Config["max-age"]; ok {
+		var err error
+		maxAge, err = loggerutils.ParseMaxAge(maxAgeString)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := loggerutils.ValidateRotationOpts(info.Config, 1); err != nil {
+		return nil, err
+	}
+
 	var compress bool
 	if compressString, ok := info.Config["compress"]; ok {
 		var err error
@@ -72,8 +95,8 @@ func New(info logger.Info) (logger.Logger, error) {
 		if err != nil {
 			return nil, err
 		}
-		if compress && (maxFiles == 1 || capval == -1) {
-			return nil, fmt.Errorf("compress cannot be true when max-file is less than 2 or max-size is not set")
+		if compress && (maxFiles == 1 || (capval == -1 && rotateInterval == 0)) {
+			return nil, fmt.Errorf("compress cannot be true when max-file is less than 2 or neither max-size nor rotate is set")
 		}
 	}
 
@@ -110,7 +133,7 @@ func New(info logger.Info) (logger.Logger, error) {
 		return b, nil
 	}
 
-	writer, err := loggerutils.NewLogFile(info.LogPath, capval, maxFiles, compress, marshalFunc, decodeFunc, 0640, getTailReader)
+	writer, err := loggerutils.NewLogFile(info.LogPath, capval, maxFiles, compress, rotateInterval, maxAge, marshalFunc, decodeFunc, 0640, getTailReader)
 	if err != nil {
 		return nil, err
 	}
@@ -155,6 +178,8 @@ func ValidateLogOpt(cfg map[string]string) error {
 		case "max-file":
 		case "max-size":
 		case "compress":
+		case "rotate":
+		case "max-age":
 		case "labels":
 		case "env":
 		case "env-regex":
@@ -163,7 +188,7 @@ func ValidateLogOpt(cfg map[string]string) error {
 			return fmt.Errorf("unknown log opt '%s' for json-file log driver", key)
 		}
 	}
-	return nil
+	return loggerutils.ValidateRotationOpts(cfg, 1)
 }
 
 // Close closes underlying file and signals all the readers
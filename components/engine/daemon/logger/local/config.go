@@ -1,6 +1,8 @@
 package local
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 )
 
@@ -9,6 +11,8 @@ type CreateConfig struct {
 	DisableCompression bool
 	MaxFileSize        int64
 	MaxFileCount       int
+	RotateInterval     time.Duration
+	MaxAge             time.Duration
 }
 
 func newDefaultConfig() *CreateConfig {
@@ -27,6 +31,10 @@ func validateConfig(cfg *CreateConfig) error {
 		return errors.New("max file count cannot be less than 0")
 	}
 
+	if (cfg.RotateInterval > 0 || cfg.MaxAge > 0) && cfg.MaxFileCount < 2 {
+		return errors.New("rotation by time and max age cannot be enabled when max file count is less than 2")
+	}
+
 	if !cfg.DisableCompression {
 		if cfg.MaxFileCount <= 1 {
 			return errors.New("compression cannot be enabled when max file count is 1")
@@ -35,6 +35,8 @@ var LogOptKeys = map[string]bool{
 	"max-file": true,
 	"max-size": true,
 	"compress": true,
+	"rotate":   true,
+	"max-age":  true,
 }
 
 // ValidateLogOpt looks for log driver specific options.
@@ -44,7 +46,7 @@ func ValidateLogOpt(cfg map[string]string) error {
 			return errors.Errorf("unknown log opt '%s' for log driver %s", key, Name)
 		}
 	}
-	return nil
+	return loggerutils.ValidateRotationOpts(cfg, defaultMaxFileCount)
 }
 
 func init() {
@@ -94,6 +96,22 @@ func New(info logger.Info) (logger.Logger, error) {
 		}
 		cfg.DisableCompression = !compressLogs
 	}
+
+	if rotate, ok := info.Config["rotate"]; ok {
+		var err error
+		cfg.RotateInterval, err = loggerutils.ParseRotateInterval(rotate)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(err)
+		}
+	}
+
+	if maxAge, ok := info.Config["max-age"]; ok {
+		var err error
+		cfg.MaxAge, err = loggerutils.ParseMaxAge(maxAge)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(err)
+		}
+	}
 	return newDriver(info.LogPath, cfg)
 }
 
@@ -140,7 +158,7 @@ func newDriver(logPath string, cfg *CreateConfig) (logger.Logger, error) {
 		return nil, errdefs.InvalidParameter(err)
 	}
 
-	lf, err := loggerutils.NewLogFile(logPath, cfg.MaxFileSize, cfg.MaxFileCount, !cfg.DisableCompression, makeMarshaller(), decodeFunc, 0640, getTailReader)
+	lf, err := loggerutils.NewLogFile(logPath, cfg.MaxFileSize, cfg.MaxFileCount, !cfg.DisableCompression, cfg.RotateInterval, cfg.MaxAge, makeMarshaller(), decodeFunc, 0640, getTailReader)
 	if err != nil {
 		return nil, err
 	}
@@ -8,10 +8,12 @@
 package logger // import "github.com/docker/docker/daemon/logger"
 
 import (
+	"regexp"
 	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types/backend"
+	"github.com/pkg/errors"
 )
 
 // ErrReadLogsNotSupported is returned when the underlying log driver does not support reading
@@ -61,6 +63,7 @@ func (m *Message) reset() {
 	m.Source = ""
 	m.Attrs = nil
 	m.PLogMetaData = nil
+	m.Fields = nil
 
 	m.Err = nil
 }
@@ -91,6 +94,68 @@ type ReadConfig struct {
 	Until  time.Time
 	Tail   int
 	Follow bool
+
+	// Include, if set, only reads the messages whose line matches it.
+	Include *regexp.Regexp
+	// Exclude, if set, skips the messages whose line matches it.
+	Exclude *regexp.Regexp
+	// Sources, if not empty, only reads the messages from these sources
+	// ("stdout" or "stderr").
+	Sources []string
+}
+
+// HasFilters returns whether the config filters the messages. Tail then
+// applies to the messages that pass the filters.
+func (c *ReadConfig) HasFilters() bool {
+	return c.Include != nil || c.Exclude != nil || len(c.Sources) > 0
+}
+
+// Matches returns whether a message passes the filters of the config.
+func (c *ReadConfig) Matches(msg *Message) bool {
+	if !c.HasFilters() {
+		return true
+	}
+	if len(c.Sources) > 0 {
+		found := false
+		for _, s := range c.Sources {
+			if s == msg.Source {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return c.MatchLine(msg.Line)
+}
+
+// MatchLine returns whether a log line passes the Include and Exclude
+// filters of the config.
+func (c *ReadConfig) MatchLine(line []byte) bool {
+	if c.Include != nil && !c.Include.Match(line) {
+		return false
+	}
+	return c.Exclude == nil || !c.Exclude.Match(line)
+}
+
+// CompileLineFilters compiles the regular expressions used for the Include
+// and Exclude filters of a ReadConfig. Empty expressions disable the
+// corresponding filter.
+func CompileLineFilters(include, exclude string) (*regexp.Regexp, *regexp.Regexp, error) {
+	var inc, exc *regexp.Regexp
+	var err error
+	if include != "" {
+		if inc, err = regexp.Compile(include); err != nil {
+			return nil, nil, errors.Wrap(err, "invalid include expression")
+		}
+	}
+	if exclude != "" {
+		if exc, err = regexp.Compile(exclude); err != nil {
+			return nil, nil, errors.Wrap(err, "invalid exclude expression")
+		}
+	}
+	return inc, exc, nil
 }
 
 // LogReader is the interface for reading log messages for loggers that support reading.
@@ -16,6 +16,13 @@ func (m *Message) copy() *Message {
 		copy(msg.Attrs, m.Attrs)
 	}
 
+	if m.Fields != nil {
+		msg.Fields = make(map[string]string, len(m.Fields))
+		for k, v := range m.Fields {
+			msg.Fields[k] = v
+		}
+	}
+
 	msg.Line = append(make([]byte, 0, len(m.Line)), m.Line...)
 	return msg
 }
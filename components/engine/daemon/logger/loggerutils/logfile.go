@@ -80,13 +80,16 @@ type LogFile struct {
 	mu              sync.RWMutex // protects the logfile access
 	f               *os.File     // store for closing
 	closed          bool
-	rotateMu        sync.Mutex // blocks the next rotation until the current rotation is completed
-	capacity        int64      // maximum size of each file
-	currentSize     int64      // current size of the latest file
-	maxFiles        int        // maximum number of files
-	compress        bool       // whether old versions of log files are compressed
-	lastTimestamp   time.Time  // timestamp of the last log
-	filesRefCounter refCounter // keep reference-counted of decompressed files
+	rotateMu        sync.Mutex    // blocks the next rotation until the current rotation is completed
+	capacity        int64         // maximum size of each file
+	currentSize     int64         // current size of the latest file
+	maxFiles        int           // maximum number of files
+	compress        bool          // whether old versions of log files are compressed
+	rotateInterval  time.Duration // interval at which the file is rotated, if any
+	maxAge          time.Duration // maximum age of the rotated files, if any
+	period          time.Time     // start of the rotation interval of the latest file
+	lastTimestamp   time.Time     // timestamp of the last log
+	filesRefCounter refCounter    // keep reference-counted of decompressed files
 	notifyRotate    *pubsub.Publisher
 	marshal         logger.MarshalFunc
 	createDecoder   makeDecoderFunc
@@ -109,8 +112,10 @@ type SizeReaderAt interface {
 // contains, and any error that occurs.
 type GetTailReaderFunc func(ctx context.Context, f SizeReaderAt, nLogLines int) (rdr io.Reader, nLines int, err error)
 
-// NewLogFile creates new LogFile
-func NewLogFile(logPath string, capacity int64, maxFiles int, compress bool, marshaller logger.MarshalFunc, decodeFunc makeDecoderFunc, perms os.FileMode, getTailReader GetTailReaderFunc) (*LogFile, error) {
+// NewLogFile creates new LogFile. In addition to being rotated when it
+// reaches capacity, the file is rotated every rotateInterval if it is not
+// zero, and rotated files older than maxAge are removed if it is not zero.
+func NewLogFile(logPath string, capacity int64, maxFiles int, compress bool, rotateInterval, maxAge time.Duration, marshaller logger.MarshalFunc, decodeFunc makeDecoderFunc, perms os.FileMode, getTailReader GetTailReaderFunc) (*LogFile, error) {
 	log, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, perms)
 	if err != nil {
 		return nil, err
@@ -121,12 +126,27 @@ func NewLogFile(logPath string, capacity int64, maxFiles int, compress bool, mar
 		return nil, err
 	}
 
+	var period time.Time
+	if rotateInterval > 0 && size > 0 {
+		stat, err := log.Stat()
+		if err != nil {
+			return nil, err
+		}
+		period = stat.ModTime().Truncate(rotateInterval)
+	}
+	if maxAge > 0 {
+		removeExpired(logPath, maxFiles, time.Now().Add(-maxAge))
+	}
+
 	return &LogFile{
 		f:               log,
 		capacity:        capacity,
 		currentSize:     size,
 		maxFiles:        maxFiles,
 		compress:        compress,
+		rotateInterval:  rotateInterval,
+		maxAge:          maxAge,
+		period:          period,
 		filesRefCounter: refCounter{counter: make(map[string]int)},
 		notifyRotate:    pubsub.NewPublisher(0, 1),
 		marshal:         marshaller,
@@ -144,6 +164,7 @@ func (w *LogFile) WriteLogEntry(msg *logger.Message) error {
 		return errors.Wrap(err, "error marshalling log message")
 	}
 
+	ts := msg.Timestamp
 	logger.PutMessage(msg)
 
 	w.mu.Lock()
@@ -152,7 +173,7 @@ func (w *LogFile) WriteLogEntry(msg *logger.Message) error {
 		return errors.New("cannot write because the output file was closed")
 	}
 
-	if err := w.checkCapacityAndRotate(); err != nil {
+	if err := w.checkCapacityAndRotate(ts); err != nil {
 		w.mu.Unlock()
 		return err
 	}
@@ -160,18 +181,14 @@ func (w *LogFile) WriteLogEntry(msg *logger.Message) error {
 	n, err := w.f.Write(b)
 	if err == nil {
 		w.currentSize += int64(n)
-		w.lastTimestamp = msg.Timestamp
+		w.lastTimestamp = ts
 	}
 	w.mu.Unlock()
 	return err
 }
 
-func (w *LogFile) checkCapacityAndRotate() error {
-	if w.capacity == -1 {
-		return nil
-	}
-
-	if w.currentSize >= w.capacity {
+func (w *LogFile) checkCapacityAndRotate(now time.Time) error {
+	if w.rotationDue(now) {
 		w.rotateMu.Lock()
 		fname := w.f.Name()
 		if err := w.f.Close(); err != nil {
@@ -182,6 +199,9 @@ func (w *LogFile) checkCapacityAndRotate() error {
 			w.rotateMu.Unlock()
 			return err
 		}
+		if w.maxAge > 0 {
+			removeExpired(fname, w.maxFiles, now.Add(-w.maxAge))
+		}
 		file, err := os.OpenFile(fname, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, w.perms)
 		if err != nil {
 			w.rotateMu.Unlock()
@@ -189,6 +209,9 @@ func (w *LogFile) checkCapacityAndRotate() error {
 		}
 		w.f = file
 		w.currentSize = 0
+		if w.rotateInterval > 0 {
+			w.period = now.Truncate(w.rotateInterval)
+		}
 		w.notifyRotate.Publish(struct{}{})
 
 		if w.maxFiles <= 1 || !w.compress {
@@ -205,6 +228,25 @@ func (w *LogFile) checkCapacityAndRotate() error {
 	return nil
 }
 
+// rotationDue returns whether the file must be rotated before writing a
+// message logged at the given time, because the file is full or because the
+// message belongs to a later rotation interval than the file.
+func (w *LogFile) rotationDue(now time.Time) bool {
+	if w.capacity != -1 && w.currentSize >= w.capacity {
+		return true
+	}
+	if w.rotateInterval == 0 {
+		return false
+	}
+	period := now.Truncate(w.rotateInterval)
+	if w.currentSize == 0 {
+		// there is nothing to rotate, the file starts in this interval
+		w.period = period
+		return false
+	}
+	return period.After(w.period)
+}
+
 func rotate(name string, maxFiles int, compress bool) error {
 	if maxFiles < 2 {
 		return nil
@@ -236,6 +278,22 @@ func rotate(name string, maxFiles int, compress bool) error {
 	return nil
 }
 
+// removeExpired removes the rotated log files that were last modified
+// before the given time.
+func removeExpired(name string, maxFiles int, before time.Time) {
+	for i := 1; i < maxFiles; i++ {
+		for _, fileName := range []string{name + "." + strconv.Itoa(i), name + "." + strconv.Itoa(i) + ".gz"} {
+			stat, err := os.Stat(fileName)
+			if err != nil || !stat.ModTime().Before(before) {
+				continue
+			}
+			if err := os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+				logrus.WithError(err).WithField("file", fileName).Warn("Failed to remove expired log file")
+			}
+		}
+	}
+}
+
 func compressFile(fileName string, lastTimestamp time.Time) {
 	file, err := os.Open(fileName)
 	if err != nil {
@@ -373,7 +431,7 @@ func (w *LogFile) ReadLogs(config logger.ReadConfig, watcher *logger.LogWatcher)
 
 	notifyRotate := w.notifyRotate.Subscribe()
 	defer w.notifyRotate.Evict(notifyRotate)
-	followLogs(currentFile, watcher, notifyRotate, w.createDecoder, config.Since, config.Until)
+	followLogs(currentFile, watcher, notifyRotate, w.createDecoder, config)
 }
 
 func (w *LogFile) openRotatedFiles(config logger.ReadConfig) (files []*os.File, err error) {
@@ -419,12 +477,25 @@ func (w *LogFile) openRotatedFiles(config logger.ReadConfig) (files []*os.File,
 			}
 			if tmpFile == nil {
 				// The log before `config.Since` does not need to read
-				break
+				continue
 			}
 
 			files = append(files, tmpFile)
 			continue
 		}
+		if !config.Since.IsZero() {
+			// Skip the files last written before `config.Since`
+			// without reading them.
+			stat, err := f.Stat()
+			if err != nil {
+				f.Close()
+				return nil, errors.Wrap(err, "error reading rotated log file")
+			}
+			if stat.ModTime().Before(config.Since) {
+				f.Close()
+				continue
+			}
+		}
 		files = append(files, f)
 	}
 
@@ -495,7 +566,11 @@ func tailFiles(files []SizeReaderAt, watcher *logger.LogWatcher, createDecoder m
 
 	readers := make([]io.Reader, 0, len(files))
 
-	if config.Tail > 0 {
+	// With filters, the tail is made of the last matching lines, which can
+	// only be found by reading all the lines.
+	filterTail := config.Tail > 0 && config.HasFilters()
+
+	if config.Tail > 0 && !filterTail {
 		for i := len(files) - 1; i >= 0 && nLines > 0; i-- {
 			tail, n, err := getTailReader(ctx, files[i], nLines)
 			if err != nil {
@@ -511,6 +586,16 @@ func tailFiles(files []SizeReaderAt, watcher *logger.LogWatcher, createDecoder m
 		}
 	}
 
+	send := func(msg *logger.Message) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case watcher.Msg <- msg:
+			return true
+		}
+	}
+
+	var tail []*logger.Message
 	rdr := io.MultiReader(readers...)
 	decodeLogLine := createDecoder(rdr)
 	for {
@@ -518,24 +603,40 @@ func tailFiles(files []SizeReaderAt, watcher *logger.LogWatcher, createDecoder m
 		if err != nil {
 			if errors.Cause(err) != io.EOF {
 				watcher.Err <- err
+				return
 			}
-			return
+			break
 		}
 		if !config.Since.IsZero() && msg.Timestamp.Before(config.Since) {
 			continue
 		}
 		if !config.Until.IsZero() && msg.Timestamp.After(config.Until) {
+			break
+		}
+		if !config.Matches(msg) {
+			continue
+		}
+		if filterTail {
+			tail = append(tail, msg)
+			if len(tail) > config.Tail {
+				tail[0] = nil
+				tail = tail[1:]
+			}
+			continue
+		}
+		if !send(msg) {
 			return
 		}
-		select {
-		case <-ctx.Done():
+	}
+
+	for _, msg := range tail {
+		if !send(msg) {
 			return
-		case watcher.Msg <- msg:
 		}
 	}
 }
 
-func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan interface{}, createDecoder makeDecoderFunc, since, until time.Time) {
+func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan interface{}, createDecoder makeDecoderFunc, config logger.ReadConfig) {
 	decodeLogLine := createDecoder(f)
 
 	name := f.Name()
@@ -663,12 +764,15 @@ func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan int
 		}
 
 		retries = 0 // reset retries since we've succeeded
-		if !since.IsZero() && msg.Timestamp.Before(since) {
+		if !config.Since.IsZero() && msg.Timestamp.Before(config.Since) {
 			continue
 		}
-		if !until.IsZero() && msg.Timestamp.After(until) {
+		if !config.Until.IsZero() && msg.Timestamp.After(config.Until) {
 			return
 		}
+		if !config.Matches(msg) {
+			continue
+		}
 		// send the message, unless the consumer is gone
 		select {
 		case logWatcher.Msg <- msg:
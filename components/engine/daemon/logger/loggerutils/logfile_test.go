@@ -6,6 +6,8 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/pkg/tailfile"
 	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
 )
 
 func TestTailFiles(t *testing.T) {
@@ -77,6 +80,58 @@ func TestTailFiles(t *testing.T) {
 	}
 }
 
+func TestTailFilesFilter(t *testing.T) {
+	files := []SizeReaderAt{
+		strings.NewReader("level=info msg=starting\nlevel=error msg=failed\n"),
+		strings.NewReader("level=error msg=retrying\nlevel=info msg=ready\n"),
+	}
+	createDecoder := func(r io.Reader) func() (*logger.Message, error) {
+		scanner := bufio.NewScanner(r)
+		return func() (*logger.Message, error) {
+			if !scanner.Scan() {
+				if err := scanner.Err(); err != nil {
+					return nil, err
+				}
+				return nil, io.EOF
+			}
+			return &logger.Message{Line: append([]byte{}, scanner.Bytes()...), Source: "stdout", Timestamp: time.Now()}, nil
+		}
+	}
+	tailReader := func(ctx context.Context, r SizeReaderAt, lines int) (io.Reader, int, error) {
+		return tailfile.NewTailReader(ctx, r, lines)
+	}
+
+	testCases := []struct {
+		tail     int
+		grep     string
+		exclude  string
+		expected []string
+	}{
+		{tail: -1, grep: "level=error", exclude: "retry", expected: []string{"level=error msg=failed"}},
+		// the tail is made of the last matching lines
+		{tail: 1, grep: "level=info", expected: []string{"level=info msg=ready"}},
+		{tail: 2, grep: "level=error", expected: []string{"level=error msg=failed", "level=error msg=retrying"}},
+		{tail: 3, exclude: "level=info", expected: []string{"level=error msg=failed", "level=error msg=retrying"}},
+		{tail: 1, expected: []string{"level=info msg=ready"}},
+	}
+	for _, tc := range testCases {
+		include, exclude, err := logger.CompileLineFilters(tc.grep, tc.exclude)
+		assert.NilError(t, err)
+		config := logger.ReadConfig{Tail: tc.tail, Include: include, Exclude: exclude}
+		watcher := logger.NewLogWatcher()
+		go func() {
+			tailFiles(files, watcher, createDecoder, tailReader, config)
+			close(watcher.Msg)
+		}()
+
+		var lines []string
+		for msg := range watcher.Msg {
+			lines = append(lines, string(msg.Line))
+		}
+		assert.DeepEqual(t, tc.expected, lines)
+	}
+}
+
 func TestFollowLogsConsumerGone(t *testing.T) {
 	lw := logger.NewLogWatcher()
 
@@ -94,9 +149,8 @@ func TestFollowLogsConsumerGone(t *testing.T) {
 	}
 
 	followLogsDone := make(chan struct{})
-	var since, until time.Time
 	go func() {
-		followLogs(f, lw, make(chan interface{}), makeDecoder, since, until)
+		followLogs(f, lw, make(chan interface{}), makeDecoder, logger.ReadConfig{})
 		close(followLogsDone)
 	}()
 
@@ -140,11 +194,9 @@ func TestFollowLogsProducerGone(t *testing.T) {
 			return &logger.Message{}, nil
 		}
 	}
-	var since, until time.Time
-
 	followLogsDone := make(chan struct{})
 	go func() {
-		followLogs(f, lw, make(chan interface{}), makeDecoder, since, until)
+		followLogs(f, lw, make(chan interface{}), makeDecoder, logger.ReadConfig{})
 		close(followLogsDone)
 	}()
 
@@ -201,3 +253,76 @@ func TestFollowLogsProducerGone(t *testing.T) {
 	default:
 	}
 }
+
+func TestRotateInterval(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	marshal := func(msg *logger.Message) ([]byte, error) {
+		return append(msg.Line, '\n'), nil
+	}
+	name := filepath.Join(dir, "container.log")
+	l, err := NewLogFile(name, -1, 3, false, time.Hour, 0, marshal, nil, 0640, nil)
+	assert.NilError(t, err)
+	defer l.Close()
+
+	start := time.Date(2019, 1, 2, 13, 0, 0, 0, time.UTC)
+	for i, ts := range []time.Time{start, start.Add(30 * time.Minute), start.Add(time.Hour), start.Add(3 * time.Hour)} {
+		msg := logger.NewMessage()
+		msg.Line = append(msg.Line, strconv.Itoa(i)...)
+		msg.Timestamp = ts
+		assert.NilError(t, l.WriteLogEntry(msg))
+	}
+
+	for file, content := range map[string]string{name + ".2": "0\n1\n", name + ".1": "2\n", name: "3\n"} {
+		b, err := ioutil.ReadFile(file)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(content, string(b)), file)
+	}
+}
+
+func TestRemoveExpired(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	name := filepath.Join(dir, "container.log")
+	now := time.Now()
+	for file, age := range map[string]time.Duration{name + ".1": time.Hour, name + ".2.gz": 48 * time.Hour, name + ".3": 72 * time.Hour} {
+		assert.NilError(t, ioutil.WriteFile(file, nil, 0640))
+		assert.NilError(t, os.Chtimes(file, now.Add(-age), now.Add(-age)))
+	}
+
+	removeExpired(name, 4, now.Add(-24*time.Hour))
+
+	_, err = os.Stat(name + ".1")
+	assert.Check(t, err)
+	_, err = os.Stat(name + ".2.gz")
+	assert.Check(t, os.IsNotExist(err))
+	_, err = os.Stat(name + ".3")
+	assert.Check(t, os.IsNotExist(err))
+}
+
+func TestValidateRotationOpts(t *testing.T) {
+	for _, tc := range []struct {
+		cfg         map[string]string
+		expectedErr string
+	}{
+		{cfg: map[string]string{}},
+		{cfg: map[string]string{"max-file": "1"}},
+		{cfg: map[string]string{"rotate": "daily"}, expectedErr: "max-file is less than 2"},
+		{cfg: map[string]string{"max-age": "7"}, expectedErr: "max-file is less than 2"},
+		{cfg: map[string]string{"rotate": "hourly", "max-file": "1"}, expectedErr: "max-file is less than 2"},
+		{cfg: map[string]string{"rotate": "hourly", "max-file": "invalid"}, expectedErr: "invalid value for max-file"},
+		{cfg: map[string]string{"rotate": "daily", "max-age": "7", "max-file": "2"}},
+	} {
+		err := ValidateRotationOpts(tc.cfg, 1)
+		if tc.expectedErr == "" {
+			assert.Check(t, err, tc.cfg)
+		} else {
+			assert.Check(t, is.ErrorContains(err, tc.expectedErr), tc.cfg)
+		}
+	}
+	assert.Check(t, ValidateRotationOpts(map[string]string{"rotate": "daily"}, 5))
+}
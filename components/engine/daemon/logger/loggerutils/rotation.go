@@ -0,0 +1,63 @@
+package loggerutils // import "github.com/docker/docker/daemon/logger/loggerutils"
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Values of the "rotate" log option, which rotates log files at fixed
+// intervals in addition to when they reach their maximum size.
+const (
+	RotateHourly = "hourly"
+	RotateDaily  = "daily"
+)
+
+// ParseRotateInterval returns the interval at which log files are rotated
+// for the given value of the "rotate" log option. Intervals start on the
+// hour, or at midnight UTC.
+func ParseRotateInterval(s string) (time.Duration, error) {
+	switch s {
+	case RotateHourly:
+		return time.Hour, nil
+	case RotateDaily:
+		return 24 * time.Hour, nil
+	default:
+		return 0, errors.Errorf("invalid value for rotate: %s: must be '%s' or '%s'", s, RotateHourly, RotateDaily)
+	}
+}
+
+// ParseMaxAge returns the retention period for the given value of the
+// "max-age" log option, which is a number of days.
+func ParseMaxAge(s string) (time.Duration, error) {
+	days, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid value for max-age: %s", s)
+	}
+	if days < 1 {
+		return 0, errors.Errorf("invalid value for max-age: %s: must be a positive number of days", s)
+	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+// ValidateRotationOpts returns an error if the "rotate" or "max-age" log
+// options are set while the "max-file" option, which defaults to
+// defaultMaxFiles, keeps no rotated files: rotating would then truncate
+// the log file.
+func ValidateRotationOpts(cfg map[string]string, defaultMaxFiles int) error {
+	if cfg["rotate"] == "" && cfg["max-age"] == "" {
+		return nil
+	}
+	maxFiles := defaultMaxFiles
+	if s, ok := cfg["max-file"]; ok {
+		var err error
+		if maxFiles, err = strconv.Atoi(s); err != nil {
+			return errors.Wrapf(err, "invalid value for max-file: %s", s)
+		}
+	}
+	if maxFiles < 2 {
+		return errors.New("rotate and max-age cannot be set when max-file is less than 2")
+	}
+	return nil
+}
@@ -8,6 +8,11 @@ var (
 	logWritesFailedCount metrics.Counter
 	logReadsFailedCount  metrics.Counter
 	totalPartialLogs     metrics.Counter
+	logRateLimitedCount  metrics.Counter
+
+	// per-driver counters, labeled with the name of the log driver
+	logDriverWritesFailedCount metrics.LabeledCounter
+	logDriverDroppedCount      metrics.LabeledCounter
 )
 
 func init() {
@@ -16,6 +21,9 @@ func init() {
 	logWritesFailedCount = loggerMetrics.NewCounter("log_write_operations_failed", "Number of log write operations that failed")
 	logReadsFailedCount = loggerMetrics.NewCounter("log_read_operations_failed", "Number of log reads from container stdio that failed")
 	totalPartialLogs = loggerMetrics.NewCounter("log_entries_size_greater_than_buffer", "Number of log entries which are larger than the log buffer")
+	logRateLimitedCount = loggerMetrics.NewCounter("log_messages_rate_limited", "Number of log messages dropped because a container exceeded its log rate limit")
+	logDriverWritesFailedCount = loggerMetrics.NewLabeledCounter("log_driver_write_operations_failed", "Number of log messages that a log driver failed to write", "driver")
+	logDriverDroppedCount = loggerMetrics.NewLabeledCounter("log_driver_messages_dropped", "Number of log messages dropped because the buffer of a log driver was full", "driver")
 
 	metrics.Register(loggerMetrics)
 }
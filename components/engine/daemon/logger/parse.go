@@ -0,0 +1,30 @@
+package logger // import "github.com/docker/docker/daemon/logger"
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/pkg/logfields"
+)
+
+// Formats supported by the "parse" log option, which extracts structured
+// fields from the lines logged by a container.
+const (
+	ParseFormatJSON   = "json"
+	ParseFormatLogfmt = "logfmt"
+)
+
+// FieldParser extracts structured fields from a log line. It returns nil
+// if the line is not in the expected format.
+type FieldParser func(line []byte) map[string]string
+
+// GetFieldParser returns the FieldParser for the given format.
+func GetFieldParser(format string) (FieldParser, error) {
+	switch format {
+	case ParseFormatJSON:
+		return logfields.ParseJSON, nil
+	case ParseFormatLogfmt:
+		return logfields.ParseLogfmt, nil
+	default:
+		return nil, fmt.Errorf("logger: unsupported parse format '%s': must be '%s' or '%s'", format, ParseFormatJSON, ParseFormatLogfmt)
+	}
+}
@@ -0,0 +1,49 @@
+package logger // import "github.com/docker/docker/daemon/logger"
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/logfields"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestGetFieldParser(t *testing.T) {
+	_, err := GetFieldParser("yaml")
+	assert.Check(t, is.ErrorContains(err, "unsupported parse format 'yaml'"))
+	assert.Check(t, is.ErrorContains(ValidateLogOpts("json-file", map[string]string{"parse": "yaml"}), "unsupported parse format"))
+}
+
+func TestCopierParseFields(t *testing.T) {
+	var jsonBuf bytes.Buffer
+	jsonLog := &TestLoggerJSON{Encoder: json.NewEncoder(&jsonBuf)}
+
+	c := NewCopier(map[string]io.Reader{
+		"stdout": strings.NewReader("level=error msg=failed\nnot logfmt\n"),
+	}, jsonLog)
+	c.SetFieldParser(logfields.ParseLogfmt)
+	c.Run()
+	wait := make(chan struct{})
+	go func() {
+		c.Wait()
+		close(wait)
+	}()
+	select {
+	case <-time.After(1 * time.Second):
+		t.Fatal("Copier failed to do its work in 1 second")
+	case <-wait:
+	}
+
+	dec := json.NewDecoder(&jsonBuf)
+	var msg Message
+	assert.NilError(t, dec.Decode(&msg))
+	assert.Check(t, is.DeepEqual(map[string]string{"level": "error", "msg": "failed"}, msg.Fields))
+	msg = Message{}
+	assert.NilError(t, dec.Decode(&msg))
+	assert.Check(t, is.Nil(msg.Fields))
+}
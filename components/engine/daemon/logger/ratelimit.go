@@ -0,0 +1,161 @@
+package logger // import "github.com/docker/docker/daemon/logger"
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+)
+
+// markerInterval is how long the rate limiter waits after dropping a
+// message before it logs a marker with the number of suppressed messages,
+// if no other message is logged in the meantime.
+const markerInterval = time.Second
+
+// ParseRateLimit returns the rate limit, in messages per second, and the
+// burst size configured by the "rate" and "burst" log options. The rate is
+// zero if the options do not set a rate limit. The burst size defaults to
+// the rate, rounded up.
+func ParseRateLimit(cfg map[string]string) (float64, int, error) {
+	r, ok := cfg["rate"]
+	if !ok {
+		if _, ok := cfg["burst"]; ok {
+			return 0, 0, fmt.Errorf("logger: burst option is only supported with the rate option")
+		}
+		return 0, 0, nil
+	}
+	limit, err := strconv.ParseFloat(r, 64)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "error parsing option rate")
+	}
+	if limit <= 0 || math.IsInf(limit, 0) || math.IsNaN(limit) {
+		return 0, 0, fmt.Errorf("logger: rate must be a positive number of messages per second: %s", r)
+	}
+
+	burst := int(math.Ceil(limit))
+	if b, ok := cfg["burst"]; ok {
+		burst, err = strconv.Atoi(b)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "error parsing option burst")
+		}
+		if burst < 1 {
+			return 0, 0, fmt.Errorf("logger: burst must be at least 1: %s", b)
+		}
+	}
+	return limit, burst, nil
+}
+
+// RateLimitedLogger is a Logger that drops the messages logged over a rate
+// limit. The number of messages dropped is logged, as a marker message, with
+// the next message let through or after a short delay.
+type RateLimitedLogger struct {
+	l       Logger
+	limiter *rate.Limiter
+	now     func() time.Time
+
+	mu         sync.Mutex
+	suppressed int
+	source     string
+	timer      *time.Timer
+	closed     bool
+}
+
+type rateLimitedWithReader struct {
+	*RateLimitedLogger
+}
+
+func (r *rateLimitedWithReader) ReadLogs(cfg ReadConfig) *LogWatcher {
+	return r.l.(LogReader).ReadLogs(cfg)
+}
+
+func newRateLimitedLogger(driver Logger, limit float64, burst int) *RateLimitedLogger {
+	return &RateLimitedLogger{
+		l:       driver,
+		limiter: rate.NewLimiter(rate.Limit(limit), burst),
+		now:     time.Now,
+	}
+}
+
+// NewRateLimitedLogger creates a new Logger that lets through at most limit
+// messages per second, with bursts of up to burst messages, to the passed
+// in logger.
+func NewRateLimitedLogger(driver Logger, limit float64, burst int) Logger {
+	l := newRateLimitedLogger(driver, limit, burst)
+	if _, ok := driver.(LogReader); ok {
+		return &rateLimitedWithReader{l}
+	}
+	return l
+}
+
+// Log sends the message to the underlying logger if the rate limit allows
+// it, and drops it otherwise.
+func (r *RateLimitedLogger) Log(msg *Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.limiter.AllowN(r.now(), 1) {
+		if r.suppressed == 0 && !r.closed {
+			r.timer = time.AfterFunc(markerInterval, r.flush)
+		}
+		r.suppressed++
+		r.source = msg.Source
+		logRateLimitedCount.Inc(1)
+		PutMessage(msg)
+		return nil
+	}
+
+	if err := r.logMarker(); err != nil {
+		PutMessage(msg)
+		return err
+	}
+	return r.l.Log(msg)
+}
+
+// flush logs the marker for the messages dropped since the last message
+// let through.
+func (r *RateLimitedLogger) flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.logMarker(); err != nil {
+		logDriverWritesFailedCount.WithValues(r.l.Name()).Inc(1)
+	}
+}
+
+// logMarker logs a message with the number of suppressed messages, if any.
+// It must be called with r.mu held.
+func (r *RateLimitedLogger) logMarker() error {
+	if r.suppressed == 0 {
+		return nil
+	}
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	m := NewMessage()
+	m.Line = append(m.Line, fmt.Sprintf("%d messages suppressed", r.suppressed)...)
+	m.Source = r.source
+	m.Timestamp = r.now()
+	r.suppressed = 0
+	return r.l.Log(m)
+}
+
+// Name returns the name of the underlying logger
+func (r *RateLimitedLogger) Name() string {
+	return r.l.Name()
+}
+
+// Close logs the marker for the messages dropped since the last message let
+// through, and closes the underlying logger.
+func (r *RateLimitedLogger) Close() error {
+	r.mu.Lock()
+	if err := r.logMarker(); err != nil {
+		logDriverWritesFailedCount.WithValues(r.l.Name()).Inc(1)
+	}
+	r.closed = true
+	r.mu.Unlock()
+	return r.l.Close()
+}
@@ -0,0 +1,66 @@
+package logger // import "github.com/docker/docker/daemon/logger"
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	limit, burst, err := ParseRateLimit(map[string]string{})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(0.0, limit))
+
+	limit, burst, err = ParseRateLimit(map[string]string{"rate": "2.5"})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(2.5, limit))
+	assert.Check(t, is.Equal(3, burst))
+
+	_, burst, err = ParseRateLimit(map[string]string{"rate": "100", "burst": "500"})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(500, burst))
+
+	_, _, err = ParseRateLimit(map[string]string{"rate": "0"})
+	assert.Check(t, is.ErrorContains(err, "rate must be a positive number"))
+	_, _, err = ParseRateLimit(map[string]string{"rate": "10", "burst": "0"})
+	assert.Check(t, is.ErrorContains(err, "burst must be at least 1"))
+	_, _, err = ParseRateLimit(map[string]string{"burst": "10"})
+	assert.Check(t, is.ErrorContains(err, "only supported with the rate option"))
+}
+
+func TestRateLimitedLogger(t *testing.T) {
+	mockLog := &mockLogger{make(chan *Message, 10)}
+	l := newRateLimitedLogger(mockLog, 1, 2)
+	now := time.Now()
+	l.now = func() time.Time { return now }
+
+	for _, line := range []string{"1", "2", "3", "4", "5"} {
+		assert.NilError(t, l.Log(&Message{Line: []byte(line), Source: "stdout"}))
+	}
+	now = now.Add(time.Second)
+	assert.NilError(t, l.Log(&Message{Line: []byte("6"), Source: "stdout"}))
+
+	var lines []string
+	for len(mockLog.c) > 0 {
+		lines = append(lines, string((<-mockLog.c).Line))
+	}
+	assert.Check(t, is.DeepEqual([]string{"1", "2", "3 messages suppressed", "6"}, lines))
+}
+
+func TestRateLimitedLoggerClose(t *testing.T) {
+	mockLog := &mockLogger{make(chan *Message, 10)}
+	l := newRateLimitedLogger(mockLog, 1, 1)
+	now := time.Now()
+	l.now = func() time.Time { return now }
+
+	assert.NilError(t, l.Log(&Message{Line: []byte("1"), Source: "stderr"}))
+	assert.NilError(t, l.Log(&Message{Line: []byte("2"), Source: "stderr"}))
+	assert.NilError(t, l.Close())
+
+	assert.Check(t, is.Equal("1", string((<-mockLog.c).Line)))
+	marker := <-mockLog.c
+	assert.Check(t, is.Equal("1 messages suppressed", string(marker.Line)))
+	assert.Check(t, is.Equal("stderr", marker.Source))
+}
@@ -40,6 +40,9 @@ func newRingLogger(driver Logger, logInfo Info, maxSize int64) *RingLogger {
 		l:       driver,
 		logInfo: logInfo,
 	}
+	l.buffer.onDrop = func() {
+		logDriverDroppedCount.WithValues(driver.Name()).Inc(1)
+	}
 	go l.run()
 	return l
 }
@@ -93,6 +96,7 @@ func (r *RingLogger) Close() error {
 		}
 
 		if err := r.l.Log(msg); err != nil {
+			logDriverWritesFailedCount.WithValues(r.l.Name()).Inc(1)
 			logrus.WithField("driver", r.l.Name()).
 				WithField("container", r.logInfo.ContainerID).
 				WithError(err).
@@ -117,6 +121,7 @@ func (r *RingLogger) run() {
 			return
 		}
 		if err := r.l.Log(msg); err != nil {
+			logDriverWritesFailedCount.WithValues(r.l.Name()).Inc(1)
 			logrus.WithField("driver", r.l.Name()).
 				WithField("container", r.logInfo.ContainerID).
 				WithError(err).
@@ -134,6 +139,10 @@ type messageRing struct {
 	maxBytes  int64 // max buffer size size
 	queue     []*Message
 	closed    bool
+
+	// onDrop, if set, is called when a message is dropped because the
+	// buffer is full
+	onDrop func()
 }
 
 func newRing(maxBytes int64) *messageRing {
@@ -163,6 +172,9 @@ func (r *messageRing) Enqueue(m *Message) error {
 	if mSize+r.sizeBytes > r.maxBytes && len(r.queue) > 0 {
 		r.wait.Signal()
 		r.mu.Unlock()
+		if r.onDrop != nil {
+			r.onDrop()
+		}
 		return nil
 	}
 
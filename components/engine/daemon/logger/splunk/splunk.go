@@ -130,6 +130,7 @@ type splunkMessageEvent struct {
 	Source string            `json:"source"`
 	Tag    string            `json:"tag,omitempty"`
 	Attrs  map[string]string `json:"attrs,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
 }
 
 const (
@@ -346,6 +347,7 @@ func (l *splunkLoggerInline) Log(msg *logger.Message) error {
 	event := *l.nullEvent
 	event.Line = string(msg.Line)
 	event.Source = msg.Source
+	event.Fields = msg.Fields
 
 	message.Event = &event
 	logger.PutMessage(msg)
@@ -364,6 +366,7 @@ func (l *splunkLoggerJSON) Log(msg *logger.Message) error {
 	}
 
 	event.Source = msg.Source
+	event.Fields = msg.Fields
 
 	message.Event = &event
 	logger.PutMessage(msg)
@@ -0,0 +1,161 @@
+package logger // import "github.com/docker/docker/daemon/logger"
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// driverSeparator separates the log drivers of a multi-driver log
+// configuration, such as "local,fluentd".
+const driverSeparator = ","
+
+// SplitDrivers returns the log drivers listed in the given log driver
+// name. It returns a single driver unless the name lists several drivers
+// separated by commas.
+func SplitDrivers(name string) []string {
+	if !strings.Contains(name, driverSeparator) {
+		return []string{name}
+	}
+	var drivers []string
+	for _, d := range strings.Split(name, driverSeparator) {
+		drivers = append(drivers, strings.TrimSpace(d))
+	}
+	return drivers
+}
+
+// DriverOpts returns the options of a multi-driver log configuration that
+// apply to the given driver. Options prefixed with the name of one of the
+// drivers and a dot, such as "fluentd.fluentd-address", only apply to that
+// driver, with the prefix removed. Other options apply to every driver,
+// except for the built-in options, which apply to the drivers as a whole.
+func DriverOpts(driver string, drivers []string, cfg map[string]string) map[string]string {
+	opts := make(map[string]string)
+	for k, v := range cfg {
+		if builtInLogOpts[k] {
+			continue
+		}
+		prefixed := false
+		for _, d := range drivers {
+			if strings.HasPrefix(k, d+".") {
+				prefixed = true
+				if d == driver {
+					opts[strings.TrimPrefix(k, d+".")] = v
+				}
+				break
+			}
+		}
+		if !prefixed {
+			if _, ok := opts[k]; !ok {
+				opts[k] = v
+			}
+		}
+	}
+	return opts
+}
+
+// validateDrivers checks the drivers of a multi-driver log configuration.
+func validateDrivers(drivers []string) error {
+	seen := make(map[string]bool)
+	for _, d := range drivers {
+		switch {
+		case d == "":
+			return fmt.Errorf("logger: empty log driver name in list of log drivers")
+		case d == "none":
+			return fmt.Errorf("logger: log driver 'none' cannot be combined with other log drivers")
+		case seen[d]:
+			return fmt.Errorf("logger: log driver '%s' is listed more than once", d)
+		}
+		seen[d] = true
+	}
+	return nil
+}
+
+// TeeLogger is a Logger that sends every message to several loggers. Each
+// logger is wrapped in a RingLogger so that a slow or failing driver does
+// not block the other ones.
+type TeeLogger struct {
+	loggers []*RingLogger
+	reader  LogReader
+}
+
+type teeWithReader struct {
+	*TeeLogger
+}
+
+func (t *teeWithReader) ReadLogs(cfg ReadConfig) *LogWatcher {
+	return t.reader.ReadLogs(cfg)
+}
+
+// NewTeeLogger creates a new Logger that sends messages to all the given
+// drivers, buffering up to maxSize bytes per driver. Reading logs is
+// supported if one of the drivers supports it, in which case the first of
+// these drivers is read from.
+func NewTeeLogger(drivers []Logger, logInfo Info, maxSize int64) Logger {
+	if maxSize < 0 {
+		maxSize = defaultRingMaxSize
+	}
+	t := &TeeLogger{}
+	for _, d := range drivers {
+		if r, ok := d.(LogReader); ok && t.reader == nil {
+			t.reader = r
+		}
+		t.loggers = append(t.loggers, newRingLogger(d, logInfo, maxSize))
+	}
+	if t.reader != nil {
+		return &teeWithReader{t}
+	}
+	return t
+}
+
+// Log queues a copy of the message for each driver.
+func (t *TeeLogger) Log(msg *Message) error {
+	defer PutMessage(msg)
+	for _, l := range t.loggers {
+		if err := l.Log(copyMessage(msg)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Name returns the names of the underlying drivers.
+func (t *TeeLogger) Name() string {
+	names := make([]string, 0, len(t.loggers))
+	for _, l := range t.loggers {
+		names = append(names, l.Name())
+	}
+	return strings.Join(names, driverSeparator)
+}
+
+// Close flushes and closes all the drivers.
+func (t *TeeLogger) Close() error {
+	var firstErr error
+	for _, l := range t.loggers {
+		if err := l.Close(); err != nil {
+			logrus.WithError(err).WithField("driver", l.Name()).Error("Error closing log driver")
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// copyMessage returns a copy of msg taken from the message pool, which the
+// driver it is passed to can release independently of msg.
+func copyMessage(msg *Message) *Message {
+	m := NewMessage()
+	m.Line = append(m.Line, msg.Line...)
+	m.Source = msg.Source
+	m.Timestamp = msg.Timestamp
+	m.Attrs = msg.Attrs
+	if msg.PLogMetaData != nil {
+		md := *msg.PLogMetaData
+		m.PLogMetaData = &md
+	}
+	m.Fields = msg.Fields
+	m.Err = msg.Err
+	return m
+}
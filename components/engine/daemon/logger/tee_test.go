@@ -0,0 +1,75 @@
+package logger // import "github.com/docker/docker/daemon/logger"
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+type mockReaderLogger struct {
+	mockLogger
+}
+
+func (l *mockReaderLogger) ReadLogs(ReadConfig) *LogWatcher {
+	return NewLogWatcher()
+}
+
+func TestSplitDrivers(t *testing.T) {
+	assert.Check(t, is.DeepEqual([]string{"json-file"}, SplitDrivers("json-file")))
+	assert.Check(t, is.DeepEqual([]string{"local", "fluentd"}, SplitDrivers("local, fluentd")))
+}
+
+func TestDriverOpts(t *testing.T) {
+	drivers := []string{"local", "fluentd"}
+	cfg := map[string]string{
+		"mode":                    "non-blocking",
+		"max-buffer-size":         "1m",
+		"tag":                     "{{.Name}}",
+		"local.max-size":          "10m",
+		"fluentd.fluentd-address": "localhost:24224",
+		"fluentd.tag":             "app",
+	}
+
+	assert.Check(t, is.DeepEqual(map[string]string{
+		"tag":      "{{.Name}}",
+		"max-size": "10m",
+	}, DriverOpts("local", drivers, cfg)))
+	assert.Check(t, is.DeepEqual(map[string]string{
+		"tag":             "app",
+		"fluentd-address": "localhost:24224",
+	}, DriverOpts("fluentd", drivers, cfg)))
+}
+
+func TestValidateTeeLogOpts(t *testing.T) {
+	assert.Check(t, is.ErrorContains(ValidateLogOpts("local,local", nil), "listed more than once"))
+	assert.Check(t, is.ErrorContains(ValidateLogOpts("local,none", nil), "cannot be combined"))
+	assert.Check(t, is.ErrorContains(ValidateLogOpts("local,fluentd", map[string]string{"mode": "blocking"}), "only support 'mode=non-blocking'"))
+}
+
+func TestTeeLogger(t *testing.T) {
+	first := &mockLogger{make(chan *Message, 1)}
+	second := &mockReaderLogger{mockLogger{make(chan *Message, 1)}}
+	l := NewTeeLogger([]Logger{first, second}, Info{}, -1)
+	defer l.Close()
+
+	assert.Check(t, is.Equal("mock,mock", l.Name()))
+	_, ok := l.(LogReader)
+	assert.Check(t, ok, "expected the tee logger to support reading logs")
+
+	msg := NewMessage()
+	msg.Line = append(msg.Line, "hello"...)
+	msg.Source = "stdout"
+	assert.NilError(t, l.Log(msg))
+
+	for _, c := range []chan *Message{first.c, second.c} {
+		select {
+		case m := <-c:
+			assert.Check(t, is.Equal("hello", string(m.Line)))
+			assert.Check(t, is.Equal("stdout", m.Source))
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for log message")
+		}
+	}
+}
@@ -88,11 +88,30 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 		until = time.Unix(s, n)
 	}
 
+	include, exclude, err := logger.CompileLineFilters(config.Grep, config.Exclude)
+	if err != nil {
+		return nil, false, errdefs.InvalidParameter(err)
+	}
+
 	readConfig := logger.ReadConfig{
-		Since:  since,
-		Until:  until,
-		Tail:   tailLines,
-		Follow: follow,
+		Since:   since,
+		Until:   until,
+		Tail:    tailLines,
+		Follow:  follow,
+		Include: include,
+		Exclude: exclude,
+	}
+	// With --grep or --exclude, the tail is made of the last matching
+	// messages, which are found by reading all the logs, so the messages of
+	// the other stream are skipped too. Otherwise, the tail is read from the
+	// end of the logs, and the messages of the other stream are dropped by
+	// the API.
+	if readConfig.HasFilters() && !(config.ShowStdout && config.ShowStderr) {
+		if config.ShowStdout {
+			readConfig.Sources = []string{"stdout"}
+		} else {
+			readConfig.Sources = []string{"stderr"}
+		}
 	}
 
 	logs := logReader.ReadLogs(readConfig)
@@ -142,6 +161,11 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 				if !ok {
 					return
 				}
+				// the file-based log readers filter messages themselves,
+				// but other log readers may not.
+				if !readConfig.Matches(msg) {
+					continue
+				}
 				m := msg.AsLogMessage() // just a pointer conversion, does not copy data
 
 				// there could be a case where the reader stops accepting
@@ -151,6 +151,7 @@ func (daemon *Daemon) ProcessEvent(id string, e libcontainerd.EventType, ei libc
 			daemon.setStateCounter(c)
 
 			daemon.initHealthMonitor(c)
+			daemon.statsCollector.Track(c)
 
 			if err := c.CheckpointTo(daemon.containersReplica); err != nil {
 				return err
@@ -44,6 +44,7 @@ func (daemon *Daemon) Reload(conf *config.Config) (err error) {
 	}
 	daemon.reloadDebug(conf, attributes)
 	daemon.reloadMaxConcurrentDownloadsAndUploads(conf, attributes)
+	daemon.reloadMaxBandwidth(conf, attributes)
 	daemon.reloadShutdownTimeout(conf, attributes)
 	daemon.reloadFeatures(conf, attributes)
 
@@ -109,6 +110,26 @@ func (daemon *Daemon) reloadMaxConcurrentDownloadsAndUploads(conf *config.Config
 	attributes["max-concurrent-uploads"] = fmt.Sprintf("%d", *daemon.configStore.MaxConcurrentUploads)
 }
 
+// reloadMaxBandwidth updates configuration with max download and upload
+// bandwidth options and updates the passed attributes
+func (daemon *Daemon) reloadMaxBandwidth(conf *config.Config, attributes map[string]string) {
+	// If no value is set, the bandwidth is not limited
+	daemon.configStore.MaxDownloadBandwidth = 0
+	if conf.IsValueSet("max-download-bandwidth") {
+		daemon.configStore.MaxDownloadBandwidth = conf.MaxDownloadBandwidth
+	}
+	daemon.configStore.MaxUploadBandwidth = 0
+	if conf.IsValueSet("max-upload-bandwidth") {
+		daemon.configStore.MaxUploadBandwidth = conf.MaxUploadBandwidth
+	}
+	logrus.Debugf("Reset Max Download Bandwidth: %d, Max Upload Bandwidth: %d", daemon.configStore.MaxDownloadBandwidth, daemon.configStore.MaxUploadBandwidth)
+
+	daemon.imageService.UpdateBandwidth(daemon.configStore.MaxDownloadBandwidth.Value(), daemon.configStore.MaxUploadBandwidth.Value())
+	// prepare reload event attributes with updatable configurations
+	attributes["max-download-bandwidth"] = fmt.Sprintf("%d", daemon.configStore.MaxDownloadBandwidth)
+	attributes["max-upload-bandwidth"] = fmt.Sprintf("%d", daemon.configStore.MaxUploadBandwidth)
+}
+
 // reloadShutdownTimeout updates configuration with daemon shutdown timeout option
 // and updates the passed attributes
 func (daemon *Daemon) reloadShutdownTimeout(conf *config.Config, attributes map[string]string) {
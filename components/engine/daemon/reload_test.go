@@ -571,3 +571,27 @@ func TestDaemonReloadNetworkDiagnosticPort(t *testing.T) {
 	}
 
 }
+
+func TestDaemonReloadMaxBandwidth(t *testing.T) {
+	daemon := &Daemon{
+		configStore:  &config.Config{},
+		imageService: images.NewImageService(images.ImageServiceConfig{}),
+	}
+
+	valuesSet := make(map[string]interface{})
+	valuesSet["max-download-bandwidth"] = "10M"
+	newConfig := &config.Config{
+		CommonConfig: config.CommonConfig{
+			MaxDownloadBandwidth: 10 * 1024 * 1024,
+			MaxUploadBandwidth:   1024,
+			ValuesSet:            valuesSet,
+		},
+	}
+	assert.NilError(t, daemon.Reload(newConfig))
+	assert.Check(t, is.Equal(int64(10*1024*1024), daemon.configStore.MaxDownloadBandwidth.Value()))
+	// max-upload-bandwidth is not set, so it is reset
+	assert.Check(t, is.Equal(int64(0), daemon.configStore.MaxUploadBandwidth.Value()))
+
+	assert.NilError(t, daemon.Reload(&config.Config{}))
+	assert.Check(t, is.Equal(int64(0), daemon.configStore.MaxDownloadBandwidth.Value()))
+}
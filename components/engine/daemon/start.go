@@ -15,11 +15,7 @@ import (
 )
 
 // ContainerStart starts a container.
-func (daemon *Daemon) ContainerStart(name string, hostConfig *containertypes.HostConfig, checkpoint string, checkpointDir string) error {
-	if checkpoint != "" && !daemon.HasExperimental() {
-		return errdefs.InvalidParameter(errors.New("checkpoint is only supported in experimental mode"))
-	}
-
+func (daemon *Daemon) ContainerStart(ctx context.Context, name string, hostConfig *containertypes.HostConfig, checkpoint string, checkpointDir string) error {
 	container, err := daemon.GetContainer(name)
 	if err != nil {
 		return err
@@ -92,6 +88,9 @@ func (daemon *Daemon) ContainerStart(name string, hostConfig *containertypes.Hos
 			return errdefs.InvalidParameter(err)
 		}
 	}
+	if err := daemon.startDependencies(ctx, container); err != nil {
+		return err
+	}
 	return daemon.containerStart(container, checkpoint, checkpointDir, true)
 }
 
@@ -210,8 +209,12 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 	container.HasBeenManuallyStopped = false
 	container.HasBeenStartedBefore = true
 	daemon.setStateCounter(container)
+	if container.ImageID != "" {
+		daemon.imageService.MarkImageUsed(container.ImageID)
+	}
 
 	daemon.initHealthMonitor(container)
+	daemon.statsCollector.Track(container)
 
 	if err := container.CheckpointTo(daemon.containersReplica); err != nil {
 		logrus.WithError(err).WithField("container", container.ID).
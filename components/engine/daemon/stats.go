@@ -12,6 +12,7 @@ import (
 	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/api/types/versions/v1p20"
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/ioutils"
 )
 
@@ -26,6 +27,10 @@ func (daemon *Daemon) ContainerStats(ctx context.Context, prefixOrName string, c
 		return err
 	}
 
+	if !config.Since.IsZero() || !config.Until.IsZero() {
+		return daemon.containerStatsHistory(container, config)
+	}
+
 	// If the container is either not running or restarting and requires no stream, return an empty stats.
 	if (!container.IsRunning() || container.IsRestarting()) && !config.Stream {
 		return json.NewEncoder(config.OutStream).Encode(&types.StatsJSON{
@@ -129,6 +134,27 @@ func (daemon *Daemon) ContainerStats(ctx context.Context, prefixOrName string, c
 	}
 }
 
+// containerStatsHistory writes the stats of the history of the container
+// read between config.Since and config.Until to the stream.
+func (daemon *Daemon) containerStatsHistory(container *container.Container, config *backend.ContainerStatsConfig) error {
+	if !daemon.statsCollector.HistoryEnabled() {
+		return errdefs.InvalidParameter(errors.New("the stats history is not enabled on the daemon"))
+	}
+	if !config.Until.IsZero() && config.Until.Before(config.Since) {
+		return errdefs.InvalidParameter(errors.New("until cannot be before since"))
+	}
+
+	enc := json.NewEncoder(config.OutStream)
+	for _, ss := range daemon.statsCollector.History(container, config.Since, config.Until) {
+		ss.Name = container.Name
+		ss.ID = container.ID
+		if err := enc.Encode(&ss); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (daemon *Daemon) subscribeToContainerStats(c *container.Container) chan interface{} {
 	return daemon.statsCollector.Collect(c)
 }
@@ -19,6 +19,10 @@ type Collector struct {
 	publishers map[*container.Container]*pubsub.Publisher
 	bufReader  *bufio.Reader
 
+	// historyOpts is nil unless the history of the stats is enabled
+	historyOpts *HistoryOptions
+	histories   map[*container.Container]*history
+
 	// The following fields are not set on Windows currently.
 	clockTicksPerSecond uint64
 }
@@ -29,6 +33,7 @@ func NewCollector(supervisor supervisor, interval time.Duration) *Collector {
 		interval:   interval,
 		supervisor: supervisor,
 		publishers: make(map[*container.Container]*pubsub.Publisher),
+		histories:  make(map[*container.Container]*history),
 		bufReader:  bufio.NewReaderSize(nil, 128),
 	}
 
@@ -56,14 +61,53 @@ func (s *Collector) Collect(c *container.Container) chan interface{} {
 	return publisher.Subscribe()
 }
 
+// EnableHistory makes the collector keep a history of the stats of the
+// containers registered with Track.
+func (s *Collector) EnableHistory(opts HistoryOptions) {
+	s.m.Lock()
+	s.historyOpts = &opts
+	s.m.Unlock()
+}
+
+// HistoryEnabled returns whether the collector keeps a history of the stats.
+func (s *Collector) HistoryEnabled() bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.historyOpts != nil
+}
+
+// Track registers the container with the collector so that the history of
+// its stats is kept, whether or not there are subscribers to its stats, until
+// StopCollection is called. It does nothing if the history is not enabled.
+func (s *Collector) Track(c *container.Container) {
+	s.m.Lock()
+	if _, exists := s.histories[c]; !exists && s.historyOpts != nil {
+		s.histories[c] = newHistory(*s.historyOpts)
+	}
+	s.m.Unlock()
+}
+
+// History returns the stats of the container read between since and until,
+// oldest first. Zero values of since and until are not taken into account.
+func (s *Collector) History(c *container.Container, since, until time.Time) []types.StatsJSON {
+	s.m.Lock()
+	defer s.m.Unlock()
+	h, exists := s.histories[c]
+	if !exists {
+		return []types.StatsJSON{}
+	}
+	return h.get(since, until)
+}
+
 // StopCollection closes the channels for all subscribers and removes
-// the container from metrics collection.
+// the container from metrics collection, and its stats from the history.
 func (s *Collector) StopCollection(c *container.Container) {
 	s.m.Lock()
 	if publisher, exists := s.publishers[c]; exists {
 		publisher.Close()
 		delete(s.publishers, c)
 	}
+	delete(s.histories, c)
 	s.m.Unlock()
 }
 
@@ -84,7 +128,10 @@ func (s *Collector) Unsubscribe(c *container.Container, ch chan interface{}) {
 func (s *Collector) Run() {
 	type publishersPair struct {
 		container *container.Container
+		// publisher is nil if there are no subscribers, and history is nil
+		// if the history of the container is not kept.
 		publisher *pubsub.Publisher
+		history   *history
 	}
 	// we cannot determine the capacity here.
 	// it will grow enough in first iteration
@@ -102,7 +149,12 @@ func (s *Collector) Run() {
 		s.m.Lock()
 		for container, publisher := range s.publishers {
 			// copy pointers here to release the lock ASAP
-			pairs = append(pairs, publishersPair{container, publisher})
+			pairs = append(pairs, publishersPair{container, publisher, s.histories[container]})
+		}
+		for container, history := range s.histories {
+			if _, exists := s.publishers[container]; !exists {
+				pairs = append(pairs, publishersPair{container, nil, history})
+			}
 		}
 		s.m.Unlock()
 		if len(pairs) == 0 {
@@ -132,14 +184,23 @@ func (s *Collector) Run() {
 				stats.CPUStats.SystemUsage = systemUsage
 				stats.CPUStats.OnlineCPUs = onlineCPUs
 
-				pair.publisher.Publish(*stats)
+				if pair.history != nil {
+					s.m.Lock()
+					pair.history.add(*stats)
+					s.m.Unlock()
+				}
+				if pair.publisher != nil {
+					pair.publisher.Publish(*stats)
+				}
 
 			case notRunningErr, notFoundErr:
 				// publish empty stats containing only name and ID if not running or not found
-				pair.publisher.Publish(types.StatsJSON{
-					Name: pair.container.Name,
-					ID:   pair.container.ID,
-				})
+				if pair.publisher != nil {
+					pair.publisher.Publish(types.StatsJSON{
+						Name: pair.container.Name,
+						ID:   pair.container.ID,
+					})
+				}
 
 			default:
 				logrus.Errorf("collecting stats for %s: %v", pair.container.ID, err)
@@ -0,0 +1,82 @@
+package stats // import "github.com/docker/docker/daemon/stats"
+
+import (
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+// HistoryOptions configures the history of the stats of the containers kept
+// by the collector.
+type HistoryOptions struct {
+	// Interval is the minimum interval between two samples of the history.
+	// The stats collected in between are dropped.
+	Interval time.Duration
+	// Retention is the duration for which the samples are kept.
+	Retention time.Duration
+}
+
+// history is a ring of the stats of a container, downsampled to one sample
+// per interval. The PreCPUStats and PreRead of each sample are those of the
+// previous sample, so that the CPU usage can be computed over the interval.
+type history struct {
+	interval  time.Duration
+	retention time.Duration
+	samples   []types.StatsJSON
+	// start is the index of the oldest of the count samples of the ring
+	start int
+	count int
+}
+
+func newHistory(opts HistoryOptions) *history {
+	size := 1
+	if opts.Interval > 0 && opts.Retention > opts.Interval {
+		size = int(opts.Retention / opts.Interval)
+	}
+	return &history{
+		interval:  opts.Interval,
+		retention: opts.Retention,
+		samples:   make([]types.StatsJSON, size),
+	}
+}
+
+// add adds stats to the history, unless the last sample is more recent than
+// the interval.
+func (h *history) add(stats types.StatsJSON) {
+	if h.count > 0 {
+		last := h.samples[(h.start+h.count-1)%len(h.samples)]
+		if stats.Read.Sub(last.Read) < h.interval {
+			return
+		}
+		stats.PreCPUStats = last.CPUStats
+		stats.PreRead = last.Read
+	}
+
+	if h.count < len(h.samples) {
+		h.samples[(h.start+h.count)%len(h.samples)] = stats
+		h.count++
+		return
+	}
+	h.samples[h.start] = stats
+	h.start = (h.start + 1) % len(h.samples)
+}
+
+// get returns the samples read between since and until, oldest first. Zero
+// values of since and until are not taken into account. Samples older than
+// the retention are never returned.
+func (h *history) get(since, until time.Time) []types.StatsJSON {
+	oldest := time.Now().Add(-h.retention)
+	if since.Before(oldest) {
+		since = oldest
+	}
+
+	samples := []types.StatsJSON{}
+	for i := 0; i < h.count; i++ {
+		s := h.samples[(h.start+i)%len(h.samples)]
+		if s.Read.Before(since) || (!until.IsZero() && s.Read.After(until)) {
+			continue
+		}
+		samples = append(samples, s)
+	}
+	return samples
+}
@@ -0,0 +1,62 @@
+package stats // import "github.com/docker/docker/daemon/stats"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func statsAt(read time.Time, cpu uint64) types.StatsJSON {
+	var s types.StatsJSON
+	s.Read = read
+	s.CPUStats.CPUUsage.TotalUsage = cpu
+	return s
+}
+
+func cpuUsages(samples []types.StatsJSON) []uint64 {
+	usages := []uint64{}
+	for _, s := range samples {
+		usages = append(usages, s.CPUStats.CPUUsage.TotalUsage)
+	}
+	return usages
+}
+
+func TestHistoryDownsample(t *testing.T) {
+	h := newHistory(HistoryOptions{Interval: 10 * time.Second, Retention: time.Hour})
+	start := time.Now().Add(-time.Minute)
+	for i := 0; i < 30; i++ {
+		h.add(statsAt(start.Add(time.Duration(i)*time.Second), uint64(i)))
+	}
+
+	samples := h.get(time.Time{}, time.Time{})
+	assert.Check(t, is.DeepEqual([]uint64{0, 10, 20}, cpuUsages(samples)))
+	assert.Check(t, is.Equal(uint64(10), samples[2].PreCPUStats.CPUUsage.TotalUsage))
+	assert.Check(t, samples[2].PreRead.Equal(start.Add(10*time.Second)))
+	assert.Check(t, samples[0].PreRead.IsZero())
+}
+
+func TestHistoryRing(t *testing.T) {
+	h := newHistory(HistoryOptions{Interval: time.Second, Retention: 3 * time.Second})
+	start := time.Now().Add(-3 * time.Second)
+	for i := 0; i < 5; i++ {
+		h.add(statsAt(start.Add(time.Duration(i)*time.Second), uint64(i)))
+	}
+	assert.Check(t, is.DeepEqual([]uint64{2, 3, 4}, cpuUsages(h.get(time.Time{}, time.Time{}))))
+}
+
+func TestHistorySinceUntil(t *testing.T) {
+	h := newHistory(HistoryOptions{Interval: time.Minute, Retention: 10 * time.Minute})
+	now := time.Now()
+	for i := 20; i >= 0; i-- {
+		h.add(statsAt(now.Add(-time.Duration(i)*time.Minute), uint64(20-i)))
+	}
+
+	// samples older than the retention are not returned
+	assert.Check(t, is.DeepEqual([]uint64{11, 12, 13, 14, 15, 16, 17, 18, 19, 20}, cpuUsages(h.get(time.Time{}, time.Time{}))))
+	assert.Check(t, is.DeepEqual([]uint64{18, 19, 20}, cpuUsages(h.get(now.Add(-150*time.Second), time.Time{}))))
+	assert.Check(t, is.DeepEqual([]uint64{16, 17}, cpuUsages(h.get(now.Add(-4*time.Minute), now.Add(-150*time.Second)))))
+	assert.Check(t, is.Len(h.get(now.Add(time.Minute), time.Time{}), 0))
+}
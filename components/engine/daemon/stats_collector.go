@@ -12,7 +12,9 @@ import (
 // stats for a registered container at the specified interval.
 // The collector allows non-running containers to be added
 // and will start processing stats when they are started.
-func (daemon *Daemon) newStatsCollector(interval time.Duration) *stats.Collector {
+// If history is not nil, the collector keeps a history of the stats
+// of the started containers.
+func (daemon *Daemon) newStatsCollector(interval time.Duration, history *stats.HistoryOptions) *stats.Collector {
 	// FIXME(vdemeester) move this elsewhere
 	if runtime.GOOS == "linux" {
 		meminfo, err := system.ReadMemInfo()
@@ -21,6 +23,9 @@ func (daemon *Daemon) newStatsCollector(interval time.Duration) *stats.Collector
 		}
 	}
 	s := stats.NewCollector(daemon, interval)
+	if history != nil {
+		s.EnableHistory(*history)
+	}
 	go s.Run()
 	return s
 }
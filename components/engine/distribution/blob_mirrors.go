@@ -0,0 +1,233 @@
+package distribution // import "github.com/docker/docker/distribution"
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/distribution/xfer"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/pkg/progress"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// BlobSharePath is the path of the blob sharing endpoint serving the content of
+	// the layer pulled as a blob, followed by the digest of the blob.
+	BlobSharePath = "/distribution/blobs/"
+
+	// DiffIDHeader is the header of the responses of the blob sharing
+	// endpoint containing the DiffID of the layer, which is the digest of
+	// the content of the response.
+	DiffIDHeader = "Docker-Diff-Id"
+
+	// blobShareVersion is the API version the blob sharing endpoint is
+	// requested with.
+	blobShareVersion = "1.40"
+)
+
+// BlobMirrors are the sources, other than the registry, that layers are
+// fetched from: a directory of blobs, possibly shared with other daemons,
+// and the peer daemons of the local network. The content fetched from a
+// mirror is verified before it is used.
+type BlobMirrors struct {
+	peers    []string
+	cacheDir string
+	client   *http.Client
+}
+
+// NewBlobMirrors returns the mirrors fetching blobs from the peers, which
+// are the base URLs of daemons serving the blob sharing endpoint, and from
+// cacheDir. The peers are connected to with tlsConfig, which holds the
+// client certificate they verify. The blobs pulled from a registry are
+// added to cacheDir.
+func NewBlobMirrors(peers []string, cacheDir string, tlsConfig *tls.Config) (*BlobMirrors, error) {
+	if cacheDir != "" {
+		if err := os.MkdirAll(cacheDir, 0700); err != nil {
+			return nil, err
+		}
+	}
+	var trimmed []string
+	for _, peer := range peers {
+		trimmed = append(trimmed, strings.TrimSuffix(peer, "/"))
+	}
+	return &BlobMirrors{
+		peers:    trimmed,
+		cacheDir: cacheDir,
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: (&net.Dialer{
+					Timeout:   5 * time.Second,
+					KeepAlive: 30 * time.Second,
+				}).DialContext,
+				TLSClientConfig:       tlsConfig,
+				TLSHandshakeTimeout:   5 * time.Second,
+				ResponseHeaderTimeout: 10 * time.Second,
+			},
+		},
+	}, nil
+}
+
+func (m *BlobMirrors) path(dgst digest.Digest) string {
+	return filepath.Join(m.cacheDir, dgst.Algorithm().String(), dgst.Hex())
+}
+
+// fetch returns the content of the blob from the cache directory, or the
+// content of the layer pulled as the blob from a peer. The peers are only
+// asked for the layer if its DiffID, which is what their content is
+// verified with, is known from the image configuration. It returns an
+// error if no mirror has the blob.
+func (m *BlobMirrors) fetch(ctx context.Context, dgst digest.Digest, diffID layer.DiffID, progressOutput progress.Output, id string) (io.ReadCloser, int64, error) {
+	if m.cacheDir != "" {
+		rc, size, err := m.fetchCache(dgst)
+		if err == nil {
+			logrus.Debugf("fetched blob %s from cache", dgst)
+			return rc, size, nil
+		}
+		if !os.IsNotExist(err) {
+			logrus.WithError(err).WithField("digest", dgst).Warn("Cannot fetch blob from cache")
+		}
+	}
+	if diffID == "" {
+		return nil, 0, errors.Errorf("blob %s not found in mirrors", dgst)
+	}
+	for _, peer := range m.peers {
+		rc, size, err := m.fetchPeer(ctx, peer, dgst, diffID, progressOutput, id)
+		if err == nil {
+			logrus.Debugf("fetched blob %s from peer %s", dgst, peer)
+			return rc, size, nil
+		}
+		if ctx.Err() != nil {
+			return nil, 0, ctx.Err()
+		}
+		logrus.WithError(err).WithField("digest", dgst).Debugf("Cannot fetch blob from peer %s", peer)
+	}
+	return nil, 0, errors.Errorf("blob %s not found in mirrors", dgst)
+}
+
+func (m *BlobMirrors) fetchCache(dgst digest.Digest) (io.ReadCloser, int64, error) {
+	f, err := os.Open(m.path(dgst))
+	if err != nil {
+		return nil, 0, err
+	}
+	verifier := dgst.Verifier()
+	size, err := io.Copy(verifier, f)
+	if err == nil && !verifier.Verified() {
+		err = fmt.Errorf("verification failed for digest %s", dgst)
+	}
+	if err == nil {
+		_, err = f.Seek(0, os.SEEK_SET)
+	}
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	return f, size, nil
+}
+
+func (m *BlobMirrors) hasPeers() bool {
+	return m != nil && len(m.peers) > 0
+}
+
+func (m *BlobMirrors) fetchPeer(ctx context.Context, peer string, dgst digest.Digest, expected layer.DiffID, progressOutput progress.Output, id string) (io.ReadCloser, int64, error) {
+	req, err := http.NewRequest(http.MethodGet, peer+"/v"+blobShareVersion+BlobSharePath+dgst.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := m.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, errors.Errorf("unexpected status: %s", resp.Status)
+	}
+	// The header is only a hint sent by the peer: the content is verified
+	// with the DiffID of the image configuration.
+	diffID := digest.Digest(expected)
+	if header := resp.Header.Get(DiffIDHeader); header != diffID.String() {
+		return nil, 0, errors.Errorf("peer has layer %q, expected %s", header, diffID)
+	}
+
+	download, err := newTempBlobDownload(diffID)
+	if err != nil {
+		return nil, 0, err
+	}
+	size := resp.ContentLength
+	if size < 0 {
+		size = 0
+	}
+	reader := progress.NewProgressReader(ioutils.NewCancelReadCloser(ctx, xfer.NewRateLimitedReader(ctx, resp.Body)), progressOutput, size, id, "Downloading")
+	defer reader.Close()
+
+	size, err = io.Copy(download, reader)
+	if err == nil && !download.Verified() {
+		err = fmt.Errorf("verification failed for diff ID %s", diffID)
+	}
+	if err != nil {
+		download.close()
+		return nil, 0, err
+	}
+
+	tmpFile := download.complete()
+	if _, err := tmpFile.Seek(0, os.SEEK_SET); err != nil {
+		download.close()
+		return nil, 0, err
+	}
+	return ioutils.NewReadCloserWrapper(tmpFile, func() error {
+		tmpFile.Close()
+		err := os.RemoveAll(tmpFile.Name())
+		if err != nil {
+			logrus.Errorf("Failed to remove temp file: %s", tmpFile.Name())
+		}
+		return err
+	}), size, nil
+}
+
+// store adds the verified content of the blob in f to the cache directory.
+func (m *BlobMirrors) store(dgst digest.Digest, f *os.File) {
+	if m.cacheDir == "" {
+		return
+	}
+	if _, err := os.Stat(m.path(dgst)); err == nil {
+		return
+	}
+	if err := m.storeFile(dgst, f); err != nil {
+		logrus.WithError(err).WithField("digest", dgst).Warn("Cannot add blob to cache")
+	}
+}
+
+func (m *BlobMirrors) storeFile(dgst digest.Digest, f *os.File) error {
+	dir := filepath.Dir(m.path(dgst))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(dir, ".tmp-"+dgst.Hex())
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := f.Seek(0, os.SEEK_SET); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := io.Copy(tmp, f); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), m.path(dgst))
+}
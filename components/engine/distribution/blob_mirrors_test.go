@@ -0,0 +1,103 @@
+package distribution // import "github.com/docker/docker/distribution"
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/progress"
+	"github.com/opencontainers/go-digest"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestBlobMirrorsCache(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "blob-cache")
+	assert.NilError(t, err)
+	defer os.RemoveAll(cacheDir)
+
+	content := "compressed layer"
+	dgst := digest.FromString(content)
+	mirrors, err := NewBlobMirrors(nil, cacheDir, nil)
+	assert.NilError(t, err)
+	_, _, err = mirrors.fetch(context.Background(), dgst, "", progress.DiscardOutput(), "layer")
+	assert.Check(t, is.ErrorContains(err, "not found in mirrors"))
+
+	f, err := ioutil.TempFile("", "blob")
+	assert.NilError(t, err)
+	defer os.Remove(f.Name())
+	defer f.Close()
+	_, err = f.WriteString(content)
+	assert.NilError(t, err)
+	mirrors.store(dgst, f)
+
+	rc, size, err := mirrors.fetch(context.Background(), dgst, "", progress.DiscardOutput(), "layer")
+	assert.NilError(t, err)
+	b, err := ioutil.ReadAll(rc)
+	rc.Close()
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(content, string(b)))
+	assert.Check(t, is.Equal(int64(len(content)), size))
+
+	// corrupted blobs are not used
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(cacheDir, "sha256", dgst.Hex()), []byte("corrupted"), 0600))
+	_, _, err = mirrors.fetch(context.Background(), dgst, "", progress.DiscardOutput(), "layer")
+	assert.Check(t, is.ErrorContains(err, "not found in mirrors"))
+}
+
+func TestBlobMirrorsPeers(t *testing.T) {
+	content := "uncompressed layer"
+	diffID := digest.FromString(content)
+	blob := digest.FromString("compressed layer")
+	unknown := digest.FromString("unknown layer")
+
+	var requested []string
+	serve := func(content string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			requested = append(requested, r.URL.Path)
+			if r.URL.Path != "/v1.40/distribution/blobs/"+blob.String() {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set(DiffIDHeader, diffID.String())
+			w.Write([]byte(content))
+		}
+	}
+	corrupted := httptest.NewTLSServer(serve("corrupted layer"))
+	defer corrupted.Close()
+	valid := httptest.NewTLSServer(serve(content))
+	defer valid.Close()
+
+	// both test servers use the same certificate
+	tlsConfig := valid.Client().Transport.(*http.Transport).TLSClientConfig
+	mirrors, err := NewBlobMirrors([]string{corrupted.URL, valid.URL + "/"}, "", tlsConfig)
+	assert.NilError(t, err)
+
+	// peers are not used if the layer cannot be verified
+	_, _, err = mirrors.fetch(context.Background(), blob, "", progress.DiscardOutput(), "layer")
+	assert.Check(t, is.ErrorContains(err, "not found in mirrors"))
+	assert.Check(t, is.Len(requested, 0))
+
+	// nor if they send another layer than the expected one
+	_, _, err = mirrors.fetch(context.Background(), blob, layer.DiffID(digest.FromString("other layer")), progress.DiscardOutput(), "layer")
+	assert.Check(t, is.ErrorContains(err, "not found in mirrors"))
+	assert.Check(t, is.Len(requested, 2))
+
+	rc, _, err := mirrors.fetch(context.Background(), blob, layer.DiffID(diffID), progress.DiscardOutput(), "layer")
+	assert.NilError(t, err)
+	b, err := ioutil.ReadAll(rc)
+	rc.Close()
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(content, string(b)))
+	assert.Check(t, is.Len(requested, 4))
+
+	_, _, err = mirrors.fetch(context.Background(), unknown, layer.DiffID(digest.FromString("unknown")), progress.DiscardOutput(), "layer")
+	assert.Check(t, is.ErrorContains(err, "not found in mirrors"))
+	assert.Check(t, strings.HasSuffix(requested[len(requested)-1], unknown.String()))
+}
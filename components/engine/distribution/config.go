@@ -62,6 +62,13 @@ type ImagePullConfig struct {
 	Schema2Types []string
 	// Platform is the requested platform of the image being pulled
 	Platform *specs.Platform
+	// PartialDownloads persists the partially downloaded layers, so that
+	// their download can be resumed by a later pull. Partial downloads are
+	// discarded if it is nil.
+	PartialDownloads *PartialDownloads
+	// BlobMirrors are the sources layers are fetched from before the
+	// registry. Layers are only fetched from the registry if it is nil.
+	BlobMirrors *BlobMirrors
 }
 
 // ImagePushConfig stores push configuration.
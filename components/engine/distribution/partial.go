@@ -0,0 +1,333 @@
+package distribution // import "github.com/docker/docker/distribution"
+
+import (
+	"encoding"
+	"encoding/json"
+	"hash"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// partialCheckpointInterval is the number of bytes downloaded between
+	// two checkpoints of the state of a partial download.
+	partialCheckpointInterval = 4 << 20
+
+	// DefaultPartialDownloadMaxAge is the time after which partial
+	// downloads that were not resumed are removed.
+	DefaultPartialDownloadMaxAge = 72 * time.Hour
+
+	// partialPruneInterval is the minimum interval between two removals
+	// of stale partial downloads at the start of a pull.
+	partialPruneInterval = time.Hour
+
+	partialDataSuffix  = ".data"
+	partialStateSuffix = ".json"
+)
+
+// PartialDownloads persists the blobs being downloaded, with the state of
+// their digest verification, so that a later pull can resume the download
+// of a blob after it was interrupted, even by a daemon restart.
+type PartialDownloads struct {
+	root string
+
+	mu        sync.Mutex
+	inUse     map[digest.Digest]struct{}
+	lastPrune time.Time
+}
+
+// NewPartialDownloads returns a store of the partial downloads in root.
+func NewPartialDownloads(root string) (*PartialDownloads, error) {
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, err
+	}
+	return &PartialDownloads{
+		root:  root,
+		inUse: make(map[digest.Digest]struct{}),
+	}, nil
+}
+
+// partialState is the state of a partial download, written next to the
+// downloaded data.
+type partialState struct {
+	Digest digest.Digest
+	// Offset is the number of bytes of the blob that were downloaded and
+	// verified by the hash state.
+	Offset int64
+	// HashState is the marshaled state of the hash of the first Offset
+	// bytes of the blob.
+	HashState []byte
+}
+
+func (p *PartialDownloads) path(dgst digest.Digest, suffix string) string {
+	return filepath.Join(p.root, dgst.Algorithm().String()+"-"+dgst.Hex()+suffix)
+}
+
+// open returns the partial download of the blob, resumed from the state of
+// a previous download if there is one. It returns nil if the blob is being
+// downloaded by another pull.
+func (p *PartialDownloads) open(dgst digest.Digest) (*blobDownload, error) {
+	if err := dgst.Validate(); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	if _, ok := p.inUse[dgst]; ok {
+		p.mu.Unlock()
+		return nil, nil
+	}
+	p.inUse[dgst] = struct{}{}
+	p.mu.Unlock()
+
+	d, err := p.resume(dgst)
+	if err != nil {
+		logrus.WithError(err).WithField("digest", dgst).Debug("Cannot resume partial download")
+		d, err = p.create(dgst)
+	}
+	if err != nil {
+		p.release(dgst)
+		return nil, err
+	}
+	return d, nil
+}
+
+func (p *PartialDownloads) resume(dgst digest.Digest) (*blobDownload, error) {
+	b, err := ioutil.ReadFile(p.path(dgst, partialStateSuffix))
+	if err != nil {
+		return nil, err
+	}
+	var state partialState
+	if err := json.Unmarshal(b, &state); err != nil {
+		return nil, err
+	}
+	if state.Digest != dgst {
+		return nil, errors.Errorf("partial download state is for %s", state.Digest)
+	}
+	h := dgst.Algorithm().Hash()
+	unmarshaler, ok := h.(encoding.BinaryUnmarshaler)
+	if !ok {
+		return nil, errors.Errorf("cannot restore the state of %s hashes", dgst.Algorithm())
+	}
+	if err := unmarshaler.UnmarshalBinary(state.HashState); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(p.path(dgst, partialDataSuffix), os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	// the data written after the last checkpoint is not covered by the
+	// hash state, and is downloaded again
+	if fi.Size() < state.Offset {
+		f.Close()
+		return nil, errors.Errorf("partial download is shorter than its state (%d < %d)", fi.Size(), state.Offset)
+	}
+	if err := f.Truncate(state.Offset); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(state.Offset, os.SEEK_SET); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &blobDownload{
+		store:      p,
+		digest:     dgst,
+		file:       f,
+		hash:       h,
+		offset:     state.Offset,
+		checkpoint: state.Offset,
+	}, nil
+}
+
+func (p *PartialDownloads) create(dgst digest.Digest) (*blobDownload, error) {
+	os.Remove(p.path(dgst, partialStateSuffix))
+	f, err := os.OpenFile(p.path(dgst, partialDataSuffix), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &blobDownload{
+		store:  p,
+		digest: dgst,
+		file:   f,
+		hash:   dgst.Algorithm().Hash(),
+	}, nil
+}
+
+func (p *PartialDownloads) release(dgst digest.Digest) {
+	p.mu.Lock()
+	delete(p.inUse, dgst)
+	p.mu.Unlock()
+}
+
+// Prune removes the partial downloads that were not resumed for maxAge, and
+// the files that do not belong to a partial download.
+func (p *PartialDownloads) Prune(maxAge time.Duration) error {
+	fis, err := ioutil.ReadDir(p.root)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lastPrune = time.Now()
+	before := p.lastPrune.Add(-maxAge)
+	for _, fi := range fis {
+		name := fi.Name()
+		base := strings.TrimSuffix(strings.TrimSuffix(name, partialDataSuffix), partialStateSuffix)
+		dgst, err := digest.Parse(strings.Replace(base, "-", ":", 1))
+		if err == nil {
+			if _, ok := p.inUse[dgst]; ok || fi.ModTime().After(before) {
+				continue
+			}
+		}
+		logrus.WithField("file", name).Debug("Removing stale partial download")
+		if err := os.RemoveAll(filepath.Join(p.root, name)); err != nil {
+			logrus.WithError(err).WithField("file", name).Warn("Error removing stale partial download")
+		}
+	}
+	return nil
+}
+
+// pruneIfDue removes the partial downloads that were not resumed for
+// DefaultPartialDownloadMaxAge, unless they were pruned less than
+// partialPruneInterval ago. It is called at the start of each pull, so that
+// the downloads of failed or cancelled pulls do not pile up on long-running
+// daemons.
+func (p *PartialDownloads) pruneIfDue() {
+	p.mu.Lock()
+	due := time.Since(p.lastPrune) >= partialPruneInterval
+	p.mu.Unlock()
+	if !due {
+		return
+	}
+	if err := p.Prune(DefaultPartialDownloadMaxAge); err != nil {
+		logrus.WithError(err).Warn("Failed to remove stale partial downloads")
+	}
+}
+
+// blobDownload is the file a blob is downloaded to, with the hash of the
+// data written to the file. The state of the hash is saved periodically if
+// the download is persisted in a PartialDownloads store.
+type blobDownload struct {
+	store  *PartialDownloads
+	digest digest.Digest
+	file   *os.File
+	hash   hash.Hash
+	// offset is the size of the data written to the file.
+	offset int64
+	// checkpoint is the offset of the last saved state.
+	checkpoint int64
+}
+
+// newTempBlobDownload returns a download of the blob to a temporary file,
+// which cannot be resumed by a later pull.
+func newTempBlobDownload(dgst digest.Digest) (*blobDownload, error) {
+	f, err := createDownloadFile()
+	if err != nil {
+		return nil, err
+	}
+	return &blobDownload{
+		digest: dgst,
+		file:   f,
+		hash:   dgst.Algorithm().Hash(),
+	}, nil
+}
+
+// Write writes p to the file, and adds it to the hash.
+func (d *blobDownload) Write(p []byte) (int, error) {
+	n, err := d.file.Write(p)
+	d.hash.Write(p[:n])
+	d.offset += int64(n)
+	if d.offset-d.checkpoint >= partialCheckpointInterval {
+		d.saveState()
+	}
+	return n, err
+}
+
+// Verified returns whether the data written to the file matches the digest
+// of the blob.
+func (d *blobDownload) Verified() bool {
+	return digest.NewDigest(d.digest.Algorithm(), d.hash) == d.digest
+}
+
+// saveState saves the state of the hash, if the download is persisted.
+func (d *blobDownload) saveState() {
+	if d.store == nil {
+		return
+	}
+	d.checkpoint = d.offset
+	marshaler, ok := d.hash.(encoding.BinaryMarshaler)
+	if !ok {
+		return
+	}
+	hashState, err := marshaler.MarshalBinary()
+	if err == nil {
+		var b []byte
+		b, err = json.Marshal(partialState{Digest: d.digest, Offset: d.offset, HashState: hashState})
+		if err == nil {
+			err = ioutils.AtomicWriteFile(d.store.path(d.digest, partialStateSuffix), b, 0600)
+		}
+	}
+	if err != nil {
+		logrus.WithError(err).WithField("digest", d.digest).Warn("Error saving the state of partial download")
+	}
+}
+
+// reset discards the downloaded data, to download the blob again.
+func (d *blobDownload) reset() error {
+	d.hash = d.digest.Algorithm().Hash()
+	d.offset, d.checkpoint = 0, 0
+	if d.store != nil {
+		os.Remove(d.store.path(d.digest, partialStateSuffix))
+	}
+	if _, err := d.file.Seek(0, os.SEEK_SET); err != nil {
+		logrus.Errorf("error seeking to beginning of download file: %v", err)
+		return err
+	}
+	if err := d.file.Truncate(0); err != nil {
+		logrus.Errorf("error truncating download file: %v", err)
+		return err
+	}
+	return nil
+}
+
+// complete marks the download as complete. The file is not resumed by
+// later pulls, and is removed when it is closed.
+func (d *blobDownload) complete() *os.File {
+	if d.store != nil {
+		os.Remove(d.store.path(d.digest, partialStateSuffix))
+		d.store.release(d.digest)
+	}
+	return d.file
+}
+
+// close closes the file of an incomplete download. The download is kept to
+// be resumed by a later pull if it is persisted, and removed otherwise.
+func (d *blobDownload) close() {
+	if d.store != nil {
+		if d.offset > d.checkpoint {
+			d.saveState()
+		}
+		d.file.Close()
+		d.store.release(d.digest)
+		return
+	}
+	d.file.Close()
+	if err := os.RemoveAll(d.file.Name()); err != nil {
+		logrus.Errorf("Failed to remove temp file: %s", d.file.Name())
+	}
+}
@@ -0,0 +1,149 @@
+package distribution // import "github.com/docker/docker/distribution"
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestPartialDownloadResume(t *testing.T) {
+	root, err := ioutil.TempDir("", "partial-downloads")
+	assert.NilError(t, err)
+	defer os.RemoveAll(root)
+
+	content := strings.Repeat("a", partialCheckpointInterval) + "hello world"
+	dgst := digest.FromString(content)
+
+	partials, err := NewPartialDownloads(root)
+	assert.NilError(t, err)
+	d, err := partials.open(dgst)
+	assert.NilError(t, err)
+	assert.Assert(t, d != nil)
+	// the blob is already being downloaded
+	other, err := partials.open(dgst)
+	assert.NilError(t, err)
+	assert.Check(t, other == nil)
+
+	_, err = d.Write([]byte(content[:partialCheckpointInterval+6]))
+	assert.NilError(t, err)
+	// simulate a daemon crash, after the checkpoint, without closing the
+	// download: the data written after the checkpoint is discarded
+	d.file.Close()
+
+	partials, err = NewPartialDownloads(root)
+	assert.NilError(t, err)
+	d, err = partials.open(dgst)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(int64(partialCheckpointInterval+6), d.offset))
+
+	_, err = d.Write([]byte(content[d.offset : d.offset+2]))
+	assert.NilError(t, err)
+	d.close()
+
+	partials, err = NewPartialDownloads(root)
+	assert.NilError(t, err)
+	d, err = partials.open(dgst)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(int64(partialCheckpointInterval+8), d.offset))
+	_, err = d.Write([]byte(content[d.offset:]))
+	assert.NilError(t, err)
+	assert.Check(t, d.Verified())
+
+	f := d.complete()
+	data, err := ioutil.ReadFile(f.Name())
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(content, string(data)))
+	f.Close()
+	os.Remove(f.Name())
+
+	_, err = os.Stat(partials.path(dgst, partialStateSuffix))
+	assert.Check(t, os.IsNotExist(err))
+}
+
+func TestPartialDownloadReset(t *testing.T) {
+	root, err := ioutil.TempDir("", "partial-downloads")
+	assert.NilError(t, err)
+	defer os.RemoveAll(root)
+
+	dgst := digest.FromString("content")
+	partials, err := NewPartialDownloads(root)
+	assert.NilError(t, err)
+	d, err := partials.open(dgst)
+	assert.NilError(t, err)
+	_, err = d.Write([]byte("invalid"))
+	assert.NilError(t, err)
+	assert.Check(t, !d.Verified())
+	assert.NilError(t, d.reset())
+	d.close()
+
+	d, err = partials.open(dgst)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(int64(0), d.offset))
+	_, err = d.Write([]byte("content"))
+	assert.NilError(t, err)
+	assert.Check(t, d.Verified())
+	d.close()
+}
+
+func TestPartialDownloadsPrune(t *testing.T) {
+	root, err := ioutil.TempDir("", "partial-downloads")
+	assert.NilError(t, err)
+	defer os.RemoveAll(root)
+
+	partials, err := NewPartialDownloads(root)
+	assert.NilError(t, err)
+	stale := digest.FromString("stale")
+	recent := digest.FromString("recent")
+	inUse := digest.FromString("in use")
+	for _, dgst := range []digest.Digest{stale, recent, inUse} {
+		d, err := partials.open(dgst)
+		assert.NilError(t, err)
+		_, err = d.Write([]byte("data"))
+		assert.NilError(t, err)
+		d.close()
+	}
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(root, "garbage"), nil, 0600))
+
+	old := time.Now().Add(-2 * time.Hour)
+	for _, dgst := range []digest.Digest{stale, inUse} {
+		for _, suffix := range []string{partialDataSuffix, partialStateSuffix} {
+			assert.NilError(t, os.Chtimes(partials.path(dgst, suffix), old, old))
+		}
+	}
+	d, err := partials.open(inUse)
+	assert.NilError(t, err)
+	defer d.close()
+
+	assert.NilError(t, partials.Prune(time.Hour))
+	fis, err := ioutil.ReadDir(root)
+	assert.NilError(t, err)
+	var names []string
+	for _, fi := range fis {
+		names = append(names, fi.Name())
+	}
+	assert.Check(t, is.Len(names, 4), names)
+	for _, dgst := range []digest.Digest{recent, inUse} {
+		_, err = os.Stat(partials.path(dgst, partialDataSuffix))
+		assert.Check(t, err)
+	}
+
+	// pulls only prune the partial downloads once in a while
+	recentPath := partials.path(recent, partialDataSuffix)
+	veryOld := time.Now().Add(-2 * DefaultPartialDownloadMaxAge)
+	assert.NilError(t, os.Chtimes(recentPath, veryOld, veryOld))
+	partials.pruneIfDue()
+	_, err = os.Stat(recentPath)
+	assert.Check(t, err)
+
+	partials.lastPrune = time.Now().Add(-partialPruneInterval)
+	partials.pruneIfDue()
+	_, err = os.Stat(recentPath)
+	assert.Check(t, os.IsNotExist(err))
+}
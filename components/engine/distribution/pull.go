@@ -63,6 +63,10 @@ func Pull(ctx context.Context, ref reference.Named, imagePullConfig *ImagePullCo
 		return err
 	}
 
+	if imagePullConfig.PartialDownloads != nil {
+		imagePullConfig.PartialDownloads.pruneIfDue()
+	}
+
 	endpoints, err := imagePullConfig.RegistryService.LookupPullEndpoints(reference.Domain(repoInfo.Name))
 	if err != nil {
 		return err
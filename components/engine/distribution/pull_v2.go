@@ -139,8 +139,10 @@ type v2LayerDescriptor struct {
 	repoInfo          *registry.RepositoryInfo
 	repo              distribution.Repository
 	V2MetadataService metadata.V2MetadataService
-	tmpFile           *os.File
-	verifier          digest.Verifier
+	partials          *PartialDownloads
+	download          *blobDownload
+	mirrors           *BlobMirrors
+	mirrorsTried      bool
 	src               distribution.Descriptor
 }
 
@@ -162,36 +164,29 @@ func (ld *v2LayerDescriptor) DiffID() (layer.DiffID, error) {
 func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progress.Output) (io.ReadCloser, int64, error) {
 	logrus.Debugf("pulling blob %q", ld.digest)
 
-	var (
-		err    error
-		offset int64
-	)
+	if ld.mirrors != nil && !ld.mirrorsTried {
+		ld.mirrorsTried = true
+		rc, size, err := ld.mirrors.fetch(ctx, ld.digest, ld.diffID, progressOutput, ld.ID())
+		if err == nil {
+			progress.Update(progressOutput, ld.ID(), "Download complete")
+			return rc, size, nil
+		}
+		logrus.Debugf("pulling blob %q from registry: %v", ld.digest, err)
+	}
 
-	if ld.tmpFile == nil {
-		ld.tmpFile, err = createDownloadFile()
+	if ld.download == nil {
+		download, err := ld.openDownload()
 		if err != nil {
 			return nil, 0, xfer.DoNotRetry{Err: err}
 		}
-	} else {
-		offset, err = ld.tmpFile.Seek(0, os.SEEK_END)
-		if err != nil {
-			logrus.Debugf("error seeking to end of download file: %v", err)
-			offset = 0
-
-			ld.tmpFile.Close()
-			if err := os.Remove(ld.tmpFile.Name()); err != nil {
-				logrus.Errorf("Failed to remove temp file: %s", ld.tmpFile.Name())
-			}
-			ld.tmpFile, err = createDownloadFile()
-			if err != nil {
-				return nil, 0, xfer.DoNotRetry{Err: err}
-			}
-		} else if offset != 0 {
-			logrus.Debugf("attempting to resume download of %q from %d bytes", ld.digest, offset)
-		}
+		ld.download = download
 	}
 
-	tmpFile := ld.tmpFile
+	download := ld.download
+	offset := download.offset
+	if offset != 0 {
+		logrus.Debugf("attempting to resume download of %q from %d bytes", ld.digest, offset)
+	}
 
 	layerDownload, err := ld.open(ctx)
 	if err != nil {
@@ -202,7 +197,7 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 	if offset != 0 {
 		_, err := layerDownload.Seek(offset, os.SEEK_SET)
 		if err != nil {
-			if err := ld.truncateDownloadFile(); err != nil {
+			if err := download.reset(); err != nil {
 				return nil, 0, xfer.DoNotRetry{Err: err}
 			}
 			return nil, 0, err
@@ -218,7 +213,7 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 		if size != 0 && offset > size {
 			logrus.Debug("Partial download is larger than full blob. Starting over")
 			offset = 0
-			if err := ld.truncateDownloadFile(); err != nil {
+			if err := download.reset(); err != nil {
 				return nil, 0, xfer.DoNotRetry{Err: err}
 			}
 		}
@@ -232,17 +227,13 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 		}
 	}
 
-	reader := progress.NewProgressReader(ioutils.NewCancelReadCloser(ctx, layerDownload), progressOutput, size-offset, ld.ID(), "Downloading")
+	reader := progress.NewProgressReader(ioutils.NewCancelReadCloser(ctx, xfer.NewRateLimitedReader(ctx, layerDownload)), progressOutput, size-offset, ld.ID(), "Downloading")
 	defer reader.Close()
 
-	if ld.verifier == nil {
-		ld.verifier = ld.digest.Verifier()
-	}
-
-	_, err = io.Copy(tmpFile, io.TeeReader(reader, ld.verifier))
+	_, err = io.Copy(download, reader)
 	if err != nil {
 		if err == transport.ErrWrongCodeForByteRange {
-			if err := ld.truncateDownloadFile(); err != nil {
+			if err := download.reset(); err != nil {
 				return nil, 0, xfer.DoNotRetry{Err: err}
 			}
 			return nil, 0, err
@@ -252,17 +243,18 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 
 	progress.Update(progressOutput, ld.ID(), "Verifying Checksum")
 
-	if !ld.verifier.Verified() {
+	if !download.Verified() {
 		err = fmt.Errorf("filesystem layer verification failed for digest %s", ld.digest)
 		logrus.Error(err)
 
+		// Do not keep the invalid data to resume a later download
+		if err := download.reset(); err != nil {
+			return nil, 0, xfer.DoNotRetry{Err: err}
+		}
+
 		// Allow a retry if this digest verification error happened
 		// after a resumed download.
 		if offset != 0 {
-			if err := ld.truncateDownloadFile(); err != nil {
-				return nil, 0, xfer.DoNotRetry{Err: err}
-			}
-
 			return nil, 0, err
 		}
 		return nil, 0, xfer.DoNotRetry{Err: err}
@@ -270,6 +262,15 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 
 	progress.Update(progressOutput, ld.ID(), "Download complete")
 
+	// hand off the downloaded file to the download manager, so it will only
+	// be closed once
+	tmpFile := download.complete()
+	ld.download = nil
+
+	if ld.mirrors != nil {
+		ld.mirrors.store(ld.digest, tmpFile)
+	}
+
 	logrus.Debugf("Downloaded %s to tempfile %s", ld.ID(), tmpFile.Name())
 
 	_, err = tmpFile.Seek(0, os.SEEK_SET)
@@ -278,15 +279,9 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 		if err := os.Remove(tmpFile.Name()); err != nil {
 			logrus.Errorf("Failed to remove temp file: %s", tmpFile.Name())
 		}
-		ld.tmpFile = nil
-		ld.verifier = nil
 		return nil, 0, xfer.DoNotRetry{Err: err}
 	}
 
-	// hand off the temporary file to the download manager, so it will only
-	// be closed once
-	ld.tmpFile = nil
-
 	return ioutils.NewReadCloserWrapper(tmpFile, func() error {
 		tmpFile.Close()
 		err := os.RemoveAll(tmpFile.Name())
@@ -297,30 +292,25 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 	}), size, nil
 }
 
-func (ld *v2LayerDescriptor) Close() {
-	if ld.tmpFile != nil {
-		ld.tmpFile.Close()
-		if err := os.RemoveAll(ld.tmpFile.Name()); err != nil {
-			logrus.Errorf("Failed to remove temp file: %s", ld.tmpFile.Name())
+// openDownload returns the download of the layer, resuming a partial
+// download persisted by a previous pull if there is one.
+func (ld *v2LayerDescriptor) openDownload() (*blobDownload, error) {
+	if ld.partials != nil {
+		download, err := ld.partials.open(ld.digest)
+		if err != nil {
+			logrus.WithError(err).WithField("digest", ld.digest).Warn("Cannot persist partial download")
+		} else if download != nil {
+			return download, nil
 		}
 	}
+	return newTempBlobDownload(ld.digest)
 }
 
-func (ld *v2LayerDescriptor) truncateDownloadFile() error {
-	// Need a new hash context since we will be redoing the download
-	ld.verifier = nil
-
-	if _, err := ld.tmpFile.Seek(0, os.SEEK_SET); err != nil {
-		logrus.Errorf("error seeking to beginning of download file: %v", err)
-		return err
-	}
-
-	if err := ld.tmpFile.Truncate(0); err != nil {
-		logrus.Errorf("error truncating download file: %v", err)
-		return err
+func (ld *v2LayerDescriptor) Close() {
+	if ld.download != nil {
+		ld.download.close()
+		ld.download = nil
 	}
-
-	return nil
 }
 
 func (ld *v2LayerDescriptor) Registered(diffID layer.DiffID) {
@@ -485,6 +475,8 @@ func (p *v2Puller) pullSchema1(ctx context.Context, ref reference.Reference, unv
 			repoInfo:          p.repoInfo,
 			repo:              p.repo,
 			V2MetadataService: p.V2MetadataService,
+			partials:          p.config.PartialDownloads,
+			mirrors:           p.config.BlobMirrors,
 		}
 
 		descriptors = append(descriptors, layerDescriptor)
@@ -571,6 +563,8 @@ func (p *v2Puller) pullSchema2(ctx context.Context, ref reference.Named, mfst *s
 			repo:              p.repo,
 			repoInfo:          p.repoInfo,
 			V2MetadataService: p.V2MetadataService,
+			partials:          p.config.PartialDownloads,
+			mirrors:           p.config.BlobMirrors,
 			src:               d,
 		}
 
@@ -648,6 +642,24 @@ func (p *v2Puller) pullSchema2(ctx context.Context, ref reference.Named, mfst *s
 		}
 	}
 
+	// The layers fetched from peers are verified with the DiffIDs of the
+	// configuration, so it is received before the download starts.
+	if configJSON == nil && p.config.BlobMirrors.hasPeers() {
+		configJSON, configRootFS, _, err = receiveConfig(p.config.ImageStore, configChan, configErrChan)
+		if err == nil && configRootFS == nil {
+			err = errRootFSInvalid
+		}
+		if err != nil {
+			return "", "", err
+		}
+		if len(descriptors) != len(configRootFS.DiffIDs) {
+			return "", "", errRootFSMismatch
+		}
+		for i := range descriptors {
+			descriptors[i].(*v2LayerDescriptor).diffID = configRootFS.DiffIDs[i]
+		}
+	}
+
 	if p.config.DownloadManager != nil {
 		go func() {
 			var (
@@ -460,7 +460,7 @@ func (pd *v2PushDescriptor) uploadUsingSession(
 	}
 
 	digester := digest.Canonical.Digester()
-	tee := io.TeeReader(reader, digester.Hash())
+	tee := io.TeeReader(xfer.NewRateLimitedReader(ctx, reader), digester.Hash())
 
 	nn, err := layerUpload.ReadFrom(tee)
 	reader.Close()
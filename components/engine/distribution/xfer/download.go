@@ -26,6 +26,7 @@ const maxDownloadAttempts = 5
 type LayerDownloadManager struct {
 	layerStores  map[string]layer.Store
 	tm           TransferManager
+	limiter      *RateLimiter
 	waitDuration time.Duration
 }
 
@@ -34,11 +35,18 @@ func (ldm *LayerDownloadManager) SetConcurrency(concurrency int) {
 	ldm.tm.SetConcurrency(concurrency)
 }
 
+// SetBandwidth sets the max bandwidth of all the downloads, in bytes per
+// second. The bandwidth is not limited if it is zero.
+func (ldm *LayerDownloadManager) SetBandwidth(bytesPerSecond int64) {
+	ldm.limiter.SetLimit(bytesPerSecond)
+}
+
 // NewLayerDownloadManager returns a new LayerDownloadManager.
 func NewLayerDownloadManager(layerStores map[string]layer.Store, concurrencyLimit int, options ...func(*LayerDownloadManager)) *LayerDownloadManager {
 	manager := LayerDownloadManager{
 		layerStores:  layerStores,
 		tm:           NewTransferManager(concurrencyLimit),
+		limiter:      NewRateLimiter(0),
 		waitDuration: time.Second,
 	}
 	for _, option := range options {
@@ -163,10 +171,10 @@ func (ldm *LayerDownloadManager) Download(ctx context.Context, initialRootFS ima
 
 		var xferFunc DoFunc
 		if topDownload != nil {
-			xferFunc = ldm.makeDownloadFunc(descriptor, "", topDownload, os)
+			xferFunc = ldm.makeDownloadFunc(ctx, descriptor, "", topDownload, os)
 			defer topDownload.Transfer.Release(watcher)
 		} else {
-			xferFunc = ldm.makeDownloadFunc(descriptor, rootFS.ChainID(), nil, os)
+			xferFunc = ldm.makeDownloadFunc(ctx, descriptor, rootFS.ChainID(), nil, os)
 		}
 		topDownloadUncasted, watcher = ldm.tm.Transfer(transferKey, xferFunc, progressOutput)
 		topDownload = topDownloadUncasted.(*downloadTransfer)
@@ -223,12 +231,14 @@ func (ldm *LayerDownloadManager) Download(ctx context.Context, initialRootFS ima
 // complete before the registration step, and registers the downloaded data
 // on top of parentDownload's resulting layer. Otherwise, it registers the
 // layer on top of the ChainID given by parentLayer.
-func (ldm *LayerDownloadManager) makeDownloadFunc(descriptor DownloadDescriptor, parentLayer layer.ChainID, parentDownload *downloadTransfer, os string) DoFunc {
+func (ldm *LayerDownloadManager) makeDownloadFunc(ctx context.Context, descriptor DownloadDescriptor, parentLayer layer.ChainID, parentDownload *downloadTransfer, os string) DoFunc {
+	limiters := append(rateLimiters(ctx), ldm.limiter)
 	return func(progressChan chan<- progress.Progress, start <-chan struct{}, inactive chan<- struct{}) Transfer {
 		d := &downloadTransfer{
 			Transfer:   NewTransfer(),
 			layerStore: ldm.layerStores[os],
 		}
+		downloadCtx := withRateLimiters(d.Transfer.Context(), limiters...)
 
 		go func() {
 			defer func() {
@@ -268,7 +278,7 @@ func (ldm *LayerDownloadManager) makeDownloadFunc(descriptor DownloadDescriptor,
 			defer descriptor.Close()
 
 			for {
-				downloadReader, size, err = descriptor.Download(d.Transfer.Context(), progressOutput)
+				downloadReader, size, err = descriptor.Download(downloadCtx, progressOutput)
 				if err == nil {
 					break
 				}
@@ -0,0 +1,113 @@
+package xfer // import "github.com/docker/docker/distribution/xfer"
+
+import (
+	"context"
+	"io"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitBurst is the maximum number of bytes read at once from a rate
+// limited reader.
+const rateLimitBurst = 32 * 1024
+
+// RateLimiter limits the throughput of transfers, in bytes per second. The
+// limit can be changed while transfers are running. A limit of zero means
+// that the throughput is not limited.
+type RateLimiter struct {
+	limiter *rate.Limiter
+}
+
+// NewRateLimiter returns a RateLimiter limiting the throughput of transfers
+// to bytesPerSecond.
+func NewRateLimiter(bytesPerSecond int64) *RateLimiter {
+	return &RateLimiter{
+		limiter: rate.NewLimiter(toLimit(bytesPerSecond), rateLimitBurst),
+	}
+}
+
+// SetLimit sets the maximum throughput of transfers to bytesPerSecond.
+func (l *RateLimiter) SetLimit(bytesPerSecond int64) {
+	l.limiter.SetLimit(toLimit(bytesPerSecond))
+}
+
+// Limit returns the maximum throughput of transfers, or zero if it is not
+// limited.
+func (l *RateLimiter) Limit() int64 {
+	limit := l.limiter.Limit()
+	if limit == rate.Inf {
+		return 0
+	}
+	return int64(limit)
+}
+
+func toLimit(bytesPerSecond int64) rate.Limit {
+	if bytesPerSecond <= 0 {
+		return rate.Inf
+	}
+	return rate.Limit(bytesPerSecond)
+}
+
+type rateLimitersKey struct{}
+
+// WithRateLimit returns a context limiting the throughput of the transfers
+// of an operation, such as a single pull or push, to bytesPerSecond, in
+// addition to the limits of the transfer manager.
+func WithRateLimit(ctx context.Context, bytesPerSecond int64) context.Context {
+	if bytesPerSecond <= 0 {
+		return ctx
+	}
+	return withRateLimiters(ctx, NewRateLimiter(bytesPerSecond))
+}
+
+func withRateLimiters(ctx context.Context, limiters ...*RateLimiter) context.Context {
+	all := rateLimiters(ctx)
+	for _, l := range limiters {
+		if l != nil {
+			all = append(all, l)
+		}
+	}
+	return context.WithValue(ctx, rateLimitersKey{}, all)
+}
+
+func rateLimiters(ctx context.Context) []*RateLimiter {
+	limiters, _ := ctx.Value(rateLimitersKey{}).([]*RateLimiter)
+	return append([]*RateLimiter(nil), limiters...)
+}
+
+// NewRateLimitedReader returns a reader limiting the throughput of r to the
+// limits of the transfer and of the operation set in ctx. Reads are
+// interrupted if ctx is cancelled.
+func NewRateLimitedReader(ctx context.Context, r io.ReadCloser) io.ReadCloser {
+	limiters := rateLimiters(ctx)
+	if len(limiters) == 0 {
+		return r
+	}
+	return &rateLimitedReader{ctx: ctx, r: r, limiters: limiters}
+}
+
+type rateLimitedReader struct {
+	ctx      context.Context
+	r        io.ReadCloser
+	limiters []*RateLimiter
+}
+
+func (r *rateLimitedReader) Close() error {
+	return r.r.Close()
+}
+
+func (r *rateLimitedReader) Read(p []byte) (int, error) {
+	if len(p) > rateLimitBurst {
+		p = p[:rateLimitBurst]
+	}
+	n, err := r.r.Read(p)
+	if n <= 0 {
+		return n, err
+	}
+	for _, l := range r.limiters {
+		if werr := l.limiter.WaitN(r.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,58 @@
+package xfer // import "github.com/docker/docker/distribution/xfer"
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestRateLimitedReader(t *testing.T) {
+	content := make([]byte, 5*rateLimitBurst)
+	ctx := WithRateLimit(context.Background(), 8*rateLimitBurst)
+
+	start := time.Now()
+	n, err := io.Copy(ioutil.Discard, NewRateLimitedReader(ctx, ioutil.NopCloser(bytes.NewReader(content))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(content)) {
+		t.Fatalf("expected %d bytes, got %d", len(content), n)
+	}
+	// the first burst is not delayed, the 4 other ones take 1/8s each
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("read was not limited, took %v", elapsed)
+	}
+}
+
+func TestRateLimitedReaderUnlimited(t *testing.T) {
+	r := ioutil.NopCloser(bytes.NewReader(nil))
+	if NewRateLimitedReader(context.Background(), r) != r {
+		t.Fatal("reader without rate limit should not be wrapped")
+	}
+	if NewRateLimitedReader(WithRateLimit(context.Background(), 0), r) != r {
+		t.Fatal("reader with a zero rate limit should not be wrapped")
+	}
+
+	l := NewRateLimiter(0)
+	if l.Limit() != 0 {
+		t.Fatalf("expected no limit, got %d", l.Limit())
+	}
+	l.SetLimit(1024)
+	if l.Limit() != 1024 {
+		t.Fatalf("expected a limit of 1024, got %d", l.Limit())
+	}
+}
+
+func TestRateLimitedReaderCancel(t *testing.T) {
+	content := make([]byte, 3*rateLimitBurst)
+	ctx, cancel := context.WithCancel(withRateLimiters(context.Background(), NewRateLimiter(1024), NewRateLimiter(0)))
+	cancel()
+
+	_, err := io.Copy(ioutil.Discard, NewRateLimitedReader(ctx, ioutil.NopCloser(bytes.NewReader(content))))
+	if err != context.Canceled {
+		t.Fatalf("expected read to be cancelled, got %v", err)
+	}
+}
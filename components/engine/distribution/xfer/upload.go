@@ -17,6 +17,7 @@ const maxUploadAttempts = 5
 // uploads.
 type LayerUploadManager struct {
 	tm           TransferManager
+	limiter      *RateLimiter
 	waitDuration time.Duration
 }
 
@@ -25,10 +26,17 @@ func (lum *LayerUploadManager) SetConcurrency(concurrency int) {
 	lum.tm.SetConcurrency(concurrency)
 }
 
+// SetBandwidth sets the max bandwidth of all the uploads, in bytes per
+// second. The bandwidth is not limited if it is zero.
+func (lum *LayerUploadManager) SetBandwidth(bytesPerSecond int64) {
+	lum.limiter.SetLimit(bytesPerSecond)
+}
+
 // NewLayerUploadManager returns a new LayerUploadManager.
 func NewLayerUploadManager(concurrencyLimit int, options ...func(*LayerUploadManager)) *LayerUploadManager {
 	manager := LayerUploadManager{
 		tm:           NewTransferManager(concurrencyLimit),
+		limiter:      NewRateLimiter(0),
 		waitDuration: time.Second,
 	}
 	for _, option := range options {
@@ -78,7 +86,7 @@ func (lum *LayerUploadManager) Upload(ctx context.Context, layers []UploadDescri
 			continue
 		}
 
-		xferFunc := lum.makeUploadFunc(descriptor)
+		xferFunc := lum.makeUploadFunc(ctx, descriptor)
 		upload, watcher := lum.tm.Transfer(descriptor.Key(), xferFunc, progressOutput)
 		defer upload.Release(watcher)
 		uploads = append(uploads, upload.(*uploadTransfer))
@@ -102,11 +110,13 @@ func (lum *LayerUploadManager) Upload(ctx context.Context, layers []UploadDescri
 	return nil
 }
 
-func (lum *LayerUploadManager) makeUploadFunc(descriptor UploadDescriptor) DoFunc {
+func (lum *LayerUploadManager) makeUploadFunc(ctx context.Context, descriptor UploadDescriptor) DoFunc {
+	limiters := append(rateLimiters(ctx), lum.limiter)
 	return func(progressChan chan<- progress.Progress, start <-chan struct{}, inactive chan<- struct{}) Transfer {
 		u := &uploadTransfer{
 			Transfer: NewTransfer(),
 		}
+		uploadCtx := withRateLimiters(u.Transfer.Context(), limiters...)
 
 		go func() {
 			defer func() {
@@ -124,7 +134,7 @@ func (lum *LayerUploadManager) makeUploadFunc(descriptor UploadDescriptor) DoFun
 
 			retries := 0
 			for {
-				remoteDescriptor, err := descriptor.Upload(u.Transfer.Context(), progressOutput)
+				remoteDescriptor, err := descriptor.Upload(uploadCtx, progressOutput)
 				if err == nil {
 					u.remoteDescriptor = remoteDescriptor
 					break
@@ -23,5 +23,6 @@ swagger generate operation -f api/swagger.yaml \
 	-n ContainerUpdate \
 	-n ContainerWait \
 	-n ImageHistory \
+	-n VolumeClone \
 	-n VolumeCreate \
 	-n VolumeList
@@ -172,6 +172,28 @@ func TestGetAndSetLastUpdated(t *testing.T) {
 	assert.Check(t, cmp.Equal(updated.IsZero(), false))
 }
 
+func TestGetAndSetLastUsed(t *testing.T) {
+	store, cleanup := defaultImageStore(t)
+	defer cleanup()
+
+	id, err := store.Create([]byte(`{"comment": "abc1", "rootfs": {"type": "layers"}}`))
+	assert.NilError(t, err)
+
+	used, err := store.GetLastUsed(id)
+	assert.NilError(t, err)
+	assert.Check(t, cmp.Equal(used.IsZero(), true))
+
+	assert.Check(t, store.SetLastUsed(id))
+
+	used, err = store.GetLastUsed(id)
+	assert.NilError(t, err)
+	assert.Check(t, cmp.Equal(used.IsZero(), false))
+
+	updated, err := store.GetLastUpdated(id)
+	assert.NilError(t, err)
+	assert.Check(t, cmp.Equal(updated.IsZero(), true))
+}
+
 func TestStoreLen(t *testing.T) {
 	store, cleanup := defaultImageStore(t)
 	defer cleanup()
@@ -0,0 +1,116 @@
+// Package logfields extracts structured fields from log lines written in
+// common formats.
+package logfields // import "github.com/docker/docker/pkg/logfields"
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// Parse parses a line holding either a JSON object or logfmt pairs. It
+// returns nil if the line is in neither format.
+func Parse(line []byte) map[string]string {
+	if fields := ParseJSON(line); fields != nil {
+		return fields
+	}
+	return ParseLogfmt(line)
+}
+
+// ParseJSON parses a line holding a JSON object. String values are
+// unquoted, other values are kept as compact JSON.
+func ParseJSON(line []byte) map[string]string {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] != '{' {
+		return nil
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(line, &raw); err != nil {
+		return nil
+	}
+	fields := make(map[string]string, len(raw))
+	for k, v := range raw {
+		var s string
+		if err := json.Unmarshal(v, &s); err == nil {
+			fields[k] = s
+			continue
+		}
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, v); err != nil {
+			return nil
+		}
+		fields[k] = buf.String()
+	}
+	return fields
+}
+
+// ParseLogfmt parses a line of space-separated key=value pairs, where
+// values may be double-quoted. Keys without a value are set to "". Lines
+// without any key=value pair are not considered to be logfmt.
+func ParseLogfmt(line []byte) map[string]string {
+	fields := make(map[string]string)
+	pairs := 0
+	i := 0
+	for {
+		for i < len(line) && line[i] == ' ' {
+			i++
+		}
+		if i == len(line) {
+			break
+		}
+
+		start := i
+		for i < len(line) && line[i] > ' ' && line[i] != '=' && line[i] != '"' {
+			i++
+		}
+		if i == start {
+			return nil
+		}
+		key := string(line[start:i])
+		if i == len(line) || line[i] == ' ' {
+			fields[key] = ""
+			continue
+		}
+		if line[i] != '=' {
+			return nil
+		}
+		i++
+		pairs++
+
+		if i < len(line) && line[i] == '"' {
+			end := i + 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(line) {
+				return nil
+			}
+			value, err := strconv.Unquote(string(line[i : end+1]))
+			if err != nil {
+				return nil
+			}
+			fields[key] = value
+			i = end + 1
+			if i < len(line) && line[i] != ' ' {
+				return nil
+			}
+			continue
+		}
+
+		start = i
+		for i < len(line) && line[i] != ' ' {
+			if line[i] == '"' || line[i] == '=' {
+				return nil
+			}
+			i++
+		}
+		fields[key] = string(line[start:i])
+	}
+	if pairs == 0 {
+		return nil
+	}
+	return fields
+}
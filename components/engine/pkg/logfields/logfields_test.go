@@ -0,0 +1,43 @@
+package logfields // import "github.com/docker/docker/pkg/logfields"
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestParseJSON(t *testing.T) {
+	fields := ParseJSON([]byte(`{"level":"info","msg":"hello \"world\"","count":3,"ctx":{"a": [1, 2]}}`))
+	assert.Check(t, is.DeepEqual(map[string]string{
+		"level": "info",
+		"msg":   `hello "world"`,
+		"count": "3",
+		"ctx":   `{"a":[1,2]}`,
+	}, fields))
+
+	for _, line := range []string{"", "hello", `["a"]`, `{"a":`} {
+		assert.Check(t, is.Nil(ParseJSON([]byte(line))), line)
+	}
+}
+
+func TestParseLogfmt(t *testing.T) {
+	fields := ParseLogfmt([]byte(`level=info msg="hello \"world\"" ok  took=12ms empty=`))
+	assert.Check(t, is.DeepEqual(map[string]string{
+		"level": "info",
+		"msg":   `hello "world"`,
+		"ok":    "",
+		"took":  "12ms",
+		"empty": "",
+	}, fields))
+
+	for _, line := range []string{"", "hello world", `msg="unterminated`, `a=b"c`, `a="b"c`, "=value"} {
+		assert.Check(t, is.Nil(ParseLogfmt([]byte(line))), line)
+	}
+}
+
+func TestParse(t *testing.T) {
+	assert.Check(t, is.DeepEqual(map[string]string{"a": "1"}, Parse([]byte(`{"a":1}`))))
+	assert.Check(t, is.DeepEqual(map[string]string{"a": "1"}, Parse([]byte(`a=1`))))
+	assert.Check(t, is.Nil(Parse([]byte(`plain text`))))
+}
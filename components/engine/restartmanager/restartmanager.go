@@ -12,9 +12,12 @@ import (
 const (
 	backoffMultiplier = 2
 	defaultTimeout    = 100 * time.Millisecond
-	maxRestartTimeout = 1 * time.Minute
 )
 
+// DefaultMaxDelay is the maximum delay between two restarts of a container
+// whose restart policy does not set one.
+const DefaultMaxDelay = 1 * time.Minute
+
 // ErrRestartCanceled is returned when the restart manager has been
 // canceled and will no longer restart the container.
 var ErrRestartCanceled = errors.New("restart canceled")
@@ -23,6 +26,9 @@ var ErrRestartCanceled = errors.New("restart canceled")
 type RestartManager interface {
 	Cancel() error
 	ShouldRestart(exitCode uint32, hasBeenManuallyStopped bool, executionDuration time.Duration) (bool, chan error, error)
+	// NextRestart returns the time at which the pending restart is
+	// scheduled, or the zero time if there is no pending restart.
+	NextRestart() time.Time
 }
 
 type restartManager struct {
@@ -30,7 +36,9 @@ type restartManager struct {
 	sync.Once
 	policy       container.RestartPolicy
 	restartCount int
+	failures     []time.Time // failures within the policy's failure window
 	timeout      time.Duration
+	nextRestart  time.Time
 	active       bool
 	cancel       chan struct{}
 	canceled     bool
@@ -71,14 +79,15 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 	if executionDuration.Seconds() >= 10 {
 		rm.timeout = 0
 	}
+	initialTimeout, maxTimeout, multiplier := rm.backoff()
 	switch {
 	case rm.timeout == 0:
-		rm.timeout = defaultTimeout
-	case rm.timeout < maxRestartTimeout:
-		rm.timeout *= backoffMultiplier
+		rm.timeout = initialTimeout
+	case rm.timeout < maxTimeout:
+		rm.timeout = time.Duration(float64(rm.timeout) * multiplier)
 	}
-	if rm.timeout > maxRestartTimeout {
-		rm.timeout = maxRestartTimeout
+	if rm.timeout > maxTimeout {
+		rm.timeout = maxTimeout
 	}
 
 	var restart bool
@@ -88,10 +97,7 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 	case rm.policy.IsUnlessStopped() && !hasBeenManuallyStopped:
 		restart = true
 	case rm.policy.IsOnFailure():
-		// the default value of 0 for MaximumRetryCount means that we will not enforce a maximum count
-		if max := rm.policy.MaximumRetryCount; max == 0 || rm.restartCount < max {
-			restart = exitCode != 0
-		}
+		restart = exitCode != 0 && rm.recordFailure(time.Now())
 	}
 
 	if !restart {
@@ -103,6 +109,7 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 
 	unlockOnExit = false
 	rm.active = true
+	rm.nextRestart = time.Now().Add(rm.timeout)
 	rm.Unlock()
 
 	ch := make(chan error)
@@ -115,6 +122,7 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 			rm.Lock()
 			close(ch)
 			rm.active = false
+			rm.nextRestart = time.Time{}
 			rm.Unlock()
 		}
 	}()
@@ -126,8 +134,60 @@ func (rm *restartManager) Cancel() error {
 	rm.Do(func() {
 		rm.Lock()
 		rm.canceled = true
+		rm.nextRestart = time.Time{}
 		close(rm.cancel)
 		rm.Unlock()
 	})
 	return nil
 }
+
+func (rm *restartManager) NextRestart() time.Time {
+	rm.Lock()
+	defer rm.Unlock()
+	return rm.nextRestart
+}
+
+// backoff returns the backoff settings of the policy, falling back to the
+// defaults for unset values.
+func (rm *restartManager) backoff() (initial, max time.Duration, multiplier float64) {
+	initial, max, multiplier = defaultTimeout, DefaultMaxDelay, backoffMultiplier
+	if rm.policy.InitialDelay > 0 {
+		initial = rm.policy.InitialDelay
+	}
+	if rm.policy.MaxDelay > 0 {
+		max = rm.policy.MaxDelay
+	}
+	if rm.policy.Multiplier > 0 {
+		multiplier = rm.policy.Multiplier
+	}
+	if initial > max {
+		initial = max
+	}
+	return initial, max, multiplier
+}
+
+// recordFailure records a failure at the given time for the "on-failure"
+// policy, and reports whether the container may be restarted. Without a
+// failure window, all failures since the container was created count
+// towards the maximum retry count.
+func (rm *restartManager) recordFailure(now time.Time) bool {
+	// the default value of 0 for MaximumRetryCount means that we will not enforce a maximum count
+	max := rm.policy.MaximumRetryCount
+	if rm.policy.FailureWindow <= 0 {
+		return max == 0 || rm.restartCount < max
+	}
+
+	cutoff := now.Add(-rm.policy.FailureWindow)
+	recent := rm.failures[:0]
+	for _, t := range rm.failures {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	rm.failures = recent
+	if max != 0 && len(rm.failures) >= max {
+		return false
+	}
+	rm.failures = append(rm.failures, now)
+	return true
+}
@@ -34,3 +34,49 @@ func TestRestartManagerTimeoutReset(t *testing.T) {
 		t.Fatalf("restart manager should have a timeout of 100 ms but has %s", rm.timeout)
 	}
 }
+
+func TestRestartManagerCustomBackoff(t *testing.T) {
+	rm := New(container.RestartPolicy{
+		Name:         "always",
+		InitialDelay: 1 * time.Second,
+		MaxDelay:     5 * time.Second,
+		Multiplier:   3,
+	}, 0).(*restartManager)
+
+	for _, expected := range []time.Duration{1 * time.Second, 3 * time.Second, 5 * time.Second, 5 * time.Second} {
+		should, _, err := rm.ShouldRestart(1, false, time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !should {
+			t.Fatal("container should be restarted")
+		}
+		if rm.timeout != expected {
+			t.Fatalf("restart manager should have a timeout of %s but has %s", expected, rm.timeout)
+		}
+		if next := rm.NextRestart(); next.IsZero() {
+			t.Fatal("restart manager should report the next restart time")
+		}
+		// pretend the scheduled restart happened
+		rm.active = false
+	}
+}
+
+func TestRestartManagerFailureWindow(t *testing.T) {
+	rm := New(container.RestartPolicy{
+		Name:              "on-failure",
+		MaximumRetryCount: 2,
+		FailureWindow:     time.Minute,
+	}, 10).(*restartManager)
+
+	now := time.Now()
+	if !rm.recordFailure(now) || !rm.recordFailure(now.Add(10*time.Second)) {
+		t.Fatal("failures within the retry count should allow a restart")
+	}
+	if rm.recordFailure(now.Add(20 * time.Second)) {
+		t.Fatal("too many failures within the window should not allow a restart")
+	}
+	if !rm.recordFailure(now.Add(65 * time.Second)) {
+		t.Fatal("failures outside of the window should not be counted")
+	}
+}
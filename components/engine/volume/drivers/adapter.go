@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/volume"
 	"github.com/sirupsen/logrus"
 )
@@ -86,6 +87,23 @@ func (a *volumeDriverAdapter) Scope() string {
 	return cap.Scope
 }
 
+// Clone asks the plugin to create a volume holding a copy of the data of the
+// source volume, if the plugin advertises the snapshot capability.
+func (a *volumeDriverAdapter) Clone(source volume.Volume, name string, opts map[string]string) (volume.Volume, error) {
+	if !a.getCapabilities().Snapshot {
+		return nil, errdefs.NotImplemented(errors.New("volume driver does not support snapshots"))
+	}
+	if err := a.proxy.Clone(name, source.Name(), opts); err != nil {
+		return nil, err
+	}
+	return &volumeAdapter{
+		proxy:      a.proxy,
+		name:       name,
+		driverName: a.name,
+		scopePath:  a.scopePath,
+	}, nil
+}
+
 func (a *volumeDriverAdapter) getCapabilities() volume.Capability {
 	if a.capabilities != nil {
 		return *a.capabilities
@@ -39,6 +39,9 @@ type volumeDriver interface {
 	Get(name string) (volume *proxyVolume, err error)
 	// Capabilities gets the list of capabilities of the driver
 	Capabilities() (capabilities volume.Capability, err error)
+	// Clone creates a volume with the given name holding a copy of the
+	// data of the source volume
+	Clone(name, source string, opts map[string]string) (err error)
 }
 
 // Store is an in-memory store for volume drivers
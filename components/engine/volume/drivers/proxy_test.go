@@ -52,6 +52,11 @@ func TestVolumeRequestError(t *testing.T) {
 		fmt.Fprintln(w, `{"Err": "Cannot get volume"}`)
 	})
 
+	mux.HandleFunc("/VolumeDriver.Clone", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.docker.plugins.v1+json")
+		fmt.Fprintln(w, `{"Err": "Cannot clone volume"}`)
+	})
+
 	mux.HandleFunc("/VolumeDriver.Capabilities", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/vnd.docker.plugins.v1+json")
 		http.Error(w, "error", 500)
@@ -125,6 +130,14 @@ func TestVolumeRequestError(t *testing.T) {
 		t.Fatalf("Unexpected error: %v\n", err)
 	}
 
+	err = driver.Clone("volume2", "volume", nil)
+	if err == nil {
+		t.Fatal("Expected error, was nil")
+	}
+	if !strings.Contains(err.Error(), "Cannot clone volume") {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+
 	_, err = driver.Capabilities()
 	if err == nil {
 		t.Fatal(err)
@@ -58,6 +58,7 @@ func New(scope string, rootIdentity idtools.Identity) (*Root, error) {
 		path:         rootDirectory,
 		volumes:      make(map[string]*localVolume),
 		rootIdentity: rootIdentity,
+		limiter:      newSizeLimiter(rootDirectory),
 	}
 
 	dirs, err := ioutil.ReadDir(rootDirectory)
@@ -75,6 +76,7 @@ func New(scope string, rootIdentity idtools.Identity) (*Root, error) {
 			driverName: r.Name(),
 			name:       name,
 			path:       r.DataPath(name),
+			limiter:    r.limiter,
 		}
 		r.volumes[name] = v
 		optsFilePath := filepath.Join(rootDirectory, name, "opts.json")
@@ -106,6 +108,7 @@ type Root struct {
 	path         string
 	volumes      map[string]*localVolume
 	rootIdentity idtools.Identity
+	limiter      *sizeLimiter
 }
 
 // List lists all the volumes
@@ -146,14 +149,15 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 	}
 
 	path := r.DataPath(name)
-	if err := idtools.MkdirAllAndChown(path, 0755, r.rootIdentity); err != nil {
-		return nil, errors.Wrapf(errdefs.System(err), "error while creating volume path '%s'", path)
+	volumePath := filepath.Dir(path)
+	if err := idtools.MkdirAllAndChown(volumePath, 0755, r.rootIdentity); err != nil {
+		return nil, errors.Wrapf(errdefs.System(err), "error while creating volume path '%s'", volumePath)
 	}
 
 	var err error
 	defer func() {
 		if err != nil {
-			os.RemoveAll(filepath.Dir(path))
+			os.RemoveAll(volumePath)
 		}
 	}()
 
@@ -161,22 +165,32 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 		driverName: r.Name(),
 		name:       name,
 		path:       path,
+		limiter:    r.limiter,
 	}
 
 	if len(opts) != 0 {
 		if err = setOpts(v, opts); err != nil {
 			return nil, err
 		}
+		// the size must be limited before the data directory is created, for
+		// it to inherit the project quota of the volume directory
+		if err = r.limitSize(v); err != nil {
+			return nil, errdefs.System(errors.Wrap(err, "error while limiting volume size"))
+		}
 		var b []byte
 		b, err = json.Marshal(v.opts)
 		if err != nil {
 			return nil, err
 		}
-		if err = ioutil.WriteFile(filepath.Join(filepath.Dir(path), "opts.json"), b, 600); err != nil {
+		if err = ioutil.WriteFile(filepath.Join(volumePath, "opts.json"), b, 600); err != nil {
 			return nil, errdefs.System(errors.Wrap(err, "error while persisting volume options"))
 		}
 	}
 
+	if err = idtools.MkdirAndChown(path, 0755, r.rootIdentity); err != nil {
+		return nil, errors.Wrapf(errdefs.System(err), "error while creating volume path '%s'", path)
+	}
+
 	r.volumes[name] = v
 	return v, nil
 }
@@ -280,6 +294,8 @@ type localVolume struct {
 	opts *optsConfig
 	// active refcounts the active mounts
 	active activeMount
+	// limiter limits the size of the volume, if set in its options
+	limiter *sizeLimiter
 }
 
 // Name returns the name of the given Volume.
@@ -361,10 +377,6 @@ func validateOpts(opts map[string]string) error {
 	return nil
 }
 
-func (v *localVolume) Status() map[string]interface{} {
-	return nil
-}
-
 // getAddress finds out address/hostname from options
 func getAddress(opts string) string {
 	optsList := strings.Split(opts, ",")
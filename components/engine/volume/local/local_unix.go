@@ -26,6 +26,7 @@ var (
 		"type":   true, // specify the filesystem type for mount, e.g. nfs
 		"o":      true, // generic mount options
 		"device": true, // device to mount from
+		"size":   true, // maximum size of the volume data
 	}
 )
 
@@ -33,10 +34,16 @@ type optsConfig struct {
 	MountType   string
 	MountOpts   string
 	MountDevice string
+	// Size is the maximum size of the volume data, in bytes.
+	Size uint64 `json:",omitempty"`
+	// Loopback is set if the size of the volume is limited by storing its
+	// data in a filesystem image mounted with a loopback device, rather than
+	// with a project quota.
+	Loopback bool `json:",omitempty"`
 }
 
 func (o *optsConfig) String() string {
-	return fmt.Sprintf("type='%s' device='%s' o='%s'", o.MountType, o.MountDevice, o.MountOpts)
+	return fmt.Sprintf("type='%s' device='%s' o='%s' size='%d'", o.MountType, o.MountDevice, o.MountOpts, o.Size)
 }
 
 // scopedPath verifies that the path where the volume is located
@@ -68,10 +75,27 @@ func setOpts(v *localVolume, opts map[string]string) error {
 		MountOpts:   opts["o"],
 		MountDevice: opts["device"],
 	}
+	if s, ok := opts["size"]; ok {
+		if v.opts.MountType != "" || v.opts.MountOpts != "" || v.opts.MountDevice != "" {
+			return validationError("size option cannot be used with type, o or device options")
+		}
+		size, err := parseSize(s)
+		if err != nil {
+			return err
+		}
+		v.opts.Size = size
+	}
 	return nil
 }
 
 func (v *localVolume) mount() error {
+	if v.opts.Size > 0 {
+		if v.opts.Loopback {
+			return v.mountLoopback()
+		}
+		// the size is limited with a project quota, there is nothing to mount
+		return nil
+	}
 	if v.opts.MountDevice == "" {
 		return fmt.Errorf("missing device in volume options")
 	}
@@ -0,0 +1,140 @@
+// +build linux,cgo
+
+package local // import "github.com/docker/docker/volume/local"
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/daemon/graphdriver/quota"
+	"github.com/docker/docker/pkg/loopback"
+	"github.com/docker/docker/pkg/mount"
+	units "github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// loopbackImageName is the name of the filesystem image storing the data
+	// of a volume whose size is limited with a loopback device.
+	loopbackImageName = "disk.img"
+	loopbackFsType    = "ext4"
+)
+
+// sizeLimiter holds the project quota control of the volumes directory. It
+// is initialized when it is first needed, to create a volume with a size
+// limit or to report the usage of one.
+type sizeLimiter struct {
+	path     string
+	once     sync.Once
+	quotaCtl *quota.Control
+}
+
+func newSizeLimiter(path string) *sizeLimiter {
+	return &sizeLimiter{path: path}
+}
+
+func parseSize(s string) (uint64, error) {
+	size, err := units.RAMInBytes(s)
+	if err != nil || size <= 0 {
+		return 0, validationError(fmt.Sprintf("invalid size: %q", s))
+	}
+	return uint64(size), nil
+}
+
+// quotaControl returns the project quota control of the volumes directory,
+// or nil if its filesystem does not support project quotas.
+func (l *sizeLimiter) quotaControl() *quota.Control {
+	l.once.Do(func() {
+		ctl, err := quota.NewControl(l.path)
+		if err != nil {
+			logrus.WithError(err).Debug("project quotas are not supported for local volumes, falling back to loopback devices to limit their size")
+			return
+		}
+		l.quotaCtl = ctl
+	})
+	return l.quotaCtl
+}
+
+// limitSize limits the size of the data of v to the size set in its options,
+// if any. The size is limited with a project quota on the volume directory if
+// the filesystem supports them, and otherwise by storing the data in a
+// filesystem image, which is mounted with a loopback device.
+func (r *Root) limitSize(v *localVolume) error {
+	if v.opts == nil || v.opts.Size == 0 {
+		return nil
+	}
+	volumePath := filepath.Dir(v.path)
+	if ctl := r.limiter.quotaControl(); ctl != nil {
+		return ctl.SetQuota(volumePath, quota.Quota{Size: v.opts.Size})
+	}
+
+	image := filepath.Join(volumePath, loopbackImageName)
+	f, err := os.OpenFile(image, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	err = f.Truncate(int64(v.opts.Size))
+	f.Close()
+	if err != nil {
+		return err
+	}
+	rootOwner := fmt.Sprintf("root_owner=%d:%d", r.rootIdentity.UID, r.rootIdentity.GID)
+	if out, err := exec.Command("mkfs."+loopbackFsType, "-q", "-F", "-E", rootOwner, image).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "failed to create filesystem image: %s", strings.TrimSpace(string(out)))
+	}
+	v.opts.Loopback = true
+	return nil
+}
+
+// mountLoopback mounts the filesystem image storing the data of v on its
+// data directory.
+func (v *localVolume) mountLoopback() error {
+	loopFile, err := loopback.AttachLoopDevice(filepath.Join(filepath.Dir(v.path), loopbackImageName))
+	if err != nil {
+		return errors.Wrap(err, "failed to attach loopback device for local volume")
+	}
+	// the loopback device is detached when it is closed and unmounted
+	defer loopFile.Close()
+
+	err = mount.Mount(loopFile.Name(), v.path, loopbackFsType, "")
+	return errors.Wrap(err, "failed to mount local volume")
+}
+
+// Status returns the size limit of the volume and the size of its data, if
+// the volume has a size limit. The size of the data is read from the project
+// quota of the volume, or from the filesystem stored in the image of the
+// volume, which is only known while it is mounted.
+func (v *localVolume) Status() map[string]interface{} {
+	if v.opts == nil || v.opts.Size == 0 {
+		return nil
+	}
+	status := map[string]interface{}{
+		"Size": v.opts.Size,
+	}
+
+	if !v.opts.Loopback {
+		if ctl := v.limiter.quotaControl(); ctl != nil {
+			if usage, err := ctl.GetUsage(filepath.Dir(v.path)); err == nil {
+				status["Usage"] = usage
+			}
+		}
+		return status
+	}
+
+	v.m.Lock()
+	mounted := v.active.mounted
+	v.m.Unlock()
+	if mounted {
+		var st unix.Statfs_t
+		if err := unix.Statfs(v.path, &st); err == nil {
+			status["Usage"] = (st.Blocks - st.Bfree) * uint64(st.Bsize)
+		}
+	}
+	return status
+}
@@ -0,0 +1,85 @@
+// +build linux,cgo
+
+package local // import "github.com/docker/docker/volume/local"
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/pkg/idtools"
+	"gotest.tools/skip"
+)
+
+func TestCreateWithSize(t *testing.T) {
+	skip.If(t, os.Getuid() != 0, "requires mounts")
+	if _, err := exec.LookPath("mkfs.ext4"); err != nil {
+		t.Skip("mkfs.ext4 not found in PATH")
+	}
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, idtools.Identity{UID: os.Geteuid(), GID: os.Getegid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.Create("test", map[string]string{"size": "invalid"}); err == nil {
+		t.Fatal("expected invalid size to cause error")
+	}
+	if _, err := r.Create("test", map[string]string{"size": "10m", "type": "tmpfs"}); err == nil {
+		t.Fatal("expected size with type to cause error")
+	}
+	if _, err := os.Stat(filepath.Join(rootDir, volumesPathName, "test")); !os.IsNotExist(err) {
+		t.Fatalf("expected volume directory to be removed after error, got %v", err)
+	}
+
+	vol, err := r.Create("test", map[string]string{"size": "16m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := vol.(*localVolume)
+	if v.opts.Size != 16*1024*1024 {
+		t.Fatalf("expected size of 16MiB, got %d", v.opts.Size)
+	}
+
+	dir, err := v.Mount("1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := v.Unmount("1234"); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "big"), make([]byte, 32*1024*1024), 0644); err == nil {
+		t.Fatal("expected writing more than the size of the volume to fail")
+	}
+
+	status := v.Status()
+	if status["Size"] != v.opts.Size {
+		t.Fatalf("expected status to report size %d, got %v", v.opts.Size, status)
+	}
+	if _, ok := status["Usage"]; !ok {
+		t.Fatalf("expected status to report usage, got %v", status)
+	}
+
+	r, err = New(rootDir, idtools.Identity{UID: os.Geteuid(), GID: os.Getegid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2, exists := r.volumes["test"]
+	if !exists {
+		t.Fatal("missing volume on restart")
+	}
+	if !reflect.DeepEqual(v.opts, v2.opts) {
+		t.Fatal("missing volume options on restart")
+	}
+}
@@ -0,0 +1,29 @@
+// +build !linux !cgo
+
+package local // import "github.com/docker/docker/volume/local"
+
+import "errors"
+
+type sizeLimiter struct{}
+
+func newSizeLimiter(path string) *sizeLimiter {
+	return &sizeLimiter{}
+}
+
+func parseSize(s string) (uint64, error) {
+	return 0, validationError("size option requires project quota or loopback device support, which is not available on this platform")
+}
+
+func (r *Root) limitSize(v *localVolume) error {
+	return nil
+}
+
+func (v *localVolume) mountLoopback() error {
+	return errors.New("loopback devices are not supported on this platform")
+}
+
+// Status returns nil, as the size of volumes cannot be limited on this
+// platform, or without cgo.
+func (v *localVolume) Status() map[string]interface{} {
+	return nil
+}
@@ -0,0 +1,192 @@
+package service // import "github.com/docker/docker/volume/service"
+
+import (
+	"context"
+	"io"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/chrootarchive"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/volume"
+	"github.com/docker/docker/volume/service/opts"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Clone creates a volume with the given name holding a copy of the data of
+// the source volume. The new volume is created with the driver and the
+// options of the source volume, and with its labels unless other labels are
+// passed in. If the driver cannot copy the volume natively, the data is
+// copied as a tar archive.
+func (s *VolumesService) Clone(ctx context.Context, source, name string, createOpts ...opts.CreateOption) (*types.Volume, error) {
+	if name == "" {
+		name = stringid.GenerateRandomID()
+	}
+	ref := "clone-" + stringid.GenerateRandomID()
+
+	src, err := s.vs.Get(ctx, source, opts.WithGetReference(ref))
+	if err != nil {
+		return nil, err
+	}
+	defer s.vs.Release(ctx, src.Name(), ref)
+
+	if _, err := s.vs.Get(ctx, name); err == nil {
+		return nil, errdefs.Conflict(errors.Errorf("volume %s already exists", name))
+	} else if !IsNotExist(err) {
+		return nil, err
+	}
+
+	cfg := opts.CreateConfig{}
+	if dv, ok := src.(volume.DetailedVolume); ok {
+		cfg.Options = dv.Options()
+		cfg.Labels = dv.Labels()
+	}
+	if src.DriverName() == volume.DefaultDriverName {
+		cfg.Options = localCloneOptions(cfg.Options)
+	}
+	for _, o := range createOpts {
+		o(&cfg)
+	}
+
+	vd, err := s.vs.drivers.GetDriver(src.DriverName())
+	if err != nil {
+		return nil, err
+	}
+	var cloned bool
+	if sd, ok := vd.(volume.SnapshotDriver); ok {
+		_, err := sd.Clone(unwrapVolume(src), name, cfg.Options)
+		if err != nil && !errdefs.IsNotImplemented(err) {
+			return nil, &OpErr{Err: err, Name: name, Op: "clone"}
+		}
+		cloned = err == nil
+	}
+
+	// the volume created by the driver, if any, is registered by Create
+	v, err := s.vs.Create(ctx, name, src.DriverName(), opts.WithCreateOptions(cfg.Options), opts.WithCreateLabels(cfg.Labels), opts.WithCreateReference(ref))
+	if err != nil {
+		return nil, err
+	}
+
+	var copyErr error
+	if !cloned {
+		copyErr = s.copyVolume(src, v, ref)
+	}
+	// the reference must be released before the volume can be removed
+	if err := s.vs.Release(ctx, v.Name(), ref); err != nil {
+		logrus.WithError(err).WithField("volume", v.Name()).Warn("Error releasing reference to volume")
+	}
+	if copyErr != nil {
+		if err := s.vs.Remove(ctx, v); err != nil {
+			logrus.WithError(err).WithField("volume", v.Name()).Warn("Error removing volume after failed copy")
+		}
+		return nil, errors.Wrapf(copyErr, "error copying volume %s", src.Name())
+	}
+
+	s.eventLogger.LogVolumeEvent(v.Name(), "create", map[string]string{"driver": v.DriverName(), "source": src.Name()})
+	apiV := volumeToAPIType(v)
+	return &apiV, nil
+}
+
+// localCloneOptions returns the options of a clone of a local volume
+// created with the given options. A local volume mounting a device, a
+// network filesystem or a bind mount would mount the same storage as the
+// source volume, so the clone is created as a plain local volume instead,
+// with the same size limit.
+func localCloneOptions(options map[string]string) map[string]string {
+	if options["type"] == "" && options["o"] == "" && options["device"] == "" {
+		return options
+	}
+	cloneOptions := make(map[string]string)
+	for k, v := range options {
+		switch k {
+		case "type", "o", "device":
+		default:
+			cloneOptions[k] = v
+		}
+	}
+	return cloneOptions
+}
+
+// Export writes a tar archive of the data of the given volume to out.
+func (s *VolumesService) Export(ctx context.Context, name string, out io.Writer) error {
+	ref := "export-" + stringid.GenerateRandomID()
+	v, err := s.vs.Get(ctx, name, opts.WithGetReference(ref))
+	if err != nil {
+		return err
+	}
+	defer s.vs.Release(ctx, v.Name(), ref)
+
+	path, err := v.Mount(ref)
+	if err != nil {
+		return errors.Wrapf(err, "error mounting volume %s", v.Name())
+	}
+	defer v.Unmount(ref)
+
+	rdr, err := archive.TarWithOptions(path, s.tarOptions())
+	if err != nil {
+		return err
+	}
+	defer rdr.Close()
+
+	_, err = io.Copy(out, rdr)
+	return err
+}
+
+// Import extracts the tar archive read from in to the given volume. Files
+// of the volume with the same name as files of the archive are replaced.
+func (s *VolumesService) Import(ctx context.Context, name string, in io.Reader) error {
+	ref := "import-" + stringid.GenerateRandomID()
+	v, err := s.vs.Get(ctx, name, opts.WithGetReference(ref))
+	if err != nil {
+		return err
+	}
+	defer s.vs.Release(ctx, v.Name(), ref)
+
+	path, err := v.Mount(ref)
+	if err != nil {
+		return errors.Wrapf(err, "error mounting volume %s", v.Name())
+	}
+	defer v.Unmount(ref)
+
+	if err := chrootarchive.Untar(in, path, s.tarOptions()); err != nil {
+		return errors.Wrapf(err, "error importing archive in volume %s", v.Name())
+	}
+	return nil
+}
+
+// copyVolume copies the data of the source volume to the target volume as a
+// tar archive.
+func (s *VolumesService) copyVolume(source, target volume.Volume, ref string) error {
+	srcPath, err := source.Mount(ref)
+	if err != nil {
+		return errors.Wrapf(err, "error mounting volume %s", source.Name())
+	}
+	defer source.Unmount(ref)
+
+	dstPath, err := target.Mount(ref)
+	if err != nil {
+		return errors.Wrapf(err, "error mounting volume %s", target.Name())
+	}
+	defer target.Unmount(ref)
+
+	rdr, err := archive.TarWithOptions(srcPath, s.tarOptions())
+	if err != nil {
+		return err
+	}
+	defer rdr.Close()
+
+	return chrootarchive.Untar(rdr, dstPath, s.tarOptions())
+}
+
+// tarOptions returns the options of the tar archives of the data of volumes.
+// The owners of the files are mapped through the identity mapping of the
+// daemon, so that archives hold the identities seen by containers.
+func (s *VolumesService) tarOptions() *archive.TarOptions {
+	return &archive.TarOptions{
+		Compression: archive.Uncompressed,
+		UIDMaps:     s.idMapping.UIDs(),
+		GIDMaps:     s.idMapping.GIDs(),
+	}
+}
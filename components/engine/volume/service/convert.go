@@ -59,6 +59,7 @@ func (s *VolumesService) volumesToAPI(ctx context.Context, volumes []volume.Volu
 			apiV.Mountpoint = v.Path()
 		}
 
+		apiV.UsageData = s.vs.usageData(v)
 		if getSize {
 			p := v.Path()
 			if apiV.Mountpoint == "" {
@@ -68,8 +69,10 @@ func (s *VolumesService) volumesToAPI(ctx context.Context, volumes []volume.Volu
 			if err != nil {
 				logrus.WithError(err).WithField("volume", v.Name()).Warnf("Failed to determine size of volume")
 				sz = -1
+			} else {
+				s.vs.SetSize(v.Name(), sz)
 			}
-			apiV.UsageData = &types.VolumeUsageData{Size: sz, RefCount: int64(s.vs.CountReferences(v))}
+			apiV.UsageData.Size = sz
 		}
 
 		out = append(out, &apiV)
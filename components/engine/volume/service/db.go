@@ -2,6 +2,7 @@ package service // import "github.com/docker/docker/volume/service"
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/docker/docker/errdefs"
 	"github.com/pkg/errors"
@@ -16,6 +17,12 @@ type volumeMetadata struct {
 	Driver  string
 	Labels  map[string]string
 	Options map[string]string
+	// Size is the size of the volume, in bytes, the last time it was
+	// computed, or nil if it was never computed.
+	Size *int64 `json:",omitempty"`
+	// LastUsed is the last time the volume was referenced, mounted or
+	// unmounted.
+	LastUsed time.Time `json:",omitempty"`
 }
 
 func (s *VolumeStore) setMeta(name string, meta volumeMetadata) error {
@@ -59,6 +66,19 @@ func getMeta(tx *bolt.Tx, name string, meta *volumeMetadata) error {
 	return nil
 }
 
+// updateMeta calls fn to modify the stored metadata of the named volume.
+func (s *VolumeStore) updateMeta(name string, fn func(*volumeMetadata)) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		var meta volumeMetadata
+		if err := getMeta(tx, name, &meta); err != nil {
+			return err
+		}
+		meta.Name = name
+		fn(&meta)
+		return setMeta(tx, name, meta)
+	})
+}
+
 func (s *VolumeStore) removeMeta(name string) error {
 	return s.db.Update(func(tx *bolt.Tx) error {
 		return removeMeta(tx, name)
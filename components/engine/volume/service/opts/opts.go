@@ -42,6 +42,7 @@ type GetConfig struct {
 	Driver        string
 	Reference     string
 	ResolveStatus bool
+	Size          bool
 }
 
 // GetOption is passed to the service `Get` add extra details on the get request
@@ -65,12 +66,19 @@ func WithGetReference(ref string) GetOption {
 	}
 }
 
-// WithGetResolveStatus indicates to `Get` to also fetch the volume status.
+// WithGetResolveStatus indicates to `Get` to also fetch the volume status
+// and usage data.
 // This can cause significant overhead in the volume lookup.
 func WithGetResolveStatus(cfg *GetConfig) {
 	cfg.ResolveStatus = true
 }
 
+// WithGetSize indicates to `Get` to compute the size of the volume.
+// This can cause significant overhead in the volume lookup.
+func WithGetSize(cfg *GetConfig) {
+	cfg.Size = true
+}
+
 // RemoveConfig is used by `RemoveOption` to store config options for remove
 type RemoveConfig struct {
 	PurgeOnError bool
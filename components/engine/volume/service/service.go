@@ -32,12 +32,13 @@ type VolumesService struct {
 	ds           ds
 	pruneRunning int32
 	eventLogger  volumeEventLogger
+	idMapping    *idtools.IdentityMapping
 }
 
 // NewVolumeService creates a new volume service
-func NewVolumeService(root string, pg plugingetter.PluginGetter, rootIDs idtools.Identity, logger volumeEventLogger) (*VolumesService, error) {
+func NewVolumeService(root string, pg plugingetter.PluginGetter, idMapping *idtools.IdentityMapping, logger volumeEventLogger) (*VolumesService, error) {
 	ds := drivers.NewStore(pg)
-	if err := setupDefaultDriver(ds, root, rootIDs); err != nil {
+	if err := setupDefaultDriver(ds, root, idMapping.RootPair()); err != nil {
 		return nil, err
 	}
 
@@ -45,7 +46,7 @@ func NewVolumeService(root string, pg plugingetter.PluginGetter, rootIDs idtools
 	if err != nil {
 		return nil, err
 	}
-	return &VolumesService{vs: vs, ds: ds, eventLogger: logger}, nil
+	return &VolumesService{vs: vs, ds: ds, eventLogger: logger, idMapping: idMapping}, nil
 }
 
 // GetDriverList gets the list of registered volume drivers
@@ -83,6 +84,20 @@ func (s *VolumesService) Get(ctx context.Context, name string, getOpts ...opts.G
 
 	if cfg.ResolveStatus {
 		vol.Status = v.Status()
+		vol.UsageData = s.vs.usageData(v)
+	}
+	if cfg.Size {
+		if vol.UsageData == nil {
+			vol.UsageData = s.vs.usageData(v)
+		}
+		if dv, ok := v.(volume.DetailedVolume); ok && v.DriverName() == volume.DefaultDriverName && len(dv.Options()) == 0 {
+			sz, err := directory.Size(ctx, v.Path())
+			if err != nil {
+				return nil, errors.Wrapf(err, "error computing size of volume %s", v.Name())
+			}
+			s.vs.SetSize(v.Name(), sz)
+			vol.UsageData.Size = sz
+		}
 	}
 	return &vol, nil
 }
@@ -96,7 +111,12 @@ func (s *VolumesService) Mount(ctx context.Context, vol *types.Volume, ref strin
 		}
 		return "", err
 	}
-	return v.Mount(ref)
+	path, err := v.Mount(ref)
+	if err != nil {
+		return "", err
+	}
+	s.vs.SetUsed(v.Name())
+	return path, nil
 }
 
 // Unmount unmounts the volume.
@@ -109,7 +129,11 @@ func (s *VolumesService) Unmount(ctx context.Context, vol *types.Volume, ref str
 		}
 		return err
 	}
-	return v.Unmount(ref)
+	if err := v.Unmount(ref); err != nil {
+		return err
+	}
+	s.vs.SetUsed(v.Name())
+	return nil
 }
 
 // Release releases a volume reference
@@ -148,8 +172,10 @@ func (s *VolumesService) Remove(ctx context.Context, name string, rmOpts ...opts
 }
 
 var acceptedPruneFilters = map[string]bool{
-	"label":  true,
-	"label!": true,
+	"label":      true,
+	"label!":     true,
+	"size>":      true,
+	"unused-for": true,
 }
 
 var acceptedListFilters = map[string]bool{
@@ -187,6 +213,17 @@ func (s *VolumesService) Prune(ctx context.Context, filter filters.Args) (*types
 	if err != nil {
 		return nil, err
 	}
+	unusedBy, err := s.vs.byUnusedFor(filter)
+	if err != nil {
+		return nil, err
+	}
+	if unusedBy != nil {
+		by = And(by, unusedBy)
+	}
+	minSize, err := minSizeFromFilter(filter)
+	if err != nil {
+		return nil, err
+	}
 	ls, _, err := s.vs.Find(ctx, And(ByDriver(volume.DefaultDriverName), ByReferenced(false), by, CustomFilter(func(v volume.Volume) bool {
 		dv, ok := v.(volume.DetailedVolume)
 		return ok && len(dv.Options()) == 0
@@ -210,6 +247,12 @@ func (s *VolumesService) Prune(ctx context.Context, filter filters.Args) (*types
 		vSize, err := directory.Size(ctx, v.Path())
 		if err != nil {
 			logrus.WithField("volume", v.Name()).WithError(err).Warn("could not determine size of volume")
+			if minSize >= 0 {
+				continue
+			}
+		}
+		if vSize <= minSize {
+			continue
 		}
 		if err := s.vs.Remove(ctx, v); err != nil {
 			logrus.WithError(err).WithField("volume", v.Name()).Warnf("Could not determine size of volume")
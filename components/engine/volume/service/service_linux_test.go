@@ -1,13 +1,18 @@
 package service
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
 
 	"github.com/docker/docker/pkg/idtools"
+	"github.com/docker/docker/pkg/reexec"
 	"github.com/docker/docker/volume"
 	volumedrivers "github.com/docker/docker/volume/drivers"
 	"github.com/docker/docker/volume/local"
@@ -15,8 +20,14 @@ import (
 	"github.com/docker/docker/volume/testutils"
 	"gotest.tools/assert"
 	is "gotest.tools/assert/cmp"
+	"gotest.tools/poll"
+	"gotest.tools/skip"
 )
 
+func init() {
+	reexec.Init()
+}
+
 func TestLocalVolumeSize(t *testing.T) {
 	t.Parallel()
 
@@ -64,3 +75,172 @@ func TestLocalVolumeSize(t *testing.T) {
 		}
 	}
 }
+
+func TestLocalVolumeSizeOnRelease(t *testing.T) {
+	t.Parallel()
+
+	ds := volumedrivers.NewStore(nil)
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	l, err := local.New(dir, idtools.Identity{UID: os.Getuid(), GID: os.Getegid()})
+	assert.NilError(t, err)
+	assert.Assert(t, ds.Register(l, volume.DefaultDriverName))
+
+	service, cleanup := newTestService(t, ds)
+	defer cleanup()
+
+	ctx := context.Background()
+	v, err := service.Create(ctx, "test", volume.DefaultDriverName, opts.WithCreateReference("c1"))
+	assert.NilError(t, err)
+	_, err = service.Get(ctx, "test", opts.WithGetReference("c2"))
+	assert.NilError(t, err)
+	err = ioutil.WriteFile(filepath.Join(v.Mountpoint, "data"), make([]byte, 1024), 0644)
+	assert.NilError(t, err)
+
+	size := func() int64 {
+		v, err := service.Get(ctx, "test", opts.WithGetResolveStatus)
+		assert.NilError(t, err)
+		return v.UsageData.Size
+	}
+
+	// the size is only computed once the volume is no longer referenced
+	assert.NilError(t, service.Release(ctx, "test", "c1"))
+	assert.Check(t, is.Equal(size(), int64(-1)))
+	assert.NilError(t, service.Release(ctx, "test", "c2"))
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if sz := size(); sz != 1024 {
+			return poll.Continue("volume size is %d", sz)
+		}
+		return poll.Success()
+	})
+}
+
+func TestLocalVolumeCloneExportImport(t *testing.T) {
+	skip.If(t, os.Getuid() != 0, "requires chroot")
+	t.Parallel()
+
+	ds := volumedrivers.NewStore(nil)
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	l, err := local.New(dir, idtools.Identity{UID: os.Getuid(), GID: os.Getegid()})
+	assert.NilError(t, err)
+	assert.Assert(t, ds.Register(l, volume.DefaultDriverName))
+
+	service, cleanup := newTestService(t, ds)
+	defer cleanup()
+
+	ctx := context.Background()
+	v1, err := service.Create(ctx, "test1", volume.DefaultDriverName)
+	assert.NilError(t, err)
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(v1.Mountpoint, "data"), []byte("hello"), 0644))
+
+	v2, err := service.Clone(ctx, "test1", "test2")
+	assert.NilError(t, err)
+	data, err := ioutil.ReadFile(filepath.Join(v2.Mountpoint, "data"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(data), "hello"))
+
+	archiveFile := filepath.Join(dir, "test1.tar")
+	f, err := os.Create(archiveFile)
+	assert.NilError(t, err)
+	assert.NilError(t, service.Export(ctx, "test1", f))
+	assert.NilError(t, f.Close())
+
+	v3, err := service.Create(ctx, "test3", volume.DefaultDriverName)
+	assert.NilError(t, err)
+	f, err = os.Open(archiveFile)
+	assert.NilError(t, err)
+	defer f.Close()
+	assert.NilError(t, service.Import(ctx, "test3", f))
+	data, err = ioutil.ReadFile(filepath.Join(v3.Mountpoint, "data"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(data), "hello"))
+
+	err = service.Import(ctx, "test3", strings.NewReader("not an archive"))
+	assert.Check(t, err != nil)
+}
+
+func TestLocalVolumeImportRemapped(t *testing.T) {
+	skip.If(t, os.Getuid() != 0, "requires chroot")
+	t.Parallel()
+
+	ds := volumedrivers.NewStore(nil)
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	idMap := []idtools.IDMap{{ContainerID: 0, HostID: 100000, Size: 65536}}
+	idMapping := idtools.NewIDMappingsFromMaps(idMap, idMap)
+	l, err := local.New(dir, idMapping.RootPair())
+	assert.NilError(t, err)
+	assert.Assert(t, ds.Register(l, volume.DefaultDriverName))
+
+	service, cleanup := newTestService(t, ds)
+	defer cleanup()
+	service.idMapping = idMapping
+
+	ctx := context.Background()
+	v, err := service.Create(ctx, "test", volume.DefaultDriverName)
+	assert.NilError(t, err)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	assert.NilError(t, tw.WriteHeader(&tar.Header{Name: "data", Mode: 0644, Uid: 1000, Gid: 1000, Size: 5}))
+	_, err = tw.Write([]byte("hello"))
+	assert.NilError(t, err)
+	assert.NilError(t, tw.Close())
+	assert.NilError(t, service.Import(ctx, "test", &buf))
+
+	fi, err := os.Stat(filepath.Join(v.Mountpoint, "data"))
+	assert.NilError(t, err)
+	st := fi.Sys().(*syscall.Stat_t)
+	assert.Check(t, is.Equal(st.Uid, uint32(101000)))
+	assert.Check(t, is.Equal(st.Gid, uint32(101000)))
+}
+
+func TestLocalVolumeCloneBindMount(t *testing.T) {
+	skip.If(t, os.Getuid() != 0, "requires mounts")
+	t.Parallel()
+
+	ds := volumedrivers.NewStore(nil)
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	l, err := local.New(filepath.Join(dir, "volumes"), idtools.Identity{UID: os.Getuid(), GID: os.Getegid()})
+	assert.NilError(t, err)
+	assert.Assert(t, ds.Register(l, volume.DefaultDriverName))
+
+	service, cleanup := newTestService(t, ds)
+	defer cleanup()
+
+	source := filepath.Join(dir, "source")
+	assert.NilError(t, os.Mkdir(source, 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(source, "data"), []byte("hello"), 0644))
+
+	ctx := context.Background()
+	_, err = service.Create(ctx, "test1", volume.DefaultDriverName, opts.WithCreateOptions(map[string]string{
+		"type":   "none",
+		"o":      "bind",
+		"device": source,
+	}))
+	assert.NilError(t, err)
+
+	v2, err := service.Clone(ctx, "test1", "test2")
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(v2.Options, 0))
+	assert.Check(t, v2.Mountpoint != source)
+	data, err := ioutil.ReadFile(filepath.Join(v2.Mountpoint, "data"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(data), "hello"))
+
+	// the clone is a copy, not another mount of the source
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(v2.Mountpoint, "data"), []byte("changed"), 0644))
+	data, err = ioutil.ReadFile(filepath.Join(source, "data"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(data), "hello"))
+}
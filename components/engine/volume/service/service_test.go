@@ -8,6 +8,7 @@ import (
 
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/volume"
 	volumedrivers "github.com/docker/docker/volume/drivers"
 	"github.com/docker/docker/volume/service/opts"
@@ -233,6 +234,105 @@ func TestServicePrune(t *testing.T) {
 	assert.Assert(t, is.Equal(pr.VolumesDeleted[0], "test"))
 }
 
+func TestServiceUsage(t *testing.T) {
+	t.Parallel()
+
+	ds := volumedrivers.NewStore(nil)
+	assert.Assert(t, ds.Register(testutils.NewFakeDriver(volume.DefaultDriverName), volume.DefaultDriverName))
+
+	service, cleanup := newTestService(t, ds)
+	defer cleanup()
+	ctx := context.Background()
+
+	_, err := service.Create(ctx, "test", volume.DefaultDriverName)
+	assert.NilError(t, err)
+
+	v, err := service.Get(ctx, "test", opts.WithGetResolveStatus)
+	assert.NilError(t, err)
+	assert.Assert(t, v.UsageData != nil)
+	assert.Check(t, is.Equal(v.UsageData.Size, int64(-1)))
+	assert.Check(t, is.Equal(v.UsageData.RefCount, int64(0)))
+	assert.Check(t, is.Equal(v.UsageData.LastUsedAt, v.CreatedAt))
+
+	service.vs.SetSize("test", 42)
+	_, err = service.Get(ctx, "test", opts.WithGetReference("container"))
+	assert.NilError(t, err)
+
+	ls, _, err := service.List(ctx, filters.NewArgs())
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(ls, 1))
+	assert.Assert(t, ls[0].UsageData != nil)
+	assert.Check(t, is.Equal(ls[0].UsageData.Size, int64(42)))
+	assert.Check(t, is.Equal(ls[0].UsageData.RefCount, int64(1)))
+	assert.Check(t, ls[0].UsageData.LastUsedAt != "")
+
+	assert.NilError(t, service.Release(ctx, "test", "container"))
+
+	_, err = service.Prune(ctx, filters.NewArgs(filters.Arg("unused-for", "banana")))
+	assert.Check(t, errdefs.IsInvalidParameter(err), err)
+	_, err = service.Prune(ctx, filters.NewArgs(filters.Arg("size>", "banana")))
+	assert.Check(t, errdefs.IsInvalidParameter(err), err)
+
+	pr, err := service.Prune(ctx, filters.NewArgs(filters.Arg("unused-for", "1h")))
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(pr.VolumesDeleted, 0))
+
+	// the size of the fake volume cannot be computed
+	pr, err = service.Prune(ctx, filters.NewArgs(filters.Arg("size>", "1KB")))
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(pr.VolumesDeleted, 0))
+
+	pr, err = service.Prune(ctx, filters.NewArgs(filters.Arg("unused-for", "0s")))
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(pr.VolumesDeleted, []string{"test"}))
+}
+
+func TestServiceCloneSnapshot(t *testing.T) {
+	t.Parallel()
+
+	ds := volumedrivers.NewStore(nil)
+	d := &fakeSnapshotDriver{Driver: testutils.NewFakeDriver("d1")}
+	assert.Assert(t, ds.Register(d, "d1"))
+
+	ctx := context.Background()
+	service, cleanup := newTestService(t, ds)
+	defer cleanup()
+
+	_, err := service.Create(ctx, "v1", "d1", opts.WithCreateOptions(map[string]string{"foo": "bar"}), opts.WithCreateLabels(map[string]string{"label": "value"}))
+	assert.NilError(t, err)
+
+	_, err = service.Clone(ctx, "notexist", "v2")
+	assert.Assert(t, IsNotExist(err), err)
+
+	_, err = service.Clone(ctx, "v1", "v1")
+	assert.Assert(t, errdefs.IsConflict(err), err)
+
+	v, err := service.Clone(ctx, "v1", "v2")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(d.cloned, []string{"v1"}))
+	assert.Check(t, is.Equal(v.Driver, "d1"))
+	assert.Check(t, is.DeepEqual(v.Options, map[string]string{"foo": "bar"}))
+	assert.Check(t, is.DeepEqual(v.Labels, map[string]string{"label": "value"}))
+
+	v, err = service.Clone(ctx, "v1", "v3", opts.WithCreateLabels(map[string]string{"other": "label"}))
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(v.Labels, map[string]string{"other": "label"}))
+
+	// the reference taken on the volumes while cloning must be released
+	assert.NilError(t, service.Remove(ctx, "v1"))
+	assert.NilError(t, service.Remove(ctx, "v2"))
+}
+
+type fakeSnapshotDriver struct {
+	volume.Driver
+	cloned []string
+}
+
+func (d *fakeSnapshotDriver) Clone(source volume.Volume, name string, opts map[string]string) (volume.Volume, error) {
+	d.cloned = append(d.cloned, source.Name())
+	return d.Create(name, opts)
+}
+
 func newTestService(t *testing.T, ds *volumedrivers.Store) (*VolumesService, func()) {
 	t.Helper()
 
@@ -241,7 +341,7 @@ func newTestService(t *testing.T, ds *volumedrivers.Store) (*VolumesService, fun
 
 	store, err := NewStore(dir, ds)
 	assert.NilError(t, err)
-	s := &VolumesService{vs: store, eventLogger: dummyEventLogger{}}
+	s := &VolumesService{vs: store, eventLogger: dummyEventLogger{}, idMapping: &idtools.IdentityMapping{}}
 	return s, func() {
 		assert.Check(t, s.Shutdown())
 		assert.Check(t, os.RemoveAll(dir))
@@ -79,6 +79,7 @@ func NewStore(rootPath string, drivers *drivers.Store) (*VolumeStore, error) {
 		options: make(map[string]map[string]string),
 		drivers: drivers,
 	}
+	vs.sizesCtx, vs.cancelSizes = context.WithCancel(context.Background())
 
 	if rootPath != "" {
 		// initialize metadata store
@@ -200,6 +201,11 @@ type VolumeStore struct {
 	// options stores volume options for each volume
 	options map[string]map[string]string
 	db      *bolt.DB
+	// sizes tracks the computations of the size of the released volumes,
+	// which are cancelled with cancelSizes on shutdown.
+	sizes       sync.WaitGroup
+	sizesCtx    context.Context
+	cancelSizes context.CancelFunc
 }
 
 func filterByDriver(names []string) filterFunc {
@@ -474,6 +480,9 @@ func (s *VolumeStore) Create(ctx context.Context, name, driverName string, creat
 	}
 
 	s.setNamed(v, cfg.Reference)
+	if cfg.Reference != "" {
+		s.markUsed(name)
+	}
 	return v, nil
 }
 
@@ -641,6 +650,9 @@ func (s *VolumeStore) Get(ctx context.Context, name string, getOptions ...opts.G
 		return nil, &OpErr{Name: name, Op: "get", Err: errdefs.Conflict(errors.New("found volume driver does not match passed in driver"))}
 	}
 	s.setNamed(v, cfg.Reference)
+	if cfg.Reference != "" {
+		s.markUsed(name)
+	}
 	return v, nil
 }
 
@@ -817,16 +829,27 @@ func (s *VolumeStore) Release(ctx context.Context, name string, ref string) erro
 	}
 
 	s.globalLock.Lock()
-	defer s.globalLock.Unlock()
-
 	select {
 	case <-ctx.Done():
+		s.globalLock.Unlock()
 		return ctx.Err()
 	default:
 	}
 
-	if s.refs[name] != nil {
-		delete(s.refs[name], ref)
+	refs, exists := s.refs[name]
+	if !exists {
+		s.globalLock.Unlock()
+		return nil
+	}
+	delete(refs, ref)
+	released := len(refs) == 0
+	s.globalLock.Unlock()
+
+	s.markUsed(name)
+	if released {
+		if v, exists := s.getNamed(name); exists {
+			s.recordSize(v)
+		}
 	}
 	return nil
 }
@@ -854,5 +877,9 @@ func unwrapVolume(v volume.Volume) volume.Volume {
 // Shutdown releases all resources used by the volume store
 // It does not make any changes to volumes, drivers, etc.
 func (s *VolumeStore) Shutdown() error {
+	if s.cancelSizes != nil {
+		s.cancelSizes()
+	}
+	s.sizes.Wait()
 	return s.db.Close()
 }
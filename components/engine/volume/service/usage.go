@@ -0,0 +1,141 @@
+package service // import "github.com/docker/docker/volume/service"
+
+import (
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/directory"
+	"github.com/docker/docker/volume"
+	units "github.com/docker/go-units"
+	"github.com/sirupsen/logrus"
+)
+
+// markUsed records the current time as the last time the named volume was
+// used.
+// Callers of this function are expected to hold the name lock.
+func (s *VolumeStore) markUsed(name string) {
+	if _, exists := s.getNamed(name); !exists {
+		return
+	}
+	if err := s.updateMeta(name, func(meta *volumeMetadata) {
+		meta.LastUsed = time.Now().UTC()
+	}); err != nil {
+		logrus.WithError(err).WithField("volume", name).Warn("Error recording last use of volume")
+	}
+}
+
+// SetUsed records the current time as the last time the named volume was
+// used.
+func (s *VolumeStore) SetUsed(name string) {
+	name = normalizeVolumeName(name)
+	s.locks.Lock(name)
+	defer s.locks.Unlock(name)
+	s.markUsed(name)
+}
+
+// SetSize records the size, in bytes, of the named volume.
+func (s *VolumeStore) SetSize(name string, size int64) {
+	name = normalizeVolumeName(name)
+	s.locks.Lock(name)
+	defer s.locks.Unlock(name)
+
+	if _, exists := s.getNamed(name); !exists {
+		return
+	}
+	if err := s.updateMeta(name, func(meta *volumeMetadata) {
+		meta.Size = &size
+	}); err != nil {
+		logrus.WithError(err).WithField("volume", name).Warn("Error recording size of volume")
+	}
+}
+
+// recordSize computes the size of a local volume in the background, and
+// records it. It is called when the last reference to the volume is
+// released, so that the recorded size is the size of the volume after it
+// was last used.
+func (s *VolumeStore) recordSize(v volume.Volume) {
+	if dv, ok := v.(volume.DetailedVolume); !ok || v.DriverName() != volume.DefaultDriverName || len(dv.Options()) > 0 {
+		return
+	}
+	s.sizes.Add(1)
+	go func() {
+		defer s.sizes.Done()
+		size, err := directory.Size(s.sizesCtx, v.Path())
+		if err != nil {
+			logrus.WithError(err).WithField("volume", v.Name()).Debug("Error computing size of released volume")
+			return
+		}
+		s.SetSize(v.Name(), size)
+	}()
+}
+
+// usage returns the recorded size of the volume, or -1 if it is not known,
+// and the last time the volume was used. The creation time of the volume is
+// returned if it was never used.
+func (s *VolumeStore) usage(v volume.Volume) (int64, time.Time) {
+	size := int64(-1)
+	meta, err := s.getMeta(normalizeVolumeName(v.Name()))
+	if err != nil {
+		logrus.WithError(err).WithField("volume", v.Name()).Debug("Error reading volume metadata")
+	}
+	if meta.Size != nil {
+		size = *meta.Size
+	}
+	lastUsed := meta.LastUsed
+	if lastUsed.IsZero() {
+		lastUsed, _ = v.CreatedAt()
+	}
+	return size, lastUsed
+}
+
+// usageData returns the usage details of the volume recorded in the store.
+func (s *VolumeStore) usageData(v volume.Volume) *types.VolumeUsageData {
+	size, lastUsed := s.usage(v)
+	data := &types.VolumeUsageData{
+		Size:     size,
+		RefCount: int64(s.CountReferences(v)),
+	}
+	if !lastUsed.IsZero() {
+		data.LastUsedAt = lastUsed.Format(time.RFC3339)
+	}
+	return data
+}
+
+// byUnusedFor returns a filter matching the volumes that were not used for
+// at least the duration set by the "unused-for" filter, if any.
+func (s *VolumeStore) byUnusedFor(filter filters.Args) (By, error) {
+	if !filter.Contains("unused-for") {
+		return nil, nil
+	}
+	values := filter.Get("unused-for")
+	if len(values) > 1 {
+		return nil, invalidFilter{"unused-for", values}
+	}
+	d, err := time.ParseDuration(values[0])
+	if err != nil || d < 0 {
+		return nil, invalidFilter{"unused-for", values[0]}
+	}
+	before := time.Now().Add(-d)
+	return CustomFilter(func(v volume.Volume) bool {
+		_, lastUsed := s.usage(v)
+		return !lastUsed.IsZero() && lastUsed.Before(before)
+	}), nil
+}
+
+// minSizeFromFilter returns the size, in bytes, set by the "size>" filter, or
+// -1 if the filter is not set.
+func minSizeFromFilter(filter filters.Args) (int64, error) {
+	if !filter.Contains("size>") {
+		return -1, nil
+	}
+	values := filter.Get("size>")
+	if len(values) > 1 {
+		return -1, invalidFilter{"size>", values}
+	}
+	size, err := units.FromHumanSize(values[0])
+	if err != nil || size < 0 {
+		return -1, invalidFilter{"size>", values[0]}
+	}
+	return size, nil
+}
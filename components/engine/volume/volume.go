@@ -39,6 +39,20 @@ type Capability struct {
 	// A `local` scope indicates that the driver only manages volumes resources local to the host
 	// Scope is declared by the driver
 	Scope string
+	// Snapshot indicates that the driver can copy volumes natively, for
+	// example by taking a snapshot of the source volume.
+	Snapshot bool
+}
+
+// SnapshotDriver is implemented by drivers which can copy volumes natively.
+// The data of the volumes of other drivers is copied as a tar archive.
+type SnapshotDriver interface {
+	Driver
+	// Clone creates a new volume with the given name and options, holding a
+	// copy of the data of the source volume. It returns an error for which
+	// errdefs.IsNotImplemented is true if the driver cannot copy the source
+	// volume natively.
+	Clone(source Volume, name string, opts map[string]string) (Volume, error)
 }
 
 // Volume is a place to store data. It is backed by a specific driver, and can be mounted.